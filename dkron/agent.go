@@ -101,6 +101,39 @@ type Agent struct {
 	shutdownCh  chan struct{}
 	retryJoinCh chan error
 
+	// Runs waiting for the next run window of their job, by job name
+	// and execution group
+	deferredRuns map[deferredRunKey]*time.Timer
+	deferredMu   sync.Mutex
+
+	// Runs waiting for a running execution of their job to finish, by job name
+	queue dispatchQueue
+
+	// Executions still to finish of the running job runs, by job and execution group
+	runs runTracker
+
+	// Resource pool slots taken by running executions
+	pools poolLedger
+
+	// Runs waiting for a saturated node to finish an execution, by node name
+	capacity dispatchQueue
+
+	// Executions running in this node
+	slots executionSlots
+
+	// Parent jobs that finished, by dependent job name
+	dependencies dependencyTracker
+
+	// Items of the running map jobs, by job and execution group
+	maps mapTracker
+
+	// Serializes the updates of the node tags
+	tagsMu sync.Mutex
+
+	// Last node picked for each job with the round robin selection strategy
+	roundRobin  map[string]string
+	selectionMu sync.Mutex
+
 	// The raft instance is used among Dkron nodes within the
 	// region to protect operations that require strong consistency
 	leaderCh <-chan bool
@@ -260,6 +293,11 @@ func (a *Agent) Start() error {
 		return fmt.Errorf("agent: Error setting tags: %w", err)
 	}
 
+	// Refresh the node tags from the facts providers
+	if providers := a.factsProviders(); len(providers) > 0 {
+		go a.factsLoop(providers)
+	}
+
 	go a.eventLoop()
 	a.ready = true
 
@@ -316,9 +354,12 @@ func (a *Agent) Stop() error {
 
 // UpdateTags updates the tag configuration for this agent
 func (a *Agent) UpdateTags(tags map[string]string) {
+	a.tagsMu.Lock()
+	defer a.tagsMu.Unlock()
+
 	// Preserve reserved tags
 	currentTags := a.serf.LocalMember().Tags
-	for _, tagName := range []string{"role", "version", "server", "bootstrap", "expect", "port", "rpc_addr"} {
+	for _, tagName := range []string{"role", "version", "server", "bootstrap", "expect", "port", "rpc_addr", maxExecutionsTag, runningExecutionsTag} {
 		if val, exists := currentTags[tagName]; exists {
 			tags[tagName] = val
 		}
@@ -512,6 +553,10 @@ func (a *Agent) setupSerf() (*serf.Serf, error) {
 	if a.config.BootstrapExpect != 0 {
 		serfConfig.Tags["expect"] = fmt.Sprintf("%d", a.config.BootstrapExpect)
 	}
+	if a.config.MaxConcurrentExecutions > 0 {
+		serfConfig.Tags[maxExecutionsTag] = strconv.Itoa(a.config.MaxConcurrentExecutions)
+		serfConfig.Tags[runningExecutionsTag] = "0"
+	}
 
 	switch config.Profile {
 	case "lan":
@@ -812,8 +857,28 @@ func defaultSelector(nodes []Node) int {
 	return rand.Intn(len(nodes))
 }
 
-// selectNodes selects at most #cardinality from the given nodes using the selectFunc
+// selectNodes selects at most #cardinality from the given nodes using the selectFunc,
+// skipping nodes running their maximum number of executions unless there are
+// not enough other nodes.
 func selectNodes(nodes []Node, cardinality int, selectFunc func([]Node) int) []Node {
+	free := make([]Node, 0, len(nodes))
+	var saturated []Node
+	for _, n := range nodes {
+		if nodeSaturated(n) {
+			saturated = append(saturated, n)
+		} else {
+			free = append(free, n)
+		}
+	}
+	if len(free) < cardinality && len(saturated) > 0 {
+		return append(free, pickNodes(saturated, cardinality-len(free), selectFunc)...)
+	}
+
+	return pickNodes(free, cardinality, selectFunc)
+}
+
+// pickNodes picks at most #cardinality from the given nodes using the selectFunc
+func pickNodes(nodes []Node, cardinality int, selectFunc func([]Node) int) []Node {
 	// Return all nodes immediately if they're all going to be selected
 	numNodes := len(nodes)
 	if numNodes <= cardinality {
@@ -877,6 +942,10 @@ func (a *Agent) applySetJob(job *typesv1.Job) error {
 		return ErrParentJobNotFound
 	case ErrSameParent:
 		return ErrParentJobNotFound
+	case ErrCalendarNotFound:
+		return ErrCalendarNotFound
+	case ErrPoolNotFound:
+		return ErrPoolNotFound
 	}
 
 	return nil
@@ -913,8 +982,12 @@ func (a *Agent) markExecutionDone(execution *Execution) error {
 	if af == nil {
 		return errors.New("raft apply unavailable")
 	}
+	if err := af.Error(); err != nil {
+		return err
+	}
 
-	return af.Error()
+	a.releasePoolSlots(execution)
+	return nil
 }
 
 func (a *Agent) cleanupStaleRunningExecutions(ctx context.Context, jobName string, activeExecutionKeys map[string]struct{}, logger *logrus.Entry, staleLogMessage string) ([]*Execution, error) {
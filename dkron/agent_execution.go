@@ -2,6 +2,7 @@ package dkron
 
 import (
 	"errors"
+	"time"
 
 	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
 )
@@ -23,3 +24,20 @@ func (a *Agent) applySetExecution(execution *typesv1.Execution) error {
 
 	return af.Error()
 }
+
+// recordSkippedExecution stores a finished execution flagged as skipped,
+// so runs that were not dispatched are visible in the job history.
+func (a *Agent) recordSkippedExecution(job *Job, reason string, metadata map[string]string) {
+	now := time.Now().UTC()
+	ex := NewExecution(job.Name)
+	ex.StartedAt = now
+	ex.FinishedAt = now
+	ex.NodeName = a.config.NodeName
+	ex.Skipped = true
+	ex.Output = reason
+	ex.Metadata = metadata
+
+	if err := a.applySetExecution(ex.ToProto()); err != nil {
+		a.logger.WithError(err).WithField("job", job.Name).Error("agent: Error storing skipped execution")
+	}
+}
@@ -1,6 +1,7 @@
 package dkron
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,8 +10,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/distribworks/dkron/v4/ntime"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/expvar"
 	"github.com/gin-gonic/gin"
@@ -20,6 +23,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/tidwall/buntdb"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -151,6 +155,12 @@ func (h *HTTPTransport) APIRoutes(r *gin.RouterGroup, middleware ...gin.HandlerF
 	v1.POST("/unpause", h.unpauseHandler)
 
 	v1.GET("/stats", h.statsHandler)
+	v1.GET("/queue", h.queueHandler)
+
+	v1.GET("/workflows/:root/runs", h.workflowRunsHandler)
+	v1.GET("/workflow-runs/:id", h.workflowRunHandler)
+
+	v1.POST("/schedule/preview", h.schedulePreviewHandler)
 
 	v1.POST("/jobs", h.jobCreateOrUpdateHandler)
 	v1.PATCH("/jobs", h.jobCreateOrUpdateHandler)
@@ -166,9 +176,37 @@ func (h *HTTPTransport) APIRoutes(r *gin.RouterGroup, middleware ...gin.HandlerF
 
 	// Place fallback routes last
 	jobs.GET("/:job", h.jobGetHandler)
+	jobs.GET("/:job/next", h.jobNextHandler)
 	jobs.GET("/:job/executions", h.executionsHandler)
 	jobs.DELETE("/:job/executions", h.executionsDeleteHandler)
 	jobs.GET("/:job/executions/:execution", h.executionHandler)
+
+	v1.POST("/calendars", h.calendarCreateOrUpdateHandler)
+	// Place fallback routes last
+	v1.GET("/calendars", h.calendarsHandler)
+
+	calendars := v1.Group("/calendars")
+	calendars.PUT("/:calendar", h.calendarCreateOrUpdateHandler)
+	calendars.DELETE("/:calendar", h.calendarDeleteHandler)
+	calendars.GET("/:calendar", h.calendarGetHandler)
+
+	v1.POST("/maintenance", h.maintenanceCreateOrUpdateHandler)
+	// Place fallback routes last
+	v1.GET("/maintenance", h.maintenanceWindowsHandler)
+
+	maintenance := v1.Group("/maintenance")
+	maintenance.PUT("/:window", h.maintenanceCreateOrUpdateHandler)
+	maintenance.DELETE("/:window", h.maintenanceDeleteHandler)
+	maintenance.GET("/:window", h.maintenanceGetHandler)
+
+	v1.POST("/pools", h.poolCreateOrUpdateHandler)
+	// Place fallback routes last
+	v1.GET("/pools", h.poolsHandler)
+
+	pools := v1.Group("/pools")
+	pools.PUT("/:pool", h.poolCreateOrUpdateHandler)
+	pools.DELETE("/:pool", h.poolDeleteHandler)
+	pools.GET("/:pool", h.poolGetHandler)
 }
 
 // MetaMiddleware adds middleware to the gin Context.
@@ -271,6 +309,195 @@ func (h *HTTPTransport) jobGetHandler(c *gin.Context) {
 	renderJSON(c, http.StatusOK, job)
 }
 
+// schedulePreviewRequest is the payload of a schedule preview,
+// the schedule related job fields and the range to preview.
+type schedulePreviewRequest struct {
+	Name            string             `json:"name"`
+	Schedule        string             `json:"schedule"`
+	Schedules       []*JobSchedule     `json:"schedules"`
+	ScheduleFormat  string             `json:"schedule_format"`
+	Timezone        string             `json:"timezone"`
+	DSTPolicy       string             `json:"dst_policy"`
+	StartsAt        ntime.NullableTime `json:"starts_at"`
+	ExpiresAt       ntime.NullableTime `json:"expires_at"`
+	Calendars       []string           `json:"calendars"`
+	RunWindows      []*RunWindow       `json:"run_windows"`
+	RunWindowPolicy string             `json:"run_window_policy"`
+	Count           int                `json:"count"`
+	From            time.Time          `json:"from"`
+}
+
+// ScheduledRun is a fire time of a schedule preview.
+type ScheduledRun struct {
+	Time time.Time `json:"time"`
+
+	// Set when the time is outside of the job run windows, the run
+	// is then skipped or deferred following the run window policy.
+	OutsideRunWindow bool `json:"outside_run_window,omitempty"`
+}
+
+// scheduledRuns returns the preview of the given fire times of the job.
+func (j *Job) scheduledRuns(times []time.Time) []*ScheduledRun {
+	runs := make([]*ScheduledRun, len(times))
+	for i, t := range times {
+		runs[i] = &ScheduledRun{
+			Time:             t,
+			OutsideRunWindow: !j.inRunWindow(t),
+		}
+	}
+	return runs
+}
+
+// previewCount returns the number of fire times to preview
+// for the requested count, within the allowed limits.
+func previewCount(count int) int {
+	if count <= 0 {
+		return DefaultPreviewCount
+	}
+	if count > MaxPreviewCount {
+		return MaxPreviewCount
+	}
+	return count
+}
+
+func (h *HTTPTransport) jobNextHandler(c *gin.Context) {
+	jobName := c.Param("job")
+
+	job, err := h.agent.Store.GetJob(c.Request.Context(), jobName, nil)
+	if err != nil {
+		if err != buntdb.ErrNotFound {
+			h.logger.Error(err)
+		}
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	count := 0
+	if q := c.Query("count"); q != "" {
+		if count, err = strconv.Atoi(q); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(fmt.Sprintf("Invalid count: %s.", err))
+			return
+		}
+	}
+
+	from := time.Now()
+	if q := c.Query("from"); q != "" {
+		if from, err = time.Parse(time.RFC3339, q); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(fmt.Sprintf("Invalid from: %s.", err))
+			return
+		}
+	}
+
+	runs, err := job.NextRuns(from, previewCount(count), h.agent.getCalendars(c.Request.Context(), job))
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("%s: %s.", ErrScheduleParse.Error(), err))
+		return
+	}
+
+	renderJSON(c, http.StatusOK, job.scheduledRuns(runs))
+}
+
+func (h *HTTPTransport) schedulePreviewHandler(c *gin.Context) {
+	var req schedulePreviewRequest
+	if err := c.BindJSON(&req); err != nil {
+		h.logger.Error(err)
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		return
+	}
+
+	job := &Job{
+		Name:            req.Name,
+		Schedule:        req.Schedule,
+		Schedules:       req.Schedules,
+		ScheduleFormat:  req.ScheduleFormat,
+		Timezone:        req.Timezone,
+		DSTPolicy:       req.DSTPolicy,
+		StartsAt:        req.StartsAt,
+		ExpiresAt:       req.ExpiresAt,
+		Calendars:       req.Calendars,
+		RunWindows:      req.RunWindows,
+		RunWindowPolicy: req.RunWindowPolicy,
+	}
+
+	if !job.hasSchedule() {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString("Schedule cannot be empty.")
+		return
+	}
+	for i, s := range job.Schedules {
+		if s == nil || s.Schedule == "" {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(fmt.Sprintf("%s: schedules[%d]: schedule cannot be empty.", ErrScheduleParse.Error(), i))
+			return
+		}
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(fmt.Sprintf("Invalid timezone: schedules[%d]: %s.", i, err))
+			return
+		}
+	}
+	if _, err := time.LoadLocation(job.Timezone); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Invalid timezone: %s.", err))
+		return
+	}
+	if job.ScheduleFormat != "" && job.ScheduleFormat != ScheduleFormatDkron && job.ScheduleFormat != ScheduleFormatCrontab {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(ErrWrongScheduleFormat.Error())
+		return
+	}
+	switch job.DSTPolicy {
+	case "", DSTSkip, DSTRunOnce, DSTRunTwice, DSTShiftForward:
+	default:
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(ErrWrongDSTPolicy.Error())
+		return
+	}
+	switch job.RunWindowPolicy {
+	case "", RunWindowSkip, RunWindowDefer:
+	default:
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(ErrWrongRunWindowPolicy.Error())
+		return
+	}
+	for i, w := range job.RunWindows {
+		if err := w.validate(); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(fmt.Sprintf("Invalid run_windows[%d]: %s.", i, err))
+			return
+		}
+	}
+
+	var calendars []*Calendar
+	for _, name := range job.Calendars {
+		cal, err := h.agent.Store.GetCalendar(c.Request.Context(), name)
+		if err != nil {
+			c.AbortWithStatus(http.StatusNotFound)
+			_, _ = c.Writer.WriteString(ErrCalendarNotFound.Error())
+			return
+		}
+		calendars = append(calendars, cal)
+	}
+
+	from := req.From
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	runs, err := job.NextRuns(from, previewCount(req.Count), calendars)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("%s: %s.", ErrScheduleParse.Error(), err))
+		return
+	}
+
+	renderJSON(c, http.StatusOK, job.scheduledRuns(runs))
+}
+
 func (h *HTTPTransport) jobCreateOrUpdateHandler(c *gin.Context) {
 	// Check if new job submissions are paused
 	if h.agent.IsNewJobsPaused() {
@@ -312,13 +539,21 @@ func (h *HTTPTransport) jobCreateOrUpdateHandler(c *gin.Context) {
 		_, _ = c.Writer.WriteString(fmt.Sprintf("Job validation failed: %s.", err))
 		return
 	}
+	if err := job.validateJitter(time.Now()); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Job validation failed: %s.", err))
+		return
+	}
 
 	// Call gRPC SetJob
 	if err := h.agent.GRPCClient.SetJob(&job); err != nil {
 		s := status.Convert(err)
 
-		if s.Message() == ErrParentJobNotFound.Error() {
+		if s.Message() == ErrParentJobNotFound.Error() || s.Message() == ErrCalendarNotFound.Error() ||
+			s.Message() == ErrPoolNotFound.Error() {
 			c.Status(http.StatusNotFound)
+		} else if s.Message() == ErrDependencyCycle.Error() {
+			c.Status(http.StatusBadRequest)
 		} else {
 			c.Status(http.StatusInternalServerError)
 		}
@@ -330,7 +565,7 @@ func (h *HTTPTransport) jobCreateOrUpdateHandler(c *gin.Context) {
 	// Immediately run the job if so requested
 	if _, exists := c.GetQuery("runoncreate"); exists {
 		go func() {
-			if _, err := h.agent.GRPCClient.RunJob(job.Name); err != nil {
+			if _, err := h.agent.GRPCClient.RunJob(job.Name, nil); err != nil {
 				h.logger.WithError(err).Error("api: Unable to run job.")
 			}
 		}()
@@ -355,9 +590,35 @@ func (h *HTTPTransport) jobDeleteHandler(c *gin.Context) {
 func (h *HTTPTransport) jobRunHandler(c *gin.Context) {
 	jobName := c.Param("job")
 
+	// The body optionally overrides the nodes to run the job on
+	var target *RunTarget
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		target = &RunTarget{}
+		if err := json.Unmarshal(body, target); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+			return
+		}
+		if err := target.Validate(); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(fmt.Sprintf("Run target validation failed: %s.", err))
+			return
+		}
+	}
+
 	// Call gRPC RunJob
-	job, err := h.agent.GRPCClient.RunJob(jobName)
+	job, err := h.agent.GRPCClient.RunJob(jobName, target)
 	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.AbortWithStatus(http.StatusBadRequest)
+			_, _ = c.Writer.WriteString(status.Convert(err).Message())
+			return
+		}
 		_ = c.AbortWithError(http.StatusNotFound, err)
 		return
 	}
@@ -367,6 +628,262 @@ func (h *HTTPTransport) jobRunHandler(c *gin.Context) {
 	renderJSON(c, http.StatusOK, job)
 }
 
+func (h *HTTPTransport) calendarsHandler(c *gin.Context) {
+	calendars, err := h.agent.Store.GetCalendars(c.Request.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("api: Unable to get calendars, store not reachable.")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(len(calendars)))
+	renderJSON(c, http.StatusOK, calendars)
+}
+
+func (h *HTTPTransport) calendarGetHandler(c *gin.Context) {
+	calendarName := c.Param("calendar")
+
+	calendar, err := h.agent.Store.GetCalendar(c.Request.Context(), calendarName)
+	if err != nil {
+		if err != buntdb.ErrNotFound {
+			h.logger.Error(err)
+		}
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	renderJSON(c, http.StatusOK, calendar)
+}
+
+func (h *HTTPTransport) calendarCreateOrUpdateHandler(c *gin.Context) {
+	var calendar Calendar
+	if err := c.BindJSON(&calendar); err != nil {
+		h.logger.Error(err)
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		return
+	}
+	if name := c.Param("calendar"); name != "" {
+		calendar.Name = name
+	}
+
+	if err := calendar.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Calendar validation failed: %s.", err))
+		return
+	}
+
+	// Call gRPC SetCalendar
+	if err := h.agent.GRPCClient.SetCalendar(&calendar); err != nil {
+		s := status.Convert(err)
+		c.Status(http.StatusInternalServerError)
+		_, _ = c.Writer.WriteString(s.Message())
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/%s/calendars/%s", apiPathPrefix, calendar.Name))
+	renderJSON(c, http.StatusCreated, &calendar)
+}
+
+func (h *HTTPTransport) calendarDeleteHandler(c *gin.Context) {
+	calendarName := c.Param("calendar")
+
+	// Call gRPC DeleteCalendar
+	calendar, err := h.agent.GRPCClient.DeleteCalendar(calendarName)
+	if err != nil {
+		s := status.Convert(err)
+		if s.Message() == ErrCalendarInUse.Error() {
+			c.Status(http.StatusConflict)
+		} else {
+			c.Status(http.StatusNotFound)
+		}
+		_, _ = c.Writer.WriteString(s.Message())
+		return
+	}
+	renderJSON(c, http.StatusOK, calendar)
+}
+
+func (h *HTTPTransport) maintenanceWindowsHandler(c *gin.Context) {
+	windows, err := h.agent.Store.GetMaintenanceWindows(c.Request.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("api: Unable to get maintenance windows, store not reachable.")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(len(windows)))
+	renderJSON(c, http.StatusOK, windows)
+}
+
+func (h *HTTPTransport) maintenanceGetHandler(c *gin.Context) {
+	name := c.Param("window")
+
+	mw, err := h.agent.Store.GetMaintenanceWindow(c.Request.Context(), name)
+	if err != nil {
+		if err != buntdb.ErrNotFound {
+			h.logger.Error(err)
+		}
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	renderJSON(c, http.StatusOK, mw)
+}
+
+func (h *HTTPTransport) maintenanceCreateOrUpdateHandler(c *gin.Context) {
+	var mw MaintenanceWindow
+	if err := c.BindJSON(&mw); err != nil {
+		h.logger.Error(err)
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		return
+	}
+	if name := c.Param("window"); name != "" {
+		mw.Name = name
+	}
+
+	if err := mw.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Maintenance window validation failed: %s.", err))
+		return
+	}
+
+	// Call gRPC SetMaintenanceWindow
+	if err := h.agent.GRPCClient.SetMaintenanceWindow(&mw); err != nil {
+		s := status.Convert(err)
+		c.Status(http.StatusInternalServerError)
+		_, _ = c.Writer.WriteString(s.Message())
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/%s/maintenance/%s", apiPathPrefix, mw.Name))
+	renderJSON(c, http.StatusCreated, &mw)
+}
+
+func (h *HTTPTransport) maintenanceDeleteHandler(c *gin.Context) {
+	name := c.Param("window")
+
+	// Call gRPC DeleteMaintenanceWindow
+	mw, err := h.agent.GRPCClient.DeleteMaintenanceWindow(name)
+	if err != nil {
+		s := status.Convert(err)
+		c.Status(http.StatusNotFound)
+		_, _ = c.Writer.WriteString(s.Message())
+		return
+	}
+	renderJSON(c, http.StatusOK, mw)
+}
+
+func (h *HTTPTransport) queueHandler(c *gin.Context) {
+	runs := h.agent.QueuedRuns()
+
+	c.Header("X-Total-Count", strconv.Itoa(len(runs)))
+	renderJSON(c, http.StatusOK, runs)
+}
+
+func (h *HTTPTransport) workflowRunsHandler(c *gin.Context) {
+	runs, err := h.agent.WorkflowRuns(c.Request.Context(), c.Param("root"))
+	if err != nil {
+		if err == buntdb.ErrNotFound {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		h.logger.WithError(err).Error("api: Unable to get workflow runs, store not reachable.")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(len(runs)))
+	renderJSON(c, http.StatusOK, runs)
+}
+
+func (h *HTTPTransport) workflowRunHandler(c *gin.Context) {
+	run, err := h.agent.GetWorkflowRun(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == ErrWorkflowRunNotFound {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		h.logger.WithError(err).Error("api: Unable to get workflow run, store not reachable.")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, run)
+}
+
+func (h *HTTPTransport) poolsHandler(c *gin.Context) {
+	pools, err := h.agent.Store.GetPools(c.Request.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("api: Unable to get pools, store not reachable.")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(len(pools)))
+	renderJSON(c, http.StatusOK, pools)
+}
+
+func (h *HTTPTransport) poolGetHandler(c *gin.Context) {
+	name := c.Param("pool")
+
+	pool, err := h.agent.Store.GetPool(c.Request.Context(), name)
+	if err != nil {
+		if err != buntdb.ErrNotFound {
+			h.logger.Error(err)
+		}
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	renderJSON(c, http.StatusOK, pool)
+}
+
+func (h *HTTPTransport) poolCreateOrUpdateHandler(c *gin.Context) {
+	var pool Pool
+	if err := c.BindJSON(&pool); err != nil {
+		h.logger.Error(err)
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		return
+	}
+	if name := c.Param("pool"); name != "" {
+		pool.Name = name
+	}
+
+	if err := pool.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		_, _ = c.Writer.WriteString(fmt.Sprintf("Pool validation failed: %s.", err))
+		return
+	}
+
+	// Call gRPC SetPool
+	if err := h.agent.GRPCClient.SetPool(&pool); err != nil {
+		s := status.Convert(err)
+		c.Status(http.StatusInternalServerError)
+		_, _ = c.Writer.WriteString(s.Message())
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/%s/pools/%s", apiPathPrefix, pool.Name))
+	renderJSON(c, http.StatusCreated, &pool)
+}
+
+func (h *HTTPTransport) poolDeleteHandler(c *gin.Context) {
+	name := c.Param("pool")
+
+	// Call gRPC DeletePool
+	pool, err := h.agent.GRPCClient.DeletePool(name)
+	if err != nil {
+		s := status.Convert(err)
+		if s.Message() == ErrPoolInUse.Error() {
+			c.Status(http.StatusConflict)
+		} else {
+			c.Status(http.StatusNotFound)
+		}
+		_, _ = c.Writer.WriteString(s.Message())
+		return
+	}
+	renderJSON(c, http.StatusOK, pool)
+}
+
 // Restore jobs from file.
 // Overwrite job if the job is exist.
 func (h *HTTPTransport) restoreHandler(c *gin.Context) {
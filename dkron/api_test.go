@@ -687,3 +687,156 @@ func TestPrometheusMetricsEndpoint(t *testing.T) {
 	assert.Empty(t, resp.Header.Get("Content-Encoding"))
 	assert.Contains(t, string(body), "dkron_")
 }
+
+func TestAPISchedulePreview(t *testing.T) {
+	port := getFreePort(t)
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop() // nolint: errcheck
+
+	jsonStr := []byte(`{
+		"name": "preview_job",
+		"schedule": "0 0 ~ * * *",
+		"timezone": "Europe/Madrid",
+		"count": 3,
+		"from": "2024-01-01T00:00:00Z"
+	}`)
+	resp, err := http.Post(baseURL+"/schedule/preview", "application/json", bytes.NewBuffer(jsonStr))
+	require.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var runs []*ScheduledRun
+	require.NoError(t, json.Unmarshal(body, &runs))
+	require.Len(t, runs, 3)
+	hour := (&Job{Name: "preview_job"}).nameHash() % 24
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	for i, r := range runs {
+		assert.Equal(t, hour, r.Time.In(madrid).Hour())
+		assert.Equal(t, i+1, r.Time.In(madrid).Day())
+		assert.False(t, r.OutsideRunWindow)
+	}
+
+	// Additional schedules are merged and run windows marked
+	jsonStr = []byte(`{
+		"schedule": "0 0 9 * * *",
+		"schedules": [{"schedule": "0 0 21 * * *"}],
+		"run_windows": [{"start": "08:00", "end": "12:00"}],
+		"count": 4,
+		"from": "2024-01-01T00:00:00Z"
+	}`)
+	resp, err = http.Post(baseURL+"/schedule/preview", "application/json", bytes.NewBuffer(jsonStr))
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	require.NoError(t, json.Unmarshal(body, &runs))
+	require.Len(t, runs, 4)
+	for i, r := range runs {
+		hour := 9
+		if i%2 == 1 {
+			hour = 21
+		}
+		assert.Equal(t, hour, r.Time.Hour())
+		assert.Equal(t, hour == 21, r.OutsideRunWindow)
+	}
+
+	// Only additional schedules
+	jsonStr = []byte(`{"schedules": [{"schedule": "@daily"}], "count": 2}`)
+	resp, err = http.Post(baseURL+"/schedule/preview", "application/json", bytes.NewBuffer(jsonStr))
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.NoError(t, json.Unmarshal(body, &runs))
+	assert.Len(t, runs, 2)
+
+	// Bad run windows are rejected
+	resp, err = http.Post(baseURL+"/schedule/preview", "application/json", bytes.NewBuffer([]byte(`{"schedule": "@daily", "run_windows": [{"start": "25:00", "end": "08:00"}]}`)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Bad schedules are rejected
+	resp, err = http.Post(baseURL+"/schedule/preview", "application/json", bytes.NewBuffer([]byte(`{"schedule": "bad"}`)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Preview of a stored job
+	jsonStr = []byte(`{
+		"name": "test_job",
+		"schedule": "@every 1m",
+		"executor": "shell",
+		"executor_config": {"command": "date"},
+		"disabled": true
+	}`)
+	resp, err = http.Post(baseURL+"/jobs", "application/json", bytes.NewBuffer(jsonStr))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "/jobs/test_job/next?count=5&from=2024-01-01T00:00:00Z")
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.NoError(t, json.Unmarshal(body, &runs))
+	assert.Len(t, runs, 5)
+	assert.True(t, runs[0].Time.Equal(time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)))
+
+	resp, err = http.Get(baseURL + "/jobs/notajob/next")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPIJobRunTarget(t *testing.T) {
+	port := getFreePort(t)
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop() // nolint: errcheck
+
+	// No node matches the job tags
+	resp, err := http.Post(baseURL+"/jobs", "application/json", bytes.NewBuffer([]byte(`{
+		"name": "test_job",
+		"schedule": "@every 1h",
+		"executor": "shell",
+		"executor_config": {"command": "date"},
+		"tags": {"role": "canary"}
+	}`)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	run := func(body string) int {
+		resp, err := http.Post(baseURL+"/jobs/test_job/run", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusNotFound, run(""))
+	assert.Equal(t, http.StatusBadRequest, run(`{"nodes": ["test"], "tags": {"role": "web"}}`))
+	assert.Equal(t, http.StatusBadRequest, run(`{"tags": {"cpus": ">= many"}}`))
+	assert.Equal(t, http.StatusNotFound, run(`{"nodes": ["missing"]}`))
+
+	// Runs on the requested node, recording it in the execution
+	assert.Equal(t, http.StatusOK, run(`{"nodes": ["test"]}`))
+	assert.Eventually(t, func() bool {
+		execs, err := a.Store.GetExecutions(context.Background(), "test_job", &ExecutionOptions{})
+		return err == nil && len(execs) == 1 &&
+			execs[0].NodeName == "test" && execs[0].Metadata[targetNodesMetadata] == "test"
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// and on the nodes matching the override tags
+	assert.Equal(t, http.StatusOK, run(`{"tags": {"region": "global"}}`))
+	assert.Eventually(t, func() bool {
+		execs, err := a.Store.GetExecutions(context.Background(), "test_job", &ExecutionOptions{})
+		return err == nil && len(execs) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+}
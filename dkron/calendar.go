@@ -0,0 +1,310 @@
+package dkron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/distribworks/dkron/v4/extcron"
+	proto "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/robfig/cron/v3"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// calendarDateLayout is the layout of the dates in a calendar date list.
+	calendarDateLayout = "2006-01-02"
+	// exdateDateLayout is the layout of RFC 5545 DATE values.
+	exdateDateLayout = "20060102"
+	// exdateDateTimeLayout is the layout of RFC 5545 DATE-TIME values.
+	exdateDateTimeLayout = "20060102T150405"
+
+	// maxCalendarSkips limits how many excluded ranges are skipped
+	// looking for the next fire time of a job.
+	maxCalendarSkips = 1000
+)
+
+var (
+	// ErrCalendarNotFound is returned when a job references a calendar that doesn't exist.
+	ErrCalendarNotFound = errors.New("specified calendar not found")
+	// ErrCalendarInUse is returned when deleting a calendar that jobs still reference.
+	ErrCalendarInUse = errors.New("store: could not delete calendar used by jobs, remove it from the jobs first")
+)
+
+// Calendar describes a set of days in which the jobs that reference it
+// are not executed, like holidays or blackout periods.
+type Calendar struct {
+	// Calendar name. Must be unique, acts as the id.
+	Name string `json:"name"`
+
+	// Description of the calendar.
+	Description string `json:"description"`
+
+	// Excluded dates in YYYY-MM-DD format.
+	Dates []string `json:"dates"`
+
+	// Excluded days of the week (sunday, monday, ...).
+	ExcludedWeekdays []string `json:"excluded_weekdays"`
+
+	// RFC 5545 EXDATE style values. Accepts dates (20241225), date-times
+	// (20241225T090000Z) and periods of them separated by a slash, with an
+	// end or an ISO8601 duration (20241224/20241226, 20241231T180000Z/PT12H).
+	Exdates []string `json:"exdates"`
+}
+
+// NewCalendarFromProto creates a new Calendar from a PB Calendar struct
+func NewCalendarFromProto(in *proto.Calendar) *Calendar {
+	return &Calendar{
+		Name:             in.Name,
+		Description:      in.Description,
+		Dates:            in.Dates,
+		ExcludedWeekdays: in.ExcludedWeekdays,
+		Exdates:          in.Exdates,
+	}
+}
+
+// ToProto returns the corresponding representation of this Calendar in proto struct
+func (c *Calendar) ToProto() *proto.Calendar {
+	return &proto.Calendar{
+		Name:             c.Name,
+		Description:      c.Description,
+		Dates:            c.Dates,
+		ExcludedWeekdays: c.ExcludedWeekdays,
+		Exdates:          c.Exdates,
+	}
+}
+
+// Validate validates whether all values in the calendar are acceptable.
+func (c *Calendar) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	if valid, chr := isSlug(c.Name); !valid {
+		return fmt.Errorf("name contains illegal character '%s'", chr)
+	}
+
+	for _, d := range c.Dates {
+		if _, err := time.Parse(calendarDateLayout, d); err != nil {
+			return fmt.Errorf("invalid calendar date %s: %s", d, err)
+		}
+	}
+
+	for _, wd := range c.ExcludedWeekdays {
+		if _, err := parseWeekday(wd); err != nil {
+			return err
+		}
+	}
+
+	for _, exdate := range c.Exdates {
+		if _, _, err := parseExdate(exdate, time.UTC); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Excludes returns whether the given time, evaluated in loc, falls inside
+// a range excluded by the calendar, and the end of that range.
+func (c *Calendar) Excludes(t time.Time, loc *time.Location) (bool, time.Time) {
+	t = t.In(loc)
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	day := t.Format(calendarDateLayout)
+	for _, d := range c.Dates {
+		if d == day {
+			return true, dayEnd
+		}
+	}
+
+	for _, wd := range c.ExcludedWeekdays {
+		if w, err := parseWeekday(wd); err == nil && w == t.Weekday() {
+			return true, dayEnd
+		}
+	}
+
+	for _, exdate := range c.Exdates {
+		start, end, err := parseExdate(exdate, loc)
+		if err != nil {
+			continue
+		}
+		if !t.Before(start) && t.Before(end) {
+			return true, end
+		}
+	}
+
+	return false, time.Time{}
+}
+
+// parseWeekday parses a day of the week by its english name or abbreviation.
+func parseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid calendar weekday %s", s)
+}
+
+// parseExdate parses an EXDATE style value and returns the range it
+// excludes. Date values exclude the whole day, date-time values exclude
+// a single second and periods exclude everything in between, both ends
+// included for dates.
+func parseExdate(s string, loc *time.Location) (time.Time, time.Time, error) {
+	parts := strings.SplitN(s, "/", 2)
+
+	start, isDate, err := parseExdateValue(parts[0], loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid calendar exdate %s: %s", s, err)
+	}
+
+	if len(parts) == 1 {
+		if isDate {
+			return start, start.AddDate(0, 0, 1), nil
+		}
+		return start, start.Add(time.Second), nil
+	}
+
+	if strings.HasPrefix(parts[1], "P") {
+		d, err := extcron.ParseISO8601Duration(parts[1])
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid calendar exdate %s: %s", s, err)
+		}
+		return start, start.Add(d), nil
+	}
+
+	end, endIsDate, err := parseExdateValue(parts[1], loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid calendar exdate %s: %s", s, err)
+	}
+	if endIsDate {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid calendar exdate %s: end must be after start", s)
+	}
+
+	return start, end, nil
+}
+
+// parseExdateValue parses a RFC 5545 DATE or DATE-TIME value, floating
+// values are evaluated in loc.
+func parseExdateValue(s string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(exdateDateLayout, s, loc); err == nil {
+		return t, true, nil
+	}
+	if strings.HasSuffix(s, "Z") {
+		t, err := time.Parse(exdateDateTimeLayout+"Z", s)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation(exdateDateTimeLayout, s, loc)
+	return t, false, err
+}
+
+// excludingCalendar returns the first calendar that excludes the given time
+// and the end of the excluded range, or nil if the time is not excluded.
+func excludingCalendar(calendars []*Calendar, t time.Time, loc *time.Location) (*Calendar, time.Time) {
+	for _, c := range calendars {
+		if ok, end := c.Excludes(t, loc); ok {
+			return c, end
+		}
+	}
+	return nil, time.Time{}
+}
+
+// calendarSchedule wraps a cron schedule skipping the fire times
+// excluded by any of its calendars.
+type calendarSchedule struct {
+	cron.Schedule
+	calendars []*Calendar
+	loc       *time.Location
+}
+
+// Next conforms to the Schedule interface.
+func (s calendarSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t)
+	for i := 0; i < maxCalendarSkips && !next.IsZero(); i++ {
+		c, end := excludingCalendar(s.calendars, next, s.loc)
+		if c == nil {
+			return next
+		}
+		next = s.Schedule.Next(end.Add(-time.Nanosecond))
+	}
+	return time.Time{}
+}
+
+// schedule parses the job schedule, wrapping it to follow its DST policy
+// and to skip the fire times excluded by the given calendars. Jobs with
+// additional schedules fire at the fire times of all of them.
+func (j *Job) schedule(calendars []*Calendar) (cron.Schedule, error) {
+	if len(j.Schedules) > 0 {
+		var ms multiSchedule
+		for _, job := range j.scheduleJobs() {
+			s, err := job.schedule(calendars)
+			if err != nil {
+				return nil, err
+			}
+			ms = append(ms, s)
+		}
+		return ms, nil
+	}
+
+	s, err := extcron.Parse(j.cronSpec())
+	if err != nil {
+		return nil, err
+	}
+	if ea, ok := s.(*extcron.EveryAfterSchedule); ok {
+		ea.Anchor = j.lastFinished()
+	}
+	s = withDSTPolicy(s, j.DSTPolicy)
+	if len(calendars) == 0 {
+		return s, nil
+	}
+	return calendarSchedule{Schedule: s, calendars: calendars, loc: j.location()}, nil
+}
+
+// nextRun returns t if no calendar excludes it and the DST policy doesn't
+// skip it, otherwise the first fire time after t that is not excluded.
+func (j *Job) nextRun(t time.Time, calendars []*Calendar) time.Time {
+	if c, _ := excludingCalendar(calendars, t, j.location()); c == nil && !j.dstSkipped(t) {
+		return t
+	}
+	s, err := j.schedule(calendars)
+	if err != nil {
+		return t
+	}
+	return s.Next(t)
+}
+
+// location returns the job time location, UTC if not valid.
+func (j *Job) location() *time.Location {
+	if loc := j.GetTimeLocation(); loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// getCalendars returns the calendars referenced by the job,
+// skipping the ones that don't exist anymore.
+func (a *Agent) getCalendars(ctx context.Context, job *Job) []*Calendar {
+	var calendars []*Calendar
+	for _, name := range job.Calendars {
+		c, err := a.Store.GetCalendar(ctx, name)
+		if err != nil {
+			if err != buntdb.ErrNotFound {
+				a.logger.WithError(err).WithField("calendar", name).Error("agent: Error retrieving calendar")
+			} else {
+				a.logger.WithField("job", job.Name).WithField("calendar", name).Warn("agent: Calendar not found")
+			}
+			continue
+		}
+		calendars = append(calendars, c)
+	}
+	return calendars
+}
@@ -0,0 +1,189 @@
+package dkron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestCalendarExcludes(t *testing.T) {
+	cal := &Calendar{
+		Name:             "holidays",
+		Dates:            []string{"2024-12-25"},
+		ExcludedWeekdays: []string{"saturday", "Sun"},
+		Exdates: []string{
+			"20240101",
+			"20240315T090000Z",
+			"20240401/20240402",
+			"20240501T180000Z/PT12H",
+		},
+	}
+	require.NoError(t, cal.Validate())
+
+	testCases := []struct {
+		name     string
+		time     time.Time
+		excluded bool
+		end      time.Time
+	}{
+		{
+			name:     "date list",
+			time:     time.Date(2024, 12, 25, 10, 0, 0, 0, time.UTC),
+			excluded: true,
+			end:      time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekday",
+			time:     time.Date(2024, 3, 16, 10, 0, 0, 0, time.UTC),
+			excluded: true,
+			end:      time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exdate date",
+			time:     time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC),
+			excluded: true,
+			end:      time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exdate date-time",
+			time:     time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+			excluded: true,
+			end:      time.Date(2024, 3, 15, 9, 0, 1, 0, time.UTC),
+		},
+		{
+			name: "exdate date-time other time",
+			time: time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exdate date period includes end day",
+			time:     time.Date(2024, 4, 2, 12, 0, 0, 0, time.UTC),
+			excluded: true,
+			end:      time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exdate duration period",
+			time:     time.Date(2024, 5, 2, 5, 0, 0, 0, time.UTC),
+			excluded: true,
+			end:      time.Date(2024, 5, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "working day",
+			time: time.Date(2024, 3, 14, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			excluded, end := cal.Excludes(tc.time, time.UTC)
+			assert.Equal(t, tc.excluded, excluded)
+			if tc.excluded {
+				assert.True(t, tc.end.Equal(end), "expected %s, got %s", tc.end, end)
+			}
+		})
+	}
+}
+
+func TestCalendarExcludesLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	cal := &Calendar{Name: "holidays", Dates: []string{"2024-07-04"}}
+
+	// 02:00 UTC on the 5th is still the 4th in New York
+	excluded, _ := cal.Excludes(time.Date(2024, 7, 5, 2, 0, 0, 0, time.UTC), loc)
+	assert.True(t, excluded)
+
+	excluded, _ = cal.Excludes(time.Date(2024, 7, 5, 2, 0, 0, 0, time.UTC), time.UTC)
+	assert.False(t, excluded)
+}
+
+func TestCalendarValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		calendar *Calendar
+	}{
+		{"empty name", &Calendar{}},
+		{"invalid name", &Calendar{Name: "bad name"}},
+		{"invalid date", &Calendar{Name: "c", Dates: []string{"25/12/2024"}}},
+		{"invalid weekday", &Calendar{Name: "c", ExcludedWeekdays: []string{"someday"}}},
+		{"invalid exdate", &Calendar{Name: "c", Exdates: []string{"2024-12-25"}}},
+		{"invalid exdate period", &Calendar{Name: "c", Exdates: []string{"20241226/20241224"}}},
+		{"invalid exdate duration", &Calendar{Name: "c", Exdates: []string{"20241224/P1X"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Error(t, tc.calendar.Validate())
+		})
+	}
+}
+
+func TestJobGetNextCalendars(t *testing.T) {
+	now := time.Now().UTC()
+	tomorrow := now.AddDate(0, 0, 1)
+
+	job := &Job{
+		Name:     "calendar_job",
+		Schedule: "0 0 12 * * *",
+		Timezone: "UTC",
+	}
+	cal := &Calendar{
+		Name: "holidays",
+		Dates: []string{
+			now.Format(calendarDateLayout),
+			tomorrow.Format(calendarDateLayout),
+		},
+	}
+
+	next, err := job.GetNext([]*Calendar{cal})
+	require.NoError(t, err)
+
+	expected := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.UTC).AddDate(0, 0, 2)
+	assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+}
+
+func TestStoreCalendars(t *testing.T) {
+	s, err := NewStore(getTestLogger(), otel.Tracer("test"))
+	require.NoError(t, err)
+	defer s.Shutdown() // nolint: errcheck
+
+	ctx := context.Background()
+	job := &Job{
+		Name:      "calendar_job",
+		Schedule:  "@every 1h",
+		Calendars: []string{"holidays"},
+	}
+
+	// Jobs can't reference missing calendars
+	assert.Equal(t, ErrCalendarNotFound, s.SetJob(ctx, job, false))
+
+	cal := &Calendar{Name: "holidays", ExcludedWeekdays: []string{"saturday"}}
+	require.NoError(t, s.SetCalendar(ctx, cal))
+	require.NoError(t, s.SetJob(ctx, job, false))
+
+	calendars, err := s.GetCalendars(ctx)
+	require.NoError(t, err)
+	assert.Len(t, calendars, 1)
+
+	// Updating the calendar recomputes the next run of its jobs
+	cal.ExcludedWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+	require.NoError(t, s.SetCalendar(ctx, cal))
+	job, err = s.GetJob(ctx, job.Name, nil)
+	require.NoError(t, err)
+	assert.True(t, job.Next.IsZero())
+
+	_, err = s.DeleteCalendar(ctx, cal.Name)
+	assert.Equal(t, ErrCalendarInUse, err)
+
+	job.Calendars = nil
+	require.NoError(t, s.SetJob(ctx, job, false))
+	_, err = s.DeleteCalendar(ctx, cal.Name)
+	require.NoError(t, err)
+
+	_, err = s.GetCalendar(ctx, cal.Name)
+	assert.Error(t, err)
+}
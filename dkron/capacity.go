@@ -0,0 +1,130 @@
+package dkron
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxExecutionsTag is the serf tag advertising the maximum number of
+	// executions a node runs at the same time.
+	maxExecutionsTag = "max_concurrent_executions"
+	// runningExecutionsTag is the serf tag advertising the number of
+	// executions running in a node.
+	runningExecutionsTag = "running_executions"
+)
+
+// ErrNodeSaturated is returned by nodes asked to run a job while running
+// their maximum number of executions.
+var ErrNodeSaturated = errors.New("node is running its maximum number of concurrent executions")
+
+// executionSlots counts the executions running in this node.
+type executionSlots struct {
+	mu      sync.Mutex
+	running int
+}
+
+// nodeSaturated returns whether the node advertises running its maximum
+// number of executions.
+func nodeSaturated(n Node) bool {
+	max, err := strconv.Atoi(n.Tags[maxExecutionsTag])
+	if err != nil || max <= 0 {
+		return false
+	}
+	running, err := strconv.Atoi(n.Tags[runningExecutionsTag])
+	if err != nil {
+		return false
+	}
+	return running >= max
+}
+
+// acquireExecutionSlot takes an execution slot of this node, it returns
+// false if the node is running its maximum number of executions.
+func (a *Agent) acquireExecutionSlot() bool {
+	a.slots.mu.Lock()
+	defer a.slots.mu.Unlock()
+
+	max := a.config.MaxConcurrentExecutions
+	if max > 0 && a.slots.running >= max {
+		return false
+	}
+	a.slots.running++
+	a.advertiseRunningExecutions()
+	return true
+}
+
+// releaseExecutionSlot frees an execution slot of this node.
+func (a *Agent) releaseExecutionSlot() {
+	a.slots.mu.Lock()
+	defer a.slots.mu.Unlock()
+
+	a.slots.running--
+	a.advertiseRunningExecutions()
+}
+
+// advertiseRunningExecutions updates the running executions tag of this
+// node, when it has a limit. It must be called holding the slots lock.
+func (a *Agent) advertiseRunningExecutions() {
+	if a.config.MaxConcurrentExecutions <= 0 || a.serf == nil {
+		return
+	}
+
+	a.tagsMu.Lock()
+	defer a.tagsMu.Unlock()
+
+	tags := make(map[string]string)
+	for k, v := range a.serf.LocalMember().Tags {
+		tags[k] = v
+	}
+	tags[runningExecutionsTag] = strconv.Itoa(a.slots.running)
+	if err := a.serf.SetTags(tags); err != nil {
+		a.logger.WithError(err).Warn("agent: Error advertising running executions")
+	}
+}
+
+// waitForCapacity holds the run of the job in the given node until the
+// node finishes one of its executions.
+func (a *Agent) waitForCapacity(job *Job, ex *Execution, node Node, queuedRun *QueuedRun) {
+	r := queuedRun
+	if r == nil {
+		r = &QueuedRun{
+			JobName:  job.Name,
+			Priority: job.Priority,
+			Reason:   QueueReasonCapacity,
+			Resource: node.Name,
+			Group:    ex.GetGroup(),
+			MapIndex: ex.Metadata[mapIndexMetadata],
+		}
+	}
+	r.run = func() {
+		a.agentRun(job, ex, node, r)
+	}
+
+	fields := logrus.Fields{
+		"job":  job.Name,
+		"node": node.Name,
+	}
+	if !a.capacity.push(r, MaxQueuedRuns) {
+		a.logger.WithFields(fields).Warning("agent: Skipping execution because the node queue is full")
+		a.pools.release(poolLeaseKey(ex, node.Name))
+		a.executionDropped(ex)
+		return
+	}
+	a.logger.WithFields(fields).Info("agent: Queueing execution until the node finishes a running one")
+}
+
+// dequeueCapacityRun starts the next run waiting for the node, if any and
+// this node is still the leader.
+func (a *Agent) dequeueCapacityRun(node string) {
+	r := a.capacity.pop(node)
+	if r == nil {
+		return
+	}
+
+	if a.sched != nil && a.sched.Started() {
+		go r.run()
+	}
+}
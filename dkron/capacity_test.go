@@ -0,0 +1,141 @@
+package dkron
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	proto "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// saturatedClientMock rejects every run as if the node had no execution slots left.
+type saturatedClientMock struct {
+	gRPCClientMock
+	calls int
+}
+
+func (m *saturatedClientMock) AgentRun(addr string, job *proto.Job, execution *proto.Execution, parent *proto.ParentExecution) error {
+	m.calls++
+	return status.Error(codes.ResourceExhausted, ErrNodeSaturated.Error())
+}
+
+func capacityNode(name, max, running string) Node {
+	return Node{Name: name, Tags: map[string]string{
+		"rpc_addr":           name + ":6868",
+		maxExecutionsTag:     max,
+		runningExecutionsTag: running,
+	}}
+}
+
+func TestNodeSaturated(t *testing.T) {
+	assert.False(t, nodeSaturated(Node{Name: "no-limit"}))
+	assert.False(t, nodeSaturated(capacityNode("free", "2", "1")))
+	assert.True(t, nodeSaturated(capacityNode("full", "2", "2")))
+	assert.False(t, nodeSaturated(capacityNode("zero", "0", "5")))
+	assert.False(t, nodeSaturated(capacityNode("malformed", "2", "x")))
+}
+
+func TestSelectNodesSkipsSaturated(t *testing.T) {
+	free := capacityNode("free", "2", "0")
+	full := capacityNode("full", "2", "2")
+
+	// Saturated nodes are skipped while there are other nodes
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []Node{free}, selectNodes([]Node{full, free}, 1, defaultSelector))
+	}
+
+	// and selected when there are not enough
+	selected := selectNodes([]Node{full, free}, 2, defaultSelector)
+	assert.Len(t, selected, 2)
+	assert.Contains(t, selected, full)
+	assert.Equal(t, []Node{full}, selectNodes([]Node{full}, 1, defaultSelector))
+}
+
+func TestAgentExecutionSlots(t *testing.T) {
+	c := DefaultConfig()
+	c.MaxConcurrentExecutions = 2
+	a := &Agent{config: c, logger: getTestLogger()}
+
+	assert.True(t, a.acquireExecutionSlot())
+	assert.True(t, a.acquireExecutionSlot())
+	assert.False(t, a.acquireExecutionSlot())
+
+	a.releaseExecutionSlot()
+	assert.True(t, a.acquireExecutionSlot())
+
+	// No limit by default
+	a = &Agent{config: DefaultConfig(), logger: getTestLogger()}
+	for i := 0; i < 100; i++ {
+		require.True(t, a.acquireExecutionSlot())
+	}
+}
+
+func TestAgentWaitForCapacity(t *testing.T) {
+	sched := NewScheduler(getTestLogger())
+	require.NoError(t, sched.Start(nil, &Agent{}))
+	defer sched.Stop()
+
+	client := &saturatedClientMock{}
+	a := &Agent{sched: sched, GRPCClient: client, logger: getTestLogger()}
+	job := &Job{Name: "report", Priority: 3}
+	ex := NewExecution(job.Name)
+	full := capacityNode("full", "1", "1")
+
+	// Saturated nodes are not called, the run waits for them
+	a.dispatchRun(job, ex, []Node{full})
+	assert.Equal(t, 0, client.calls)
+	runs := a.QueuedRuns()
+	require.Len(t, runs, 1)
+	assert.Equal(t, QueueReasonCapacity, runs[0].Reason)
+	assert.Equal(t, "full", runs[0].Resource)
+	assert.Equal(t, 3, runs[0].Priority)
+	queuedAt := runs[0].QueuedAt
+
+	// Other nodes finishing executions don't start it
+	a.dequeueCapacityRun("other")
+	assert.Equal(t, 1, a.capacity.len("full"))
+
+	// Runs rejected by the node wait again, keeping their place
+	r := a.capacity.pop("full")
+	r.run()
+	assert.Equal(t, 1, client.calls)
+	require.Equal(t, 1, a.capacity.len("full"))
+	assert.Equal(t, queuedAt, a.capacity.peek("full").QueuedAt)
+}
+
+// saturatedAgentServer is a node rejecting every run as saturated.
+type saturatedAgentServer struct {
+	proto.UnimplementedAgentServiceServer
+	calls atomic.Int32
+}
+
+func (s *saturatedAgentServer) AgentRun(*proto.AgentRunRequest, grpc.ServerStreamingServer[proto.AgentRunStream]) error {
+	s.calls.Add(1)
+	return status.Error(codes.ResourceExhausted, ErrNodeSaturated.Error())
+}
+
+func TestGRPCClientAgentRunSaturated(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	node := &saturatedAgentServer{}
+	srv := grpc.NewServer()
+	proto.RegisterAgentServiceServer(srv, node)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	config := DefaultConfig()
+	config.AgentRunRetryInitialInterval = 10 * time.Millisecond
+	a := &Agent{config: config}
+	client := NewGRPCClient(nil, a, getTestLogger())
+
+	// Saturated nodes are called once, the run waits for them
+	err = client.AgentRun(lis.Addr().String(), &proto.Job{Name: "report"}, NewExecution("report").ToProto(), nil)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, int32(1), node.calls.Load())
+}
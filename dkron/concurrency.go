@@ -0,0 +1,158 @@
+package dkron
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaxQueuedRuns is the maximum number of runs of a job waiting for a
+// running execution to finish with the queue concurrency policy.
+const MaxQueuedRuns = 100
+
+// concurrencyLimit returns how many runs of the job can execute at
+// the same time, zero if there is no limit.
+func (j *Job) concurrencyLimit() int {
+	if j.MaxConcurrency > 0 {
+		return int(j.MaxConcurrency)
+	}
+	if j.Concurrency == ConcurrencyForbid || j.Concurrency == ConcurrencyQueue || j.completionAnchored() {
+		return 1
+	}
+	return 0
+}
+
+// runningExecutions returns how many runs of the job are executing, in
+// any node. Runs targeting several nodes count once.
+func (j *Job) runningExecutions(logger *logrus.Entry) (int, error) {
+	groups := make(map[int64]struct{})
+
+	// Check in-memory active executions first - these are definitely running
+	exs, err := j.Agent.GetActiveExecutions()
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range exs {
+		if e.JobName == j.Name {
+			groups[e.Group] = struct{}{}
+		}
+	}
+
+	// Check persistent storage for running executions
+	// This catches executions that might be running on nodes after a leader change
+	runningExecs, err := j.Agent.cleanupStaleRunningExecutions(context.Background(), j.Name, activeExecutionKeys(exs), logger, "job: Cleaning up stale execution from storage")
+	if err != nil {
+		return 0, err
+	}
+	for _, exec := range runningExecs {
+		// Execution is not in active memory but hasn't exceeded the stale threshold.
+		// Conservatively count it to avoid exceeding the limit.
+		logger.WithFields(logrus.Fields{
+			"job":        j.Name,
+			"execution":  exec.Key(),
+			"node":       exec.NodeName,
+			"started_at": exec.StartedAt,
+		}).Debug("job: Found running execution in storage")
+		groups[exec.Group] = struct{}{}
+	}
+
+	return len(groups), nil
+}
+
+// queueRun holds a run of the job until one of its executions finishes.
+// It returns false if the job queue is full.
+func (a *Agent) queueRun(job *Job, run func()) bool {
+	return a.queue.push(&QueuedRun{
+		JobName:  job.Name,
+		Priority: job.Priority,
+		Reason:   QueueReasonConcurrency,
+		Resource: job.Name,
+		run:      run,
+	}, MaxQueuedRuns)
+}
+
+// dequeueRun starts the oldest queued run of the job, if any and this
+// node is still the leader.
+func (a *Agent) dequeueRun(jobName string) {
+	r := a.queue.pop(jobName)
+	if r == nil {
+		return
+	}
+
+	if a.sched != nil && a.sched.Started() {
+		go r.run()
+	}
+}
+
+// runTracker tracks in the leader how many executions of the running job
+// runs are still to finish, to act once per run instead of once per node.
+type runTracker struct {
+	mu      sync.Mutex
+	pending map[string]int
+}
+
+// runTrackerKey returns the key of the run of the execution.
+func runTrackerKey(ex *Execution) string {
+	return ex.JobName + ":" + ex.GetGroup()
+}
+
+// start records that the run of the execution was dispatched to the
+// given number of nodes.
+func (t *runTracker) start(ex *Execution, nodes int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pending == nil {
+		t.pending = make(map[string]int)
+	}
+	t.pending[runTrackerKey(ex)] = nodes
+}
+
+// done records that an execution of the run finished, or won't finish,
+// and returns whether it was the last one. Executions of runs started by
+// another leader are unknown and finish their run.
+func (t *runTracker) done(ex *Execution) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := runTrackerKey(ex)
+	n, ok := t.pending[key]
+	if !ok {
+		return true
+	}
+	if n > 1 {
+		t.pending[key] = n - 1
+		return false
+	}
+	delete(t.pending, key)
+	return true
+}
+
+// reset forgets the running job runs.
+func (t *runTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = nil
+}
+
+// executionDropped records that an execution won't finish, starting the
+// next queued run of the job if it was the last one of its run. Dropped
+// items of map jobs start the next pending item, their map run finishes
+// with its last item.
+func (a *Agent) executionDropped(ex *Execution) {
+	runDone := false
+	if isMapItem(ex) {
+		var next *Execution
+		next, runDone = a.maps.itemDone(ex)
+		if next != nil {
+			a.runMapItem(ex.JobName, next)
+		}
+	} else {
+		runDone = a.runs.done(ex)
+	}
+	if runDone {
+		a.dequeueRun(ex.JobName)
+	}
+}
@@ -321,3 +321,121 @@ func TestConcurrencyAllow_NotAffected(t *testing.T) {
 	// This is tested indirectly - the GetRunningExecutions check is only
 	// applied when Concurrency == ConcurrencyForbid in isRunnable()
 }
+
+func TestJobConcurrencyLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      *Job
+		expected int
+	}{
+		{"allow", &Job{Concurrency: ConcurrencyAllow}, 0},
+		{"default", &Job{}, 0},
+		{"forbid", &Job{Concurrency: ConcurrencyForbid}, 1},
+		{"queue", &Job{Concurrency: ConcurrencyQueue}, 1},
+		{"allow with max", &Job{Concurrency: ConcurrencyAllow, MaxConcurrency: 3}, 3},
+		{"queue with max", &Job{Concurrency: ConcurrencyQueue, MaxConcurrency: 3}, 3},
+		{"every-after", &Job{Schedule: "@every-after 1m"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.job.concurrencyLimit())
+		})
+	}
+}
+
+func TestJobValidateMaxConcurrency(t *testing.T) {
+	job := &Job{
+		Name:           "reports",
+		Schedule:       "@every 1m",
+		Concurrency:    ConcurrencyQueue,
+		MaxConcurrency: 3,
+	}
+	assert.NoError(t, job.Validate())
+
+	job.Concurrency = ConcurrencyForbid
+	assert.Equal(t, ErrWrongMaxConcurrency, job.Validate())
+
+	job.MaxConcurrency = 1
+	assert.NoError(t, job.Validate())
+
+	job.Concurrency = "wait"
+	assert.Equal(t, ErrWrongConcurrency, job.Validate())
+}
+
+func TestAgentQueueRun(t *testing.T) {
+	sched := NewScheduler(getTestLogger())
+	require.NoError(t, sched.Start(nil, &Agent{}))
+	defer sched.Stop()
+	a := &Agent{sched: sched}
+	job := &Job{Name: "reports"}
+
+	ran := make(chan int, 2)
+	for i := 0; i < MaxQueuedRuns; i++ {
+		i := i
+		require.True(t, a.queueRun(job, func() { ran <- i }))
+	}
+	assert.False(t, a.queueRun(job, func() {}))
+
+	// Queued runs start in order, one per finished execution
+	a.dequeueRun("reports")
+	assert.Equal(t, 0, <-ran)
+	a.dequeueRun("reports")
+	assert.Equal(t, 1, <-ran)
+	assert.Equal(t, MaxQueuedRuns-2, a.queue.len("reports"))
+
+	// Nothing to start for other jobs
+	a.dequeueRun("other")
+	assert.Len(t, ran, 0)
+}
+
+func TestJobMaxConcurrencyProto(t *testing.T) {
+	job := &Job{
+		Name:           "reports",
+		Concurrency:    ConcurrencyQueue,
+		MaxConcurrency: 3,
+	}
+	pj := NewJobFromProto(job.ToProto(), getTestLogger())
+	assert.Equal(t, job.Concurrency, pj.Concurrency)
+	assert.Equal(t, job.MaxConcurrency, pj.MaxConcurrency)
+}
+
+func TestRunTracker(t *testing.T) {
+	var r runTracker
+	ex := &Execution{JobName: "reports", Group: 1}
+	r.start(ex, 3)
+
+	// Runs in several nodes finish with their last execution
+	assert.False(t, r.done(ex))
+	assert.False(t, r.done(ex))
+	assert.True(t, r.done(ex))
+
+	// Runs started by another leader finish with every execution
+	assert.True(t, r.done(ex))
+	assert.True(t, r.done(&Execution{JobName: "reports", Group: 2}))
+
+	r.start(ex, 2)
+	r.reset()
+	assert.True(t, r.done(ex))
+}
+
+func TestAgentExecutionDropped(t *testing.T) {
+	sched := NewScheduler(getTestLogger())
+	require.NoError(t, sched.Start(nil, &Agent{}))
+	defer sched.Stop()
+	a := &Agent{sched: sched}
+	job := &Job{Name: "reports"}
+
+	ran := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		require.True(t, a.queueRun(job, func() { ran <- struct{}{} }))
+	}
+
+	// A single queued run starts once all the executions of the run end
+	ex := &Execution{JobName: job.Name, Group: 1}
+	a.runs.start(ex, 2)
+	a.executionDropped(ex)
+	assert.Equal(t, 2, a.queue.len(job.Name))
+	a.executionDropped(ex)
+	<-ran
+	assert.Equal(t, 1, a.queue.len(job.Name))
+}
@@ -184,6 +184,25 @@ type Config struct {
 	// OpenTelemetryEndpoint is the gRPC endpoint to send OpenTelemetry traces to. If empty, no traces will be sent.
 	OpenTelemetryEndpoint string `mapstructure:"otel-endpoint"`
 
+	// MaxConcurrentExecutions is the maximum number of executions this node
+	// runs at the same time. Defaults to 0, no limit.
+	MaxConcurrentExecutions int `mapstructure:"max-concurrent-executions"`
+
+	// FactsBuiltin enables the built-in facts provider, adding tags with
+	// the node CPU count, memory, free disk, OS and kernel.
+	FactsBuiltin bool `mapstructure:"facts-builtin"`
+
+	// FactsScript is the path of a script whose output lines are key=value
+	// tags of this node.
+	FactsScript string `mapstructure:"facts-script"`
+
+	// FactsFile is the path of a JSON file with an object of tags of this node.
+	FactsFile string `mapstructure:"facts-file"`
+
+	// FactsInterval is how often the facts providers tags are refreshed.
+	// Defaults to 1 minute.
+	FactsInterval time.Duration `mapstructure:"facts-interval"`
+
 	// AgentRunMaxRetries is the maximum number of retry attempts for AgentRun RPC calls. Defaults to 3.
 	AgentRunMaxRetries int `mapstructure:"agent-run-max-retries"`
 
@@ -229,6 +248,7 @@ func DefaultConfig() *Config {
 		RaftMultiplier:               1,
 		SerfReconnectTimeout:         "24h",
 		UI:                           true,
+		FactsInterval:                1 * time.Minute,
 		AgentRunMaxRetries:           3,
 		AgentRunRetryInitialInterval: 1 * time.Second,
 		AgentRunRetryMaxInterval:     30 * time.Second,
@@ -284,6 +304,18 @@ The maximum allowed value is 10.`)
 	cmdFlags.StringSlice("tag", []string{},
 		`Tag can be specified multiple times to attach multiple key/value tag pairs 
 to the given node, specified as key=value`)
+	cmdFlags.Bool("facts-builtin", false,
+		`Add tags with the node facts: cpus, memory_gb, disk_free_gb, os, arch
+and kernel`)
+	cmdFlags.String("facts-script", "",
+		"Script whose output lines are key=value tags of this node, refreshed periodically")
+	cmdFlags.String("facts-file", "",
+		"JSON file with an object of tags of this node, refreshed periodically")
+	cmdFlags.Duration("facts-interval", c.FactsInterval,
+		"How often the tags from the facts providers are refreshed")
+	cmdFlags.Int("max-concurrent-executions", 0,
+		`Maximum number of executions this node runs at the same time, further
+runs wait until an execution finishes. Defaults to 0, no limit.`)
 	cmdFlags.String("encrypt", "",
 		"Key for encrypting network traffic. Must be a base64-encoded 16-byte key")
 	cmdFlags.String("log-level", c.LogLevel,
@@ -0,0 +1,207 @@
+package dkron
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+
+	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+)
+
+const (
+	// Execution metadata keys recording the parent execution that
+	// triggered the run of a dependent job.
+	parentJobMetadata       = "parent_job"
+	parentExecutionMetadata = "parent_execution_id"
+	parentGroupMetadata     = "parent_group"
+	parentNodeMetadata      = "parent_node"
+	parentSuccessMetadata   = "parent_success"
+)
+
+// maxParentRuns is the maximum number of workflow runs in which the parents
+// of a job are tracked at the same time, older ones are dropped so parents
+// that never finish don't hold them forever.
+const maxParentRuns = 100
+
+// parentOutcomes holds whether the parent jobs of a job that finished in
+// a workflow run met their condition.
+type parentOutcomes struct {
+	met   map[string]bool
+	fired bool
+
+	// Workflow run of the first parent that finished
+	workflowRunID string
+}
+
+// dependencyTracker tracks the parent jobs that finished for every job
+// with parents, to run it when its trigger rule is satisfied.
+type dependencyTracker struct {
+	mu sync.Mutex
+
+	// Workflow runs with finished parents by job name, oldest first
+	outcomes map[string][]*parentOutcomes
+}
+
+// parentDone records that a parent of the job finished with the given
+// status in a workflow run, and returns whether the job has to run and the
+// workflow run it belongs to. Once all the parents finished in a workflow
+// run its outcomes are cleared.
+func (t *dependencyTracker) parentDone(job *Job, parent string, status string, workflowRunID string) (bool, string) {
+	parents := job.parents()
+	if !slices.Contains(parents, parent) {
+		return false, ""
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o := t.outcomesOf(job.Name, parent, workflowRunID)
+	met := conditionMet(job.parentCondition(parent), status)
+	o.met[parent] = met
+
+	done, metCount := 0, 0
+	for _, p := range parents {
+		if m, ok := o.met[p]; ok {
+			done++
+			if m {
+				metCount++
+			}
+		}
+	}
+
+	run := triggerRuleMet(job.TriggerRule, len(parents), done, metCount)
+	if job.TriggerRule == TriggerAnySuccess {
+		run = met && !o.fired
+	}
+	if run {
+		o.fired = true
+	}
+
+	if done == len(parents) {
+		runs := t.outcomes[job.Name]
+		t.outcomes[job.Name] = slices.DeleteFunc(runs, func(r *parentOutcomes) bool { return r == o })
+		if len(t.outcomes[job.Name]) == 0 {
+			delete(t.outcomes, job.Name)
+		}
+	}
+	return run, o.workflowRunID
+}
+
+// outcomesOf returns the outcomes of the job parents in the workflow run.
+// Parents started by another root job join the oldest workflow run they
+// didn't finish in yet, so jobs with parents in several workflows combine
+// them, while overlapping runs of the same workflow are kept apart.
+func (t *dependencyTracker) outcomesOf(jobName, parent, workflowRunID string) *parentOutcomes {
+	if t.outcomes == nil {
+		t.outcomes = make(map[string][]*parentOutcomes)
+	}
+	runs := t.outcomes[jobName]
+	for _, o := range runs {
+		if o.workflowRunID == workflowRunID {
+			return o
+		}
+	}
+
+	root, _ := workflowRoot(workflowRunID)
+	for _, o := range runs {
+		if _, ok := o.met[parent]; ok {
+			continue
+		}
+		if r, _ := workflowRoot(o.workflowRunID); r != root {
+			return o
+		}
+	}
+
+	if len(runs) >= maxParentRuns {
+		runs = runs[1:]
+	}
+	o := &parentOutcomes{met: make(map[string]bool), workflowRunID: workflowRunID}
+	t.outcomes[jobName] = append(runs, o)
+	return o
+}
+
+// triggerRuleMet returns whether the trigger rule of a job with the given
+// number of parents is met, given how many of them finished and how many
+// met their condition.
+func triggerRuleMet(rule string, parents, done, met int) bool {
+	switch rule {
+	case TriggerAnySuccess:
+		return met > 0
+	case TriggerAllDone:
+		return done == parents
+	default:
+		return met == parents
+	}
+}
+
+// reset forgets the finished parents of all jobs.
+func (t *dependencyTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.outcomes = nil
+}
+
+// setParentMetadata records the parent execution that triggered this copy
+// of the job in the execution metadata, so it's kept by all the executions
+// of the group and passed to the executor. The parent result is not copied,
+// it's read from the stored parent execution when the job is dispatched.
+func (j *Job) setParentMetadata(ex *Execution) {
+	p := j.parentExecution
+	if p == nil {
+		return
+	}
+	if ex.Metadata == nil {
+		ex.Metadata = make(map[string]string)
+	}
+	ex.Metadata[parentJobMetadata] = p.JobName
+	ex.Metadata[parentExecutionMetadata] = p.Key()
+	ex.Metadata[parentGroupMetadata] = p.GetGroup()
+	ex.Metadata[parentNodeMetadata] = p.NodeName
+	ex.Metadata[parentSuccessMetadata] = strconv.FormatBool(p.Success)
+}
+
+// parentFromMetadata returns the parent execution recorded in the
+// execution metadata, or nil if the run wasn't triggered by a parent job.
+func parentFromMetadata(md map[string]string) *typesv1.ParentExecution {
+	name, ok := md[parentJobMetadata]
+	if !ok {
+		return nil
+	}
+	group, _ := strconv.ParseInt(md[parentGroupMetadata], 10, 64)
+	success, _ := strconv.ParseBool(md[parentSuccessMetadata])
+	return &typesv1.ParentExecution{
+		JobName:     name,
+		ExecutionId: md[parentExecutionMetadata],
+		Group:       group,
+		NodeName:    md[parentNodeMetadata],
+		Success:     success,
+	}
+}
+
+// parentResult returns the result of the parent execution that triggered
+// the run, read from the store, or an empty string if there is none.
+func (a *Agent) parentResult(ctx context.Context, ex *Execution) string {
+	name, ok := ex.Metadata[parentJobMetadata]
+	if !ok {
+		return ""
+	}
+	p, err := a.Store.GetExecution(ctx, name, ex.Metadata[parentExecutionMetadata])
+	if err != nil {
+		a.logger.WithError(err).WithField("job", ex.JobName).Warn("agent: Error retrieving parent execution")
+		return ""
+	}
+	return p.Result
+}
+
+// parentExecution returns the parent execution that triggered the run, to
+// pass it to the executor, or nil if the run wasn't triggered by a parent
+// job. Items of map jobs get their item instead of the parent result.
+func (a *Agent) parentExecution(ctx context.Context, ex *Execution) *typesv1.ParentExecution {
+	p := parentFromMetadata(ex.Metadata)
+	if p != nil && !isMapItem(ex) {
+		p.Result = []byte(a.parentResult(ctx, ex))
+	}
+	return p
+}
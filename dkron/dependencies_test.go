@@ -0,0 +1,175 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyTrackerParentDone(t *testing.T) {
+	var d dependencyTracker
+	done := func(job *Job, parent, status string) bool {
+		run, _ := d.parentDone(job, parent, status, "")
+		return run
+	}
+	job := &Job{Name: "merge", ParentJobs: []string{"ingest_a", "ingest_b"}}
+
+	// all_success waits for every parent to succeed
+	assert.False(t, done(job, "ingest_a", StatusSuccess))
+	assert.True(t, done(job, "ingest_b", StatusSuccess))
+	assert.False(t, done(job, "ingest_b", StatusSuccess))
+	assert.False(t, done(job, "ingest_a", StatusFailed))
+	assert.False(t, done(job, "other", StatusSuccess))
+
+	// any_success runs once, after the first parent succeeds
+	job.TriggerRule = TriggerAnySuccess
+	d.reset()
+	assert.False(t, done(job, "ingest_a", StatusFailed))
+	assert.True(t, done(job, "ingest_b", StatusSuccess))
+	assert.True(t, done(job, "ingest_a", StatusSuccess))
+	assert.False(t, done(job, "ingest_b", StatusSuccess))
+
+	// all_done runs after every parent finishes
+	job.TriggerRule = TriggerAllDone
+	d.reset()
+	assert.False(t, done(job, "ingest_a", StatusFailed))
+	assert.True(t, done(job, "ingest_b", StatusFailed))
+
+	// Jobs with a single parent run after it succeeds
+	child := &Job{Name: "report", ParentJob: "merge"}
+	assert.True(t, done(child, "merge", StatusSuccess))
+	assert.False(t, done(child, "merge", StatusFailed))
+
+	// Conditions choose the results that meet each dependency
+	child.ParentConditions = map[string]string{"merge": ConditionOnFailure}
+	assert.False(t, done(child, "merge", StatusSuccess))
+	assert.True(t, done(child, "merge", StatusFailed))
+	assert.True(t, done(child, "merge", StatusPartiallyFailed))
+
+	child.ParentConditions["merge"] = ConditionOnPartialFailure
+	assert.False(t, done(child, "merge", StatusFailed))
+	assert.True(t, done(child, "merge", StatusPartiallyFailed))
+
+	child.ParentConditions["merge"] = ConditionAlways
+	assert.True(t, done(child, "merge", StatusSuccess))
+	assert.True(t, done(child, "merge", StatusFailed))
+
+	// and combine with the trigger rule
+	job.TriggerRule = ""
+	job.ParentConditions = map[string]string{"ingest_b": ConditionAlways}
+	assert.False(t, done(job, "ingest_b", StatusFailed))
+	assert.True(t, done(job, "ingest_a", StatusSuccess))
+
+	// Jobs join the workflow run of the first parent that finished
+	d.reset()
+	job.ParentConditions = nil
+	run, id := d.parentDone(job, "ingest_a", StatusSuccess, "ingest_a-1")
+	assert.False(t, run)
+	assert.Equal(t, "ingest_a-1", id)
+	run, id = d.parentDone(job, "ingest_b", StatusSuccess, "ingest_b-2")
+	assert.True(t, run)
+	assert.Equal(t, "ingest_a-1", id)
+}
+
+func TestDependencyTrackerWorkflowRuns(t *testing.T) {
+	var d dependencyTracker
+	done := func(job *Job, parent, status, workflowRunID string) (bool, string) {
+		return d.parentDone(job, parent, status, workflowRunID)
+	}
+	job := &Job{Name: "merge", ParentJobs: []string{"extract_a", "extract_b"}}
+
+	// Interleaved runs of the same workflow don't mix their parents
+	run, _ := done(job, "extract_a", StatusSuccess, "ingest-1")
+	assert.False(t, run)
+	run, _ = done(job, "extract_a", StatusFailed, "ingest-2")
+	assert.False(t, run)
+	run, _ = done(job, "extract_b", StatusSuccess, "ingest-2")
+	assert.False(t, run)
+	run, id := done(job, "extract_b", StatusSuccess, "ingest-1")
+	assert.True(t, run)
+	assert.Equal(t, "ingest-1", id)
+	assert.Empty(t, d.outcomes)
+
+	// any_success runs in every workflow run, even if a parent never finishes
+	job.TriggerRule = TriggerAnySuccess
+	run, id = done(job, "extract_a", StatusSuccess, "ingest-3")
+	assert.True(t, run)
+	assert.Equal(t, "ingest-3", id)
+	run, id = done(job, "extract_a", StatusSuccess, "ingest-4")
+	assert.True(t, run)
+	assert.Equal(t, "ingest-4", id)
+
+	// Workflow runs whose parents never finish are dropped
+	for i := 0; i < maxParentRuns; i++ {
+		done(job, "extract_a", StatusSuccess, workflowRunID("ingest", int64(i+5)))
+	}
+	assert.Len(t, d.outcomes[job.Name], maxParentRuns)
+	assert.Equal(t, "ingest-5", d.outcomes[job.Name][0].workflowRunID)
+}
+
+func TestDependencyTrackerSeveralWorkflows(t *testing.T) {
+	var d dependencyTracker
+	done := func(job *Job, parent, status, workflowRunID string) (bool, string) {
+		return d.parentDone(job, parent, status, workflowRunID)
+	}
+	job := &Job{Name: "merge", ParentJobs: []string{"ingest_a", "ingest_b"}}
+
+	// Runs of two workflows pair in order: the parents of one workflow
+	// join the oldest run the other one started
+	run, _ := done(job, "ingest_a", StatusSuccess, "ingest_a-1")
+	assert.False(t, run)
+	run, _ = done(job, "ingest_a", StatusSuccess, "ingest_a-2")
+	assert.False(t, run)
+	run, id := done(job, "ingest_b", StatusSuccess, "ingest_b-1")
+	assert.True(t, run)
+	assert.Equal(t, "ingest_a-1", id)
+	run, id = done(job, "ingest_b", StatusSuccess, "ingest_b-2")
+	assert.True(t, run)
+	assert.Equal(t, "ingest_a-2", id)
+	assert.Empty(t, d.outcomes)
+
+	// Interleaved runs join whichever workflow started waiting first
+	run, _ = done(job, "ingest_a", StatusSuccess, "ingest_a-3")
+	assert.False(t, run)
+	run, id = done(job, "ingest_b", StatusSuccess, "ingest_b-3")
+	assert.True(t, run)
+	assert.Equal(t, "ingest_a-3", id)
+	run, _ = done(job, "ingest_b", StatusFailed, "ingest_b-4")
+	assert.False(t, run)
+	run, _ = done(job, "ingest_b", StatusSuccess, "ingest_b-5")
+	assert.False(t, run)
+	run, id = done(job, "ingest_a", StatusSuccess, "ingest_a-4")
+	assert.False(t, run)
+	assert.Equal(t, "ingest_b-4", id)
+	run, id = done(job, "ingest_a", StatusSuccess, "ingest_a-5")
+	assert.True(t, run)
+	assert.Equal(t, "ingest_b-5", id)
+	assert.Empty(t, d.outcomes)
+}
+
+func TestParentMetadata(t *testing.T) {
+	ex := NewExecution("process_files")
+	job := &Job{Name: "process_files"}
+	job.setParentMetadata(ex)
+	assert.Nil(t, ex.Metadata)
+	assert.Nil(t, parentFromMetadata(ex.Metadata))
+
+	job.parentExecution = &Execution{
+		JobName:   "find_files",
+		StartedAt: time.Unix(1700000000, 0),
+		NodeName:  "node1",
+		Group:     1700000000,
+		Success:   false,
+	}
+	job.setParentMetadata(ex)
+	parent := parentFromMetadata(ex.Metadata)
+	require.NotNil(t, parent)
+	assert.Equal(t, "find_files", parent.JobName)
+	assert.Equal(t, job.parentExecution.Key(), parent.ExecutionId)
+	assert.Equal(t, int64(1700000000), parent.Group)
+	assert.Equal(t, "node1", parent.NodeName)
+	assert.False(t, parent.Success)
+	assert.Empty(t, parent.Result)
+}
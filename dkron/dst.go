@@ -0,0 +1,241 @@
+package dkron
+
+import (
+	"time"
+
+	"github.com/distribworks/dkron/v4/extcron"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// dstWindow bounds how far from a fire time a daylight saving time
+	// change can affect it, longer than any clock shift.
+	dstWindow = 3 * time.Hour
+
+	// Adjustments made to a run by the DST policy.
+	dstShifted  = "shifted"
+	dstRepeated = "repeated"
+	dstSkipped  = "skipped"
+)
+
+// dstRun is a fire time of a dstSchedule.
+type dstRun struct {
+	time time.Time
+	// Scheduled wall clock time, in UTC.
+	wall       time.Time
+	adjustment string
+}
+
+// metadata returns the execution metadata recording the adjustment.
+func (r dstRun) metadata() map[string]string {
+	return map[string]string{
+		"dst_adjustment":     r.adjustment,
+		"dst_scheduled_time": r.wall.Format("2006-01-02T15:04:05"),
+	}
+}
+
+// dstSchedule wraps a cron schedule evaluated in wall clock time,
+// resolving the wall clock times that don't exist or are repeated
+// in its location following a DST policy.
+type dstSchedule struct {
+	// The schedule evaluated in UTC, used as wall clock time.
+	wall   cron.Schedule
+	policy string
+	loc    *time.Location
+}
+
+// withDSTPolicy wraps the schedule with the given DST policy. Only
+// cron specs follow the wall clock, other schedules are returned as is.
+func withDSTPolicy(s cron.Schedule, policy string) cron.Schedule {
+	if policy == "" {
+		return s
+	}
+
+	switch cs := s.(type) {
+	case *cron.SpecSchedule:
+		wall := *cs
+		wall.Location = time.UTC
+		return &dstSchedule{wall: &wall, policy: policy, loc: cs.Location}
+	case *extcron.QuartzSchedule:
+		spec := *cs.SpecSchedule
+		spec.Location = time.UTC
+		wall := *cs
+		wall.SpecSchedule = &spec
+		return &dstSchedule{wall: &wall, policy: policy, loc: cs.Location}
+	}
+	return s
+}
+
+// Next conforms to the Schedule interface, it returns the next
+// fire time after t that is not skipped by the DST policy.
+func (s *dstSchedule) Next(t time.Time) time.Time {
+	for r := s.next(t); !r.time.IsZero(); r = s.next(r.time) {
+		if r.adjustment != dstSkipped {
+			return r.time.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// next returns the next run after t, including the skipped ones.
+func (s *dstSchedule) next(t time.Time) dstRun {
+	w := s.wallTime(t)
+	if s.offset(t.Add(-dstWindow)) != s.offset(t.Add(dstWindow)) {
+		// Close to a clock change, wall clock times before
+		// t can still happen after it.
+		w = w.Add(-dstWindow)
+	}
+
+	var best dstRun
+	var limit time.Time
+	for {
+		w = s.wall.Next(w)
+		if w.IsZero() || (!limit.IsZero() && w.After(limit)) {
+			return best
+		}
+
+		for _, r := range s.resolve(w) {
+			if r.time.After(t) && (best.time.IsZero() || r.time.Before(best.time)) {
+				best = r
+			}
+		}
+
+		if !best.time.IsZero() && limit.IsZero() {
+			limit = w
+			if s.offset(best.time.Add(-dstWindow)) != s.offset(best.time.Add(dstWindow)) {
+				// Close to a clock change later wall clock times can
+				// happen earlier, keep looking for them.
+				limit = w.Add(dstWindow)
+			}
+		}
+	}
+}
+
+// resolve returns the runs of the wall clock time w in order.
+func (s *dstSchedule) resolve(w time.Time) []dstRun {
+	before := s.offset(w.Add(-24 * time.Hour))
+	after := s.offset(w.Add(24 * time.Hour))
+
+	var runs []dstRun
+	for _, off := range []time.Duration{before, after} {
+		t := w.Add(-off)
+		if s.wallTime(t).Equal(w) && (len(runs) == 0 || !runs[0].time.Equal(t)) {
+			runs = append(runs, dstRun{time: t, wall: w})
+		}
+	}
+
+	switch len(runs) {
+	case 0:
+		// The clocks were turned forward and w doesn't exist
+		r := dstRun{
+			time:       s.transition(w.Add(-after), w.Add(-before)),
+			wall:       w,
+			adjustment: dstShifted,
+		}
+		switch s.policy {
+		case DSTSkip:
+			r.adjustment = dstSkipped
+		case DSTShiftForward:
+			r.time = w.Add(-before)
+		}
+		return []dstRun{r}
+	case 2:
+		// The clocks were turned back and w happens twice
+		if runs[1].time.Before(runs[0].time) {
+			runs[0], runs[1] = runs[1], runs[0]
+		}
+		runs[1].adjustment = dstSkipped
+		if s.policy == DSTRunTwice {
+			runs[1].adjustment = dstRepeated
+		}
+	}
+	return runs
+}
+
+// runAt returns the run that started at most scheduleTolerance before now.
+func (s *dstSchedule) runAt(now time.Time) (dstRun, bool) {
+	r := s.next(now.Add(-scheduleTolerance))
+	if r.time.IsZero() || r.time.After(now) {
+		return dstRun{}, false
+	}
+	for n := s.next(r.time); !n.time.IsZero() && !n.time.After(now); n = s.next(n.time) {
+		r = n
+	}
+	return r, true
+}
+
+// transition returns the first second in (from, to] with the offset of to.
+func (s *dstSchedule) transition(from, to time.Time) time.Time {
+	off := s.offset(to)
+	lo, hi := from.Unix(), to.Unix()
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if s.offset(time.Unix(mid, 0)) == off {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return time.Unix(hi, 0).UTC()
+}
+
+// wallTime returns the wall clock time of t in the schedule location, in UTC.
+func (s *dstSchedule) wallTime(t time.Time) time.Time {
+	lt := t.In(s.loc)
+	return time.Date(lt.Year(), lt.Month(), lt.Day(), lt.Hour(), lt.Minute(), lt.Second(), lt.Nanosecond(), time.UTC)
+}
+
+func (s *dstSchedule) offset(t time.Time) time.Duration {
+	_, off := t.In(s.loc).Zone()
+	return time.Duration(off) * time.Second
+}
+
+// skippedRunsSchedule also fires at the runs skipped by the
+// DST policy, so the scheduler can record them.
+type skippedRunsSchedule struct {
+	*dstSchedule
+}
+
+// Next conforms to the Schedule interface.
+func (s skippedRunsSchedule) Next(t time.Time) time.Time {
+	r := s.next(t)
+	if r.time.IsZero() {
+		return r.time
+	}
+	return r.time.In(t.Location())
+}
+
+// cronSchedule returns the schedule used to add the job to the scheduler.
+func (j *Job) cronSchedule() (cron.Schedule, error) {
+	s, err := j.schedule(nil)
+	if err != nil {
+		return nil, err
+	}
+	if ds, ok := s.(*dstSchedule); ok {
+		return skippedRunsSchedule{ds}, nil
+	}
+	return s, nil
+}
+
+// dstRun returns the run of the job at now if it was adjusted by the DST policy.
+func (j *Job) dstRun(now time.Time) (dstRun, bool) {
+	if j.DSTPolicy == "" || j.Schedule == "" {
+		return dstRun{}, false
+	}
+	s, err := j.schedule(nil)
+	if err != nil {
+		return dstRun{}, false
+	}
+	ds, ok := s.(*dstSchedule)
+	if !ok {
+		return dstRun{}, false
+	}
+	r, ok := ds.runAt(now)
+	return r, ok && r.adjustment != ""
+}
+
+// dstSkipped returns whether t is a run skipped by the job DST policy.
+func (j *Job) dstSkipped(t time.Time) bool {
+	r, ok := j.dstRun(t)
+	return ok && r.adjustment == dstSkipped && r.time.Equal(t)
+}
@@ -0,0 +1,230 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSTScheduleNext(t *testing.T) {
+	springForward := time.Date(2024, 3, 29, 12, 0, 0, 0, time.UTC)
+	fallBack := time.Date(2024, 10, 25, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		policy   string
+		from     time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "no policy skips the missing time",
+			schedule: "0 30 2 * * *",
+			from:     springForward,
+			expected: dstTimes("2024-03-30T01:30:00Z", "2024-04-01T00:30:00Z"),
+		},
+		{
+			name:     "skip the missing time",
+			schedule: "0 30 2 * * *",
+			policy:   DSTSkip,
+			from:     springForward,
+			expected: dstTimes("2024-03-30T01:30:00Z", "2024-04-01T00:30:00Z"),
+		},
+		{
+			name:     "run once at the transition",
+			schedule: "0 30 2 * * *",
+			policy:   DSTRunOnce,
+			from:     springForward,
+			expected: dstTimes("2024-03-30T01:30:00Z", "2024-03-31T01:00:00Z", "2024-04-01T00:30:00Z"),
+		},
+		{
+			name:     "run twice runs once at the transition",
+			schedule: "0 30 2 * * *",
+			policy:   DSTRunTwice,
+			from:     springForward,
+			expected: dstTimes("2024-03-30T01:30:00Z", "2024-03-31T01:00:00Z", "2024-04-01T00:30:00Z"),
+		},
+		{
+			name:     "shift forward the missing time",
+			schedule: "0 30 2 * * *",
+			policy:   DSTShiftForward,
+			from:     springForward,
+			expected: dstTimes("2024-03-30T01:30:00Z", "2024-03-31T01:30:00Z", "2024-04-01T00:30:00Z"),
+		},
+		{
+			name:     "run once several missing times",
+			schedule: "0 */20 2 * * *",
+			policy:   DSTRunOnce,
+			from:     springForward,
+			expected: dstTimes("2024-03-30T01:00:00Z", "2024-03-30T01:20:00Z", "2024-03-30T01:40:00Z", "2024-03-31T01:00:00Z", "2024-04-01T00:00:00Z"),
+		},
+		{
+			name:     "shift forward several missing times",
+			schedule: "0 */20 2 * * *",
+			policy:   DSTShiftForward,
+			from:     time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			expected: dstTimes("2024-03-31T01:00:00Z", "2024-03-31T01:20:00Z", "2024-03-31T01:40:00Z", "2024-04-01T00:00:00Z"),
+		},
+		{
+			name:     "quartz schedule",
+			schedule: "0 30 2 L * *",
+			policy:   DSTRunOnce,
+			from:     springForward,
+			expected: dstTimes("2024-03-31T01:00:00Z", "2024-04-30T00:30:00Z"),
+		},
+		{
+			name:     "no policy repeats the time",
+			schedule: "0 30 2 * * *",
+			from:     fallBack,
+			expected: dstTimes("2024-10-26T00:30:00Z", "2024-10-27T00:30:00Z", "2024-10-27T01:30:00Z", "2024-10-28T01:30:00Z"),
+		},
+		{
+			name:     "skip the repeated time",
+			schedule: "0 30 2 * * *",
+			policy:   DSTSkip,
+			from:     fallBack,
+			expected: dstTimes("2024-10-26T00:30:00Z", "2024-10-27T00:30:00Z", "2024-10-28T01:30:00Z"),
+		},
+		{
+			name:     "run once the repeated time",
+			schedule: "0 30 2 * * *",
+			policy:   DSTRunOnce,
+			from:     fallBack,
+			expected: dstTimes("2024-10-26T00:30:00Z", "2024-10-27T00:30:00Z", "2024-10-28T01:30:00Z"),
+		},
+		{
+			name:     "shift forward runs once the repeated time",
+			schedule: "0 30 2 * * *",
+			policy:   DSTShiftForward,
+			from:     fallBack,
+			expected: dstTimes("2024-10-26T00:30:00Z", "2024-10-27T00:30:00Z", "2024-10-28T01:30:00Z"),
+		},
+		{
+			name:     "run twice the repeated time",
+			schedule: "0 30 2 * * *",
+			policy:   DSTRunTwice,
+			from:     fallBack,
+			expected: dstTimes("2024-10-26T00:30:00Z", "2024-10-27T00:30:00Z", "2024-10-27T01:30:00Z", "2024-10-28T01:30:00Z"),
+		},
+		{
+			name:     "run once during the repeated hour",
+			schedule: "0 */15 * * * *",
+			policy:   DSTRunOnce,
+			from:     time.Date(2024, 10, 27, 0, 40, 0, 0, time.UTC),
+			expected: dstTimes("2024-10-27T00:45:00Z", "2024-10-27T02:00:00Z"),
+		},
+		{
+			name:     "run twice during the repeated hour",
+			schedule: "0 */15 * * * *",
+			policy:   DSTRunTwice,
+			from:     time.Date(2024, 10, 27, 0, 40, 0, 0, time.UTC),
+			expected: dstTimes("2024-10-27T00:45:00Z", "2024-10-27T01:00:00Z", "2024-10-27T01:15:00Z"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				Name:      "test_job",
+				Schedule:  tt.schedule,
+				Timezone:  "Europe/Madrid",
+				DSTPolicy: tt.policy,
+			}
+			require.NoError(t, job.Validate())
+
+			s, err := job.schedule(nil)
+			require.NoError(t, err)
+
+			next := tt.from
+			for _, expected := range tt.expected {
+				next = s.Next(next)
+				assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next.UTC())
+			}
+		})
+	}
+}
+
+func TestDSTScheduleOnlyWallClock(t *testing.T) {
+	job := &Job{
+		Name:      "test_job",
+		Schedule:  "@every 1h",
+		Timezone:  "Europe/Madrid",
+		DSTPolicy: DSTSkip,
+	}
+	s, err := job.schedule(nil)
+	require.NoError(t, err)
+	_, ok := s.(*dstSchedule)
+	assert.False(t, ok)
+}
+
+func TestJobDSTRun(t *testing.T) {
+	job := &Job{
+		Name:      "test_job",
+		Schedule:  "0 30 2 * * *",
+		Timezone:  "Europe/Madrid",
+		DSTPolicy: DSTShiftForward,
+	}
+
+	r, ok := job.dstRun(time.Date(2024, 3, 31, 1, 30, 2, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"dst_adjustment":     dstShifted,
+		"dst_scheduled_time": "2024-03-31T02:30:00",
+	}, r.metadata())
+
+	_, ok = job.dstRun(time.Date(2024, 4, 1, 0, 30, 1, 0, time.UTC))
+	assert.False(t, ok)
+
+	job.DSTPolicy = DSTRunTwice
+	r, ok = job.dstRun(time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, dstRepeated, r.adjustment)
+
+	_, ok = job.dstRun(time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestJobDSTSkippedRuns(t *testing.T) {
+	job := &Job{
+		Name:      "test_job",
+		Schedule:  "0 30 2 * * *",
+		Timezone:  "Europe/Madrid",
+		DSTPolicy: DSTSkip,
+	}
+
+	// The scheduler wakes up at the skipped runs to record them
+	s, err := job.cronSchedule()
+	require.NoError(t, err)
+	skipped := s.Next(time.Date(2024, 3, 30, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC), skipped.UTC())
+	assert.True(t, job.dstSkipped(skipped))
+
+	r, ok := job.dstRun(skipped)
+	require.True(t, ok)
+	assert.Equal(t, dstSkipped, r.adjustment)
+
+	// But they are never the job next run
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC), job.nextRun(skipped, nil).UTC())
+}
+
+func TestJobValidateDSTPolicy(t *testing.T) {
+	job := &Job{
+		Name:      "test_job",
+		Schedule:  "0 30 2 * * *",
+		DSTPolicy: DSTRunOnce,
+	}
+	assert.NoError(t, job.Validate())
+
+	job.DSTPolicy = "run_always"
+	assert.Equal(t, ErrWrongDSTPolicy, job.Validate())
+}
+
+func dstTimes(values ...string) []time.Time {
+	times := make([]time.Time, len(values))
+	for i, v := range values {
+		times[i], _ = time.Parse(time.RFC3339, v)
+	}
+	return times
+}
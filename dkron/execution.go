@@ -40,6 +40,25 @@ type Execution struct {
 
 	// Retry attempt of this execution.
 	Attempt uint `json:"attempt,omitempty"`
+
+	// If this execution was skipped and never dispatched.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Metadata about how this execution was scheduled.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Time this execution was scheduled at, empty for manual runs.
+	ScheduledAt time.Time `json:"scheduled_at,omitempty"`
+
+	// Random delay added to the scheduled time by the job jitter.
+	JitterDelay string `json:"jitter_delay,omitempty"`
+
+	// Run of the workflow, started by a job without parents, that this
+	// execution belongs to.
+	WorkflowRunID string `json:"workflow_run_id,omitempty"`
+
+	// Result emitted by the executor for the dependent jobs.
+	Result string `json:"result,omitempty"`
 }
 
 // NewExecution creates a new execution.
@@ -55,16 +74,26 @@ func NewExecution(jobName string) *Execution {
 func NewExecutionFromProto(e *proto.Execution) *Execution {
 	startedAt := e.GetStartedAt().AsTime()
 	finishedAt := e.GetFinishedAt().AsTime()
+	var scheduledAt time.Time
+	if e.GetScheduledAt() != nil {
+		scheduledAt = e.GetScheduledAt().AsTime()
+	}
 	return &Execution{
-		Id:         e.Key(),
-		JobName:    e.JobName,
-		Success:    e.Success,
-		Output:     string(e.Output),
-		NodeName:   e.NodeName,
-		Group:      e.Group,
-		Attempt:    uint(e.Attempt),
-		StartedAt:  startedAt,
-		FinishedAt: finishedAt,
+		Id:            e.Key(),
+		JobName:       e.JobName,
+		Success:       e.Success,
+		Output:        string(e.Output),
+		NodeName:      e.NodeName,
+		Group:         e.Group,
+		Attempt:       uint(e.Attempt),
+		Skipped:       e.Skipped,
+		Metadata:      e.Metadata,
+		ScheduledAt:   scheduledAt,
+		JitterDelay:   e.JitterDelay,
+		WorkflowRunID: e.WorkflowRunId,
+		Result:        string(e.Result),
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
 	}
 }
 
@@ -73,15 +102,25 @@ func NewExecutionFromProto(e *proto.Execution) *Execution {
 func (e *Execution) ToProto() *proto.Execution {
 	startedAt := timestamppb.New(e.StartedAt)
 	finishedAt := timestamppb.New(e.FinishedAt)
+	var scheduledAt *timestamppb.Timestamp
+	if !e.ScheduledAt.IsZero() {
+		scheduledAt = timestamppb.New(e.ScheduledAt)
+	}
 	return &proto.Execution{
-		JobName:    e.JobName,
-		Success:    e.Success,
-		Output:     []byte(e.Output),
-		NodeName:   e.NodeName,
-		Group:      e.Group,
-		Attempt:    uint32(e.Attempt),
-		StartedAt:  startedAt,
-		FinishedAt: finishedAt,
+		JobName:       e.JobName,
+		Success:       e.Success,
+		Output:        []byte(e.Output),
+		NodeName:      e.NodeName,
+		Group:         e.Group,
+		Attempt:       uint32(e.Attempt),
+		Skipped:       e.Skipped,
+		Metadata:      e.Metadata,
+		ScheduledAt:   scheduledAt,
+		JitterDelay:   e.JitterDelay,
+		WorkflowRunId: e.WorkflowRunID,
+		Result:        []byte(e.Result),
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
 	}
 }
 
@@ -95,6 +134,16 @@ func (e *Execution) GetGroup() string {
 	return strconv.FormatInt(e.Group, 10)
 }
 
+// runItem returns the identity of the execution in the executions of its
+// group, the group plus the item index for the items of map jobs, which
+// share the group of their run.
+func (e *Execution) runItem() string {
+	if i, ok := e.Metadata[mapIndexMetadata]; ok {
+		return e.GetGroup() + "/" + i
+	}
+	return e.GetGroup()
+}
+
 func (e *Execution) CalculateExponentialBackoff() time.Duration {
 	now := time.Now()
 	if now.Before(e.StartedAt) {
@@ -0,0 +1,227 @@
+package dkron
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// factsScriptTimeout is how long the facts script can run.
+const factsScriptTimeout = 30 * time.Second
+
+// FactsProvider returns facts about the node that are added to its tags.
+type FactsProvider interface {
+	Name() string
+	Facts() (map[string]string, error)
+}
+
+// builtinFacts provides the node hardware and operating system facts.
+type builtinFacts struct {
+	// Path of the disk to report the free space of.
+	path string
+}
+
+func (f *builtinFacts) Name() string { return "builtin" }
+
+func (f *builtinFacts) Facts() (map[string]string, error) {
+	facts := map[string]string{
+		"cpus": strconv.Itoa(runtime.NumCPU()),
+		"os":   runtime.GOOS,
+		"arch": runtime.GOARCH,
+	}
+
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return nil, fmt.Errorf("reading memory: %w", err)
+	}
+	facts["memory_gb"] = strconv.FormatUint(vm.Total>>30, 10)
+
+	usage, err := disk.Usage(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading disk usage of %s: %w", f.path, err)
+	}
+	facts["disk_free_gb"] = strconv.FormatUint(usage.Free>>30, 10)
+
+	kernel, err := host.KernelVersion()
+	if err != nil {
+		return nil, fmt.Errorf("reading kernel version: %w", err)
+	}
+	facts["kernel"] = kernel
+
+	return facts, nil
+}
+
+// scriptFacts runs a script whose output lines are key=value facts.
+// Empty lines and lines starting with # are ignored.
+type scriptFacts struct {
+	path string
+}
+
+func (f *scriptFacts) Name() string { return "script" }
+
+func (f *scriptFacts) Facts() (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), factsScriptTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, f.path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("running %s: %w", f.path, err)
+	}
+
+	facts := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid line in %s output, expected key=value: %s", f.path, line)
+		}
+		facts[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return facts, scanner.Err()
+}
+
+// fileFacts reads the facts from a JSON file with an object whose values
+// are strings, numbers or booleans.
+type fileFacts struct {
+	path string
+}
+
+func (f *fileFacts) Name() string { return "file" }
+
+func (f *fileFacts) Facts() (map[string]string, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", f.path, err)
+	}
+
+	facts := make(map[string]string, len(values))
+	for k, v := range values {
+		switch v := v.(type) {
+		case string:
+			facts[k] = v
+		case json.Number:
+			facts[k] = v.String()
+		case bool:
+			facts[k] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("invalid value of %s in %s, expected a string, number or boolean", k, f.path)
+		}
+	}
+	return facts, nil
+}
+
+// factsProviders returns the facts providers enabled in the config, in
+// the order their facts are merged.
+func (a *Agent) factsProviders() []FactsProvider {
+	var providers []FactsProvider
+	if a.config.FactsBuiltin {
+		path := a.config.DataDir
+		if _, err := os.Stat(path); err != nil {
+			path = "/"
+		}
+		providers = append(providers, &builtinFacts{path: path})
+	}
+	if a.config.FactsFile != "" {
+		providers = append(providers, &fileFacts{path: a.config.FactsFile})
+	}
+	if a.config.FactsScript != "" {
+		providers = append(providers, &scriptFacts{path: a.config.FactsScript})
+	}
+	return providers
+}
+
+// gatherFacts returns the facts of all the providers, later providers
+// overriding the facts of earlier ones. known holds the last facts of
+// each provider, providers that fail keep them so their tags don't go
+// away on transient errors.
+func (a *Agent) gatherFacts(providers []FactsProvider, known []map[string]string) map[string]string {
+	facts := make(map[string]string)
+	for i, p := range providers {
+		pf, err := p.Facts()
+		if err != nil {
+			a.logger.WithError(err).WithField("provider", p.Name()).Warn("agent: Error gathering facts")
+			pf = known[i]
+		} else {
+			known[i] = pf
+		}
+		for k, v := range pf {
+			facts[k] = v
+		}
+	}
+	return facts
+}
+
+// mergeFactTags returns the node tags with the given facts. Tags not set
+// from facts, configured or updated with UpdateTags, take precedence over
+// facts. owned holds the tags set from facts, it's updated with the ones
+// in the returned tags.
+func mergeFactTags(current, facts map[string]string, owned map[string]bool) map[string]string {
+	tags := make(map[string]string, len(current)+len(facts))
+	for k, v := range current {
+		if !owned[k] {
+			tags[k] = v
+		}
+	}
+
+	clear(owned)
+	for k, v := range facts {
+		if _, ok := tags[k]; !ok {
+			tags[k] = v
+			owned[k] = true
+		}
+	}
+	return tags
+}
+
+// factsLoop refreshes the node tags with the facts of the providers
+// periodically, until serf shuts down.
+func (a *Agent) factsLoop(providers []FactsProvider) {
+	interval := a.config.FactsInterval
+	if interval <= 0 {
+		interval = DefaultConfig().FactsInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last map[string]string
+	known := make([]map[string]string, len(providers))
+	owned := make(map[string]bool)
+	for {
+		facts := a.gatherFacts(providers, known)
+		if !reflect.DeepEqual(facts, last) {
+			a.logger.WithField("facts", facts).Debug("agent: Updating tags with facts")
+			a.UpdateTags(mergeFactTags(a.serf.LocalMember().Tags, facts, owned))
+			last = facts
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.serf.ShutdownCh():
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package dkron
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticFacts struct {
+	facts map[string]string
+	err   error
+}
+
+func (f *staticFacts) Name() string                      { return "static" }
+func (f *staticFacts) Facts() (map[string]string, error) { return f.facts, f.err }
+
+func TestBuiltinFacts(t *testing.T) {
+	facts, err := (&builtinFacts{path: os.TempDir()}).Facts()
+	require.NoError(t, err)
+
+	for _, k := range []string{"cpus", "memory_gb", "disk_free_gb", "os", "arch", "kernel"} {
+		assert.Contains(t, facts, k)
+	}
+	assert.Equal(t, runtime.GOOS, facts["os"])
+
+	// Numeric facts can be targeted with tag expressions
+	for _, k := range []string{"cpus", "memory_gb", "disk_free_gb"} {
+		sel, err := parseTagSelector(">= 0")
+		require.NoError(t, err)
+		assert.True(t, sel.matches(facts[k], true), k)
+	}
+}
+
+func TestScriptFacts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "facts.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho '# node facts'\necho 'gpu=nvidia-a100'\necho\necho ' rack = r12 '\n"), 0755))
+
+	facts, err := (&scriptFacts{path: script}).Facts()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"gpu": "nvidia-a100", "rack": "r12"}, facts)
+
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho 'not a fact'\n"), 0755))
+	_, err = (&scriptFacts{path: script}).Facts()
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nexit 1\n"), 0755))
+	_, err = (&scriptFacts{path: script}).Facts()
+	assert.Error(t, err)
+}
+
+func TestFileFacts(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "facts.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"rack": "r12", "gpus": 4, "ssd": true}`), 0644))
+
+	facts, err := (&fileFacts{path: file}).Facts()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"rack": "r12", "gpus": "4", "ssd": "true"}, facts)
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"rack": {"row": 1}}`), 0644))
+	_, err = (&fileFacts{path: file}).Facts()
+	assert.Error(t, err)
+
+	_, err = (&fileFacts{path: filepath.Join(t.TempDir(), "missing.json")}).Facts()
+	assert.Error(t, err)
+}
+
+func TestAgentGatherFacts(t *testing.T) {
+	a := &Agent{config: DefaultConfig(), logger: getTestLogger()}
+	assert.Empty(t, a.factsProviders())
+
+	a.config.FactsBuiltin = true
+	a.config.FactsFile = "facts.json"
+	a.config.FactsScript = "facts.sh"
+	providers := a.factsProviders()
+	require.Len(t, providers, 3)
+	assert.Equal(t, "builtin", providers[0].Name())
+	assert.Equal(t, "file", providers[1].Name())
+	assert.Equal(t, "script", providers[2].Name())
+
+	// Later providers override earlier ones
+	failing := &staticFacts{facts: map[string]string{"gpu": "a100"}}
+	providers = []FactsProvider{
+		&staticFacts{facts: map[string]string{"rack": "r1", "zone": "eu-1a"}},
+		failing,
+		&staticFacts{facts: map[string]string{"rack": "r2"}},
+	}
+	known := make([]map[string]string, len(providers))
+	facts := a.gatherFacts(providers, known)
+	assert.Equal(t, map[string]string{"gpu": "a100", "rack": "r2", "zone": "eu-1a"}, facts)
+
+	// Failing providers keep their last facts
+	failing.facts, failing.err = nil, errors.New("unavailable")
+	assert.Equal(t, facts, a.gatherFacts(providers, known))
+
+	// Providers that never succeeded add nothing
+	known = make([]map[string]string, len(providers))
+	assert.Equal(t, map[string]string{"rack": "r2", "zone": "eu-1a"}, a.gatherFacts(providers, known))
+}
+
+func TestMergeFactTags(t *testing.T) {
+	owned := make(map[string]bool)
+	current := map[string]string{"role": "dkron", "rack": "configured"}
+
+	// Other tags take precedence over facts
+	tags := mergeFactTags(current, map[string]string{"rack": "r1", "zone": "eu-1a"}, owned)
+	assert.Equal(t, map[string]string{"role": "dkron", "rack": "configured", "zone": "eu-1a"}, tags)
+	assert.Equal(t, map[string]bool{"zone": true}, owned)
+
+	// Tags updated since are kept, facts that went away are removed
+	tags["team"] = "billing"
+	tags = mergeFactTags(tags, map[string]string{"cpus": "8"}, owned)
+	assert.Equal(t, map[string]string{"role": "dkron", "rack": "configured", "team": "billing", "cpus": "8"}, tags)
+	assert.Equal(t, map[string]bool{"cpus": true}, owned)
+}
@@ -25,6 +25,18 @@ const (
 	// ExecutionDoneType is the command to perform the logic needed once an execution
 	// is done.
 	ExecutionDoneType
+	// SetCalendarType is the command used to store a calendar in the store.
+	SetCalendarType
+	// DeleteCalendarType is the command used to delete a calendar from the store.
+	DeleteCalendarType
+	// SetMaintenanceWindowType is the command used to store a maintenance window in the store.
+	SetMaintenanceWindowType
+	// DeleteMaintenanceWindowType is the command used to delete a maintenance window from the store.
+	DeleteMaintenanceWindowType
+	// SetPoolType is the command used to store a resource pool in the store.
+	SetPoolType
+	// DeletePoolType is the command used to delete a resource pool from the store.
+	DeletePoolType
 )
 
 // LogApplier is the definition of a function that can apply a Raft log
@@ -71,6 +83,18 @@ func (d *dkronFSM) Apply(l *raft.Log) interface{} {
 		return d.applyExecutionDone(ctx, buf[1:])
 	case SetExecutionType:
 		return d.applySetExecution(ctx, buf[1:])
+	case SetCalendarType:
+		return d.applySetCalendar(ctx, buf[1:])
+	case DeleteCalendarType:
+		return d.applyDeleteCalendar(ctx, buf[1:])
+	case SetMaintenanceWindowType:
+		return d.applySetMaintenanceWindow(ctx, buf[1:])
+	case DeleteMaintenanceWindowType:
+		return d.applyDeleteMaintenanceWindow(ctx, buf[1:])
+	case SetPoolType:
+		return d.applySetPool(ctx, buf[1:])
+	case DeletePoolType:
+		return d.applyDeletePool(ctx, buf[1:])
 	}
 
 	// Check enterprise only message types.
@@ -150,6 +174,75 @@ func (d *dkronFSM) applySetExecution(ctx context.Context, buf []byte) interface{
 	return key
 }
 
+func (d *dkronFSM) applySetCalendar(ctx context.Context, buf []byte) interface{} {
+	var pc dkronpb.Calendar
+	if err := proto.Unmarshal(buf, &pc); err != nil {
+		return err
+	}
+	if err := d.store.SetCalendar(ctx, NewCalendarFromProto(&pc)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *dkronFSM) applyDeleteCalendar(ctx context.Context, buf []byte) interface{} {
+	var dcr dkronpb.DeleteCalendarRequest
+	if err := proto.Unmarshal(buf, &dcr); err != nil {
+		return err
+	}
+	calendar, err := d.store.DeleteCalendar(ctx, dcr.GetName())
+	if err != nil {
+		return err
+	}
+	return calendar
+}
+
+func (d *dkronFSM) applySetMaintenanceWindow(ctx context.Context, buf []byte) interface{} {
+	var pmw dkronpb.MaintenanceWindow
+	if err := proto.Unmarshal(buf, &pmw); err != nil {
+		return err
+	}
+	if err := d.store.SetMaintenanceWindow(ctx, NewMaintenanceWindowFromProto(&pmw)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *dkronFSM) applyDeleteMaintenanceWindow(ctx context.Context, buf []byte) interface{} {
+	var dmr dkronpb.DeleteMaintenanceWindowRequest
+	if err := proto.Unmarshal(buf, &dmr); err != nil {
+		return err
+	}
+	mw, err := d.store.DeleteMaintenanceWindow(ctx, dmr.GetName())
+	if err != nil {
+		return err
+	}
+	return mw
+}
+
+func (d *dkronFSM) applySetPool(ctx context.Context, buf []byte) interface{} {
+	var pp dkronpb.Pool
+	if err := proto.Unmarshal(buf, &pp); err != nil {
+		return err
+	}
+	if err := d.store.SetPool(ctx, NewPoolFromProto(&pp)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *dkronFSM) applyDeletePool(ctx context.Context, buf []byte) interface{} {
+	var dpr dkronpb.DeletePoolRequest
+	if err := proto.Unmarshal(buf, &dpr); err != nil {
+		return err
+	}
+	pool, err := d.store.DeletePool(ctx, dpr.GetName())
+	if err != nil {
+		return err
+	}
+	return pool
+}
+
 // Snapshot returns a snapshot of the key-value store. We wrap
 // the things we need in dkronSnapshot and then send that over to Persist.
 // Persist encodes the needed data from dkronSnapshot and transport it to
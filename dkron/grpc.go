@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
@@ -16,6 +17,8 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -92,9 +95,11 @@ func (grpcs *GRPCServer) SetJob(ctx context.Context, setJobReq *typesv1.SetJobRe
 		return nil, err
 	}
 
-	// If everything is ok, add the job to the scheduler
+	// If everything is ok, add the job to the scheduler. Runs deferred
+	// before the update are dropped, the updated job defers its own.
 	job := NewJobFromProto(setJobReq.Job, grpcs.logger)
 	job.Agent = grpcs.agent
+	grpcs.agent.cancelDeferredRuns(job.Name)
 	if err := grpcs.agent.sched.AddJob(job); err != nil {
 		return nil, err
 	}
@@ -102,6 +107,31 @@ func (grpcs *GRPCServer) SetJob(ctx context.Context, setJobReq *typesv1.SetJobRe
 	return &typesv1.SetJobResponse{}, nil
 }
 
+// rearmJob adds back to the scheduler the @every-after schedule of the job
+// that ran the finished execution, counting its delay from the execution end.
+func (grpcs *GRPCServer) rearmJob(ctx context.Context, job *Job, execution *Execution) {
+	job.Agent = grpcs.agent
+	sj := job
+	if i, err := strconv.Atoi(execution.Metadata["schedule_index"]); err == nil {
+		sj = job.withSchedule(i)
+	}
+	if err := grpcs.agent.sched.rearmJob(sj, execution.FinishedAt); err != nil {
+		grpcs.logger.WithError(err).WithField("job", job.Name).Error("grpc: Error arming job again")
+		return
+	}
+
+	// Store the next run, computed as when the job runs as the scheduler
+	// entry is empty from the moment the job fires until it's armed again.
+	next, ok := grpcs.agent.scheduledNext(ctx, job)
+	if !ok || !next.After(job.Next) {
+		return
+	}
+	job.Next = next
+	if err := grpcs.agent.applySetJob(job.ToProto()); err != nil {
+		grpcs.logger.WithError(err).WithField("job", job.Name).Error("grpc: Error storing job next run")
+	}
+}
+
 // DeleteJob broadcast a state change to the cluster members that will delete the job.
 // This only works on the leader
 func (grpcs *GRPCServer) DeleteJob(ctx context.Context, delJobReq *typesv1.DeleteJobRequest) (*typesv1.DeleteJobResponse, error) {
@@ -125,6 +155,7 @@ func (grpcs *GRPCServer) DeleteJob(ctx context.Context, delJobReq *typesv1.Delet
 
 	// If everything is ok, remove the job
 	grpcs.agent.sched.RemoveJob(job.Name)
+	grpcs.agent.cancelDeferredRuns(job.Name)
 	if job.Ephemeral {
 		grpcs.logger.WithField("job", job.Name).Info("grpc: Done deleting ephemeral job")
 	}
@@ -132,6 +163,144 @@ func (grpcs *GRPCServer) DeleteJob(ctx context.Context, delJobReq *typesv1.Delet
 	return &typesv1.DeleteJobResponse{Job: jpb}, nil
 }
 
+// SetCalendar broadcast a state change to the cluster members that will store the calendar.
+// This only works on the leader
+func (grpcs *GRPCServer) SetCalendar(ctx context.Context, setCalendarReq *typesv1.SetCalendarRequest) (*typesv1.SetCalendarResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_calendar"}, time.Now())
+	grpcs.logger.WithField("calendar", setCalendarReq.GetCalendar().GetName()).Debug("grpc: Received SetCalendar")
+
+	cmd, err := Encode(SetCalendarType, setCalendarReq.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	if err, ok := af.Response().(error); ok {
+		return nil, err
+	}
+
+	return &typesv1.SetCalendarResponse{Calendar: setCalendarReq.Calendar}, nil
+}
+
+// DeleteCalendar broadcast a state change to the cluster members that will delete the calendar.
+// This only works on the leader
+func (grpcs *GRPCServer) DeleteCalendar(ctx context.Context, delCalendarReq *typesv1.DeleteCalendarRequest) (*typesv1.DeleteCalendarResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "delete_calendar"}, time.Now())
+	grpcs.logger.WithField("calendar", delCalendarReq.GetName()).Debug("grpc: Received DeleteCalendar")
+
+	cmd, err := Encode(DeleteCalendarType, delCalendarReq)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	calendar, ok := res.(*Calendar)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in DeleteCalendar: %v", res)
+	}
+
+	return &typesv1.DeleteCalendarResponse{Calendar: calendar.ToProto()}, nil
+}
+
+// SetMaintenanceWindow broadcast a state change to the cluster members that will store the maintenance window.
+// This only works on the leader
+func (grpcs *GRPCServer) SetMaintenanceWindow(ctx context.Context, setReq *typesv1.SetMaintenanceWindowRequest) (*typesv1.SetMaintenanceWindowResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_maintenance_window"}, time.Now())
+	grpcs.logger.WithField("maintenance", setReq.GetWindow().GetName()).Debug("grpc: Received SetMaintenanceWindow")
+
+	cmd, err := Encode(SetMaintenanceWindowType, setReq.Window)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	if err, ok := af.Response().(error); ok {
+		return nil, err
+	}
+
+	return &typesv1.SetMaintenanceWindowResponse{Window: setReq.Window}, nil
+}
+
+// DeleteMaintenanceWindow broadcast a state change to the cluster members that will delete the maintenance window.
+// This only works on the leader
+func (grpcs *GRPCServer) DeleteMaintenanceWindow(ctx context.Context, delReq *typesv1.DeleteMaintenanceWindowRequest) (*typesv1.DeleteMaintenanceWindowResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "delete_maintenance_window"}, time.Now())
+	grpcs.logger.WithField("maintenance", delReq.GetName()).Debug("grpc: Received DeleteMaintenanceWindow")
+
+	cmd, err := Encode(DeleteMaintenanceWindowType, delReq)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	mw, ok := res.(*MaintenanceWindow)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in DeleteMaintenanceWindow: %v", res)
+	}
+
+	return &typesv1.DeleteMaintenanceWindowResponse{Window: mw.ToProto()}, nil
+}
+
+// SetPool broadcast a state change to the cluster members that will store the resource pool.
+// This only works on the leader
+func (grpcs *GRPCServer) SetPool(ctx context.Context, setReq *typesv1.SetPoolRequest) (*typesv1.SetPoolResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_pool"}, time.Now())
+	grpcs.logger.WithField("pool", setReq.GetPool().GetName()).Debug("grpc: Received SetPool")
+
+	cmd, err := Encode(SetPoolType, setReq.Pool)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	if err, ok := af.Response().(error); ok {
+		return nil, err
+	}
+
+	// Runs waiting for the pool can start if it grew
+	grpcs.agent.pools.resize(setReq.Pool.Name, int(setReq.Pool.Slots))
+
+	return &typesv1.SetPoolResponse{Pool: setReq.Pool}, nil
+}
+
+// DeletePool broadcast a state change to the cluster members that will delete the resource pool.
+// This only works on the leader
+func (grpcs *GRPCServer) DeletePool(ctx context.Context, delReq *typesv1.DeletePoolRequest) (*typesv1.DeletePoolResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "delete_pool"}, time.Now())
+	grpcs.logger.WithField("pool", delReq.GetName()).Debug("grpc: Received DeletePool")
+
+	cmd, err := Encode(DeletePoolType, delReq)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	pool, ok := res.(*Pool)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in DeletePool: %v", res)
+	}
+
+	return &typesv1.DeletePoolResponse{Pool: pool.ToProto()}, nil
+}
+
 // DeleteExecutions removes all executions for a job and resets counters
 func (grpcs *GRPCServer) DeleteExecutions(ctx context.Context, delExecReq *typesv1.DeleteExecutionsRequest) (*typesv1.DeleteExecutionsResponse, error) {
 	defer metrics.MeasureSince([]string{"grpc", "delete_executions"}, time.Now())
@@ -231,6 +400,12 @@ func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *typesv1
 
 	// If the execution failed, retry it until retries limit (default: don't retry)
 	execution := NewExecutionFromProto(pbex)
+
+	// The execution pool slots are free, retries take them again
+	grpcs.agent.releasePoolSlots(execution)
+
+	// The node can run the next job waiting for it
+	grpcs.agent.dequeueCapacityRun(execution.NodeName)
 	if !execution.Success &&
 		uint(execution.Attempt) < job.Retries+1 {
 		// Increment the attempt counter
@@ -238,6 +413,7 @@ func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *typesv1
 
 		// Keep all execution properties intact except the last output
 		execution.Output = ""
+		execution.Result = ""
 
 		eb := execution.CalculateExponentialBackoff()
 		grpcs.logger.WithFields(logrus.Fields{
@@ -248,14 +424,54 @@ func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *typesv1
 
 		time.Sleep(eb)
 
-		if _, err := grpcs.agent.Run(ctx, job.Name, execution); err != nil {
-			return nil, err
+		// Retries outside of the job run windows are deferred or dropped
+		job.Agent = grpcs.agent
+		retry := func() {
+			if _, err := grpcs.agent.Run(context.Background(), job.Name, execution); err != nil {
+				grpcs.logger.WithError(err).WithField("job", job.Name).Error("grpc: Error running deferred retry")
+			}
+		}
+		runnable, deferred := job.checkRunWindow(grpcs.logger, time.Now(), execution.Group, retry)
+		if runnable {
+			if _, err := grpcs.agent.Run(ctx, job.Name, execution); err != nil {
+				grpcs.agent.executionDropped(execution)
+				return nil, err
+			}
 		}
 
-		return &typesv1.ExecutionDoneResponse{
-			From:    grpcs.agent.config.NodeName,
-			Payload: []byte("retry"),
-		}, nil
+		if runnable || deferred {
+			return &typesv1.ExecutionDoneResponse{
+				From:    grpcs.agent.config.NodeName,
+				Payload: []byte("retry"),
+			}, nil
+		}
+	}
+
+	// Items of map jobs run the next pending item, the run finishes
+	// with the last item.
+	if isMapItem(execution) {
+		next, finished := grpcs.agent.maps.itemDone(execution)
+		if next != nil {
+			grpcs.agent.runMapItem(job.Name, next)
+		}
+		if !finished {
+			return &typesv1.ExecutionDoneResponse{
+				From:    grpcs.agent.config.NodeName,
+				Payload: []byte("saved"),
+			}, nil
+		}
+	}
+
+	// A run of the job finished, start the next queued one. Runs in
+	// several nodes finish with the last of their executions.
+	runDone := isMapItem(execution) || grpcs.agent.runs.done(execution)
+	if runDone {
+		grpcs.agent.dequeueRun(job.Name)
+
+		// Jobs with @every-after schedules are armed again from the run end
+		if job.completionAnchored() && grpcs.agent.sched.Started() {
+			grpcs.rearmJob(ctx, job, execution)
+		}
 	}
 
 	exg, err := grpcs.agent.Store.GetExecutionGroup(ctx, execution, &ExecutionOptions{
@@ -271,17 +487,29 @@ func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *typesv1
 		return nil, err
 	}
 
-	// Jobs that have dependent jobs are a bit more expensive because we need to call the Status() method for every execution.
-	// Check first if there's dependent jobs and then check for the job status to begin execution dependent jobs on success.
-	if len(job.DependentJobs) > 0 && job.Status == StatusSuccess {
+	// Dependent jobs run once the results of their parent jobs meet their
+	// conditions and trigger rule. Parents report once all the executions
+	// of their run finished, with its final status.
+	status := executionsStatus(exg)
+	if job.Map && status != StatusRunning {
+		status = mapStatus(job.MapSuccessRule, exg)
+	}
+	if len(job.DependentJobs) > 0 && runDone && status != StatusRunning {
 		for _, djn := range job.DependentJobs {
 			dj, err := grpcs.agent.Store.GetJob(ctx, djn, nil)
 			if err != nil {
 				return nil, err
 			}
+			run, workflowRunID := grpcs.agent.dependencies.parentDone(dj, job.Name, status, execution.WorkflowRunID)
+			if !run {
+				continue
+			}
 			dj.Agent = grpcs.agent
+			dj.workflowRunID = workflowRunID
+			dj.parentExecution = execution
 			grpcs.logger.WithField("job", djn).Debug("grpc: Running dependent job")
-			dj.Run()
+			now := time.Now()
+			dj.run(now, now, 0)
 		}
 	}
 
@@ -308,7 +536,13 @@ func (grpcs *GRPCServer) Leave(ctx context.Context, in *emptypb.Empty) (*emptypb
 
 // RunJob runs a job in the cluster
 func (grpcs *GRPCServer) RunJob(ctx context.Context, req *typesv1.RunJobRequest) (*typesv1.RunJobResponse, error) {
+	target := &RunTarget{Nodes: req.Nodes, Tags: req.Tags}
+	if err := target.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ex := NewExecution(req.JobName)
+	target.record(ex)
 	job, err := grpcs.agent.Run(ctx, req.JobName, ex)
 	if err != nil {
 		return nil, err
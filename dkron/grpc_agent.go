@@ -2,12 +2,17 @@ package dkron
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/armon/circbuf"
 	"github.com/hashicorp/go-metrics"
 	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/distribworks/dkron/v4/plugin"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -59,6 +64,14 @@ func (as *AgentServer) AgentRun(req *typesv1.AgentRunRequest, stream typesv1.Age
 		"job": job.Name,
 	}).Info("grpc_agent: Starting job")
 
+	// Reject the job if this node is running its maximum number of executions,
+	// the server will run it later.
+	if !as.agent.acquireExecutionSlot() {
+		as.logger.WithField("job", job.Name).Warn("grpc_agent: Rejecting job, no execution slots left")
+		return status.Error(codes.ResourceExhausted, ErrNodeSaturated.Error())
+	}
+	defer as.agent.releaseExecutionSlot()
+
 	output, _ := circbuf.NewBuffer(maxBufSize)
 
 	var success bool
@@ -83,10 +96,13 @@ func (as *AgentServer) AgentRun(req *typesv1.AgentRunRequest, stream typesv1.Age
 	// Check if executor exists
 	if executor, ok := as.agent.ExecutorPlugins[jex]; ok {
 		as.logger.WithField("plugin", jex).Debug("grpc_agent: calling executor plugin")
-		runningExecutions.Store(execution.GetGroup(), execution)
+		runningExecutions.Store(runningKey(execution), execution)
 		out, err := executor.Execute(&typesv1.ExecuteRequest{
-			JobName: job.Name,
-			Config:  exc,
+			JobName:       job.Name,
+			Config:        exc,
+			Parent:        req.GetParent(),
+			CaptureResult: len(job.DependentJobs) > 0,
+			MapItem:       mapItemFromMetadata(execution.GetMetadata()),
 		}, &statusAgentHelper{
 			stream:    stream,
 			execution: execution,
@@ -95,6 +111,9 @@ func (as *AgentServer) AgentRun(req *typesv1.AgentRunRequest, stream typesv1.Age
 		if err == nil && out.Error != "" {
 			err = errors.New(out.Error)
 		}
+		if err == nil && len(out.Result) > plugin.MaxResultSize {
+			err = fmt.Errorf("grpc_agent: result of %d bytes exceeds the limit of %d bytes", len(out.Result), plugin.MaxResultSize)
+		}
 		if err != nil {
 			as.logger.WithError(err).WithField("job", job.Name).WithField("plugin", executor).Error("grpc_agent: command error output")
 			success = false
@@ -105,6 +124,9 @@ func (as *AgentServer) AgentRun(req *typesv1.AgentRunRequest, stream typesv1.Age
 
 		if out != nil {
 			_, _ = output.Write(out.Output)
+			if success {
+				execution.Result = out.Result
+			}
 		}
 	} else {
 		as.logger.WithField("executor", jex).Error("grpc_agent: Specified executor is not present")
@@ -115,7 +137,7 @@ func (as *AgentServer) AgentRun(req *typesv1.AgentRunRequest, stream typesv1.Age
 	execution.Success = success
 	execution.Output = output.Bytes()
 
-	runningExecutions.Delete(execution.GetGroup())
+	runningExecutions.Delete(runningKey(execution))
 
 	// Send the final execution
 	if err := stream.Send(&typesv1.AgentRunStream{
@@ -132,3 +154,13 @@ func (as *AgentServer) AgentRun(req *typesv1.AgentRunRequest, stream typesv1.Age
 
 	return nil
 }
+
+// runningKey returns the key of a running execution, the execution group
+// or the group and item for items of map jobs, that share the group.
+func runningKey(execution *typesv1.Execution) string {
+	group := strconv.FormatInt(execution.GetGroup(), 10)
+	if i, ok := execution.GetMetadata()[mapIndexMetadata]; ok {
+		return group + "-" + i
+	}
+	return group
+}
@@ -26,14 +26,20 @@ type DkronGRPCClient interface {
 	GetJob(string, string) (*Job, error)
 	SetJob(*Job) error
 	DeleteJob(string) (*Job, error)
+	SetCalendar(*Calendar) error
+	DeleteCalendar(string) (*Calendar, error)
+	SetMaintenanceWindow(*MaintenanceWindow) error
+	DeleteMaintenanceWindow(string) (*MaintenanceWindow, error)
+	SetPool(*Pool) error
+	DeletePool(string) (*Pool, error)
 	DeleteExecutions(string) (*Job, error)
 	Leave(string) error
-	RunJob(string) (*Job, error)
+	RunJob(string, *RunTarget) (*Job, error)
 	RaftGetConfiguration(string) (*typesv1.RaftGetConfigurationResponse, error)
 	RaftRemovePeerByID(string, string) error
 	GetActiveExecutions(string) ([]*typesv1.Execution, error)
 	SetExecution(execution *typesv1.Execution) error
-	AgentRun(addr string, job *typesv1.Job, execution *typesv1.Execution) error
+	AgentRun(addr string, job *typesv1.Job, execution *typesv1.Execution, parent *typesv1.ParentExecution) error
 }
 
 // GRPCClient is the local implementation of the DkronGRPCClient interface.
@@ -235,6 +241,201 @@ func (grpcc *GRPCClient) DeleteJob(jobName string) (*Job, error) {
 	return job, nil
 }
 
+// SetCalendar calls the leader passing the calendar
+func (grpcc *GRPCClient) SetCalendar(calendar *Calendar) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetCalendar",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := typesv1.NewDkronClient(conn)
+	_, err = d.SetCalendar(context.Background(), &typesv1.SetCalendarRequest{
+		Calendar: calendar.ToProto(),
+	})
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetCalendar",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+	return nil
+}
+
+// DeleteCalendar calls the leader passing the calendar name
+func (grpcc *GRPCClient) DeleteCalendar(name string) (*Calendar, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteCalendar",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := typesv1.NewDkronClient(conn)
+	res, err := d.DeleteCalendar(context.Background(), &typesv1.DeleteCalendarRequest{
+		Name: name,
+	})
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteCalendar",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewCalendarFromProto(res.Calendar), nil
+}
+
+// SetMaintenanceWindow calls the leader passing the maintenance window
+func (grpcc *GRPCClient) SetMaintenanceWindow(mw *MaintenanceWindow) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := typesv1.NewDkronClient(conn)
+	_, err = d.SetMaintenanceWindow(context.Background(), &typesv1.SetMaintenanceWindowRequest{
+		Window: mw.ToProto(),
+	})
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+	return nil
+}
+
+// DeleteMaintenanceWindow calls the leader passing the maintenance window name
+func (grpcc *GRPCClient) DeleteMaintenanceWindow(name string) (*MaintenanceWindow, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := typesv1.NewDkronClient(conn)
+	res, err := d.DeleteMaintenanceWindow(context.Background(), &typesv1.DeleteMaintenanceWindowRequest{
+		Name: name,
+	})
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewMaintenanceWindowFromProto(res.Window), nil
+}
+
+// SetPool calls the leader passing the resource pool
+func (grpcc *GRPCClient) SetPool(pool *Pool) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetPool",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := typesv1.NewDkronClient(conn)
+	_, err = d.SetPool(context.Background(), &typesv1.SetPoolRequest{
+		Pool: pool.ToProto(),
+	})
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetPool",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+	return nil
+}
+
+// DeletePool calls the leader passing the resource pool name
+func (grpcc *GRPCClient) DeletePool(name string) (*Pool, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeletePool",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := typesv1.NewDkronClient(conn)
+	res, err := d.DeletePool(context.Background(), &typesv1.DeletePoolRequest{
+		Name: name,
+	})
+	if err != nil {
+		grpcc.logger.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeletePool",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewPoolFromProto(res.Pool), nil
+}
+
 // DeleteExecutions calls the leader to delete all executions for a job and reset counters
 func (grpcc *GRPCClient) DeleteExecutions(jobName string) (*Job, error) {
 	if jobName == "" {
@@ -274,8 +475,9 @@ func (grpcc *GRPCClient) DeleteExecutions(jobName string) (*Job, error) {
 	return job, nil
 }
 
-// RunJob calls the leader passing the job name
-func (grpcc *GRPCClient) RunJob(jobName string) (*Job, error) {
+// RunJob calls the leader passing the job name and optionally the nodes
+// to run it on instead of the ones matching its tags
+func (grpcc *GRPCClient) RunJob(jobName string, target *RunTarget) (*Job, error) {
 	var conn *grpc.ClientConn
 
 	addr := grpcc.agent.raft.Leader()
@@ -293,9 +495,14 @@ func (grpcc *GRPCClient) RunJob(jobName string) (*Job, error) {
 
 	// Synchronous call
 	d := typesv1.NewDkronClient(conn)
-	res, err := d.RunJob(context.Background(), &typesv1.RunJobRequest{
+	req := &typesv1.RunJobRequest{
 		JobName: jobName,
-	})
+	}
+	if target != nil {
+		req.Nodes = target.Nodes
+		req.Tags = target.Tags
+	}
+	res, err := d.RunJob(context.Background(), req)
 	if err != nil {
 		grpcc.logger.WithError(err).WithFields(logrus.Fields{
 			"method":      "RunJob",
@@ -428,8 +635,9 @@ func (grpcc *GRPCClient) SetExecution(execution *typesv1.Execution) error {
 	return nil
 }
 
-// AgentRun runs a job in the given agent
-func (grpcc *GRPCClient) AgentRun(addr string, job *typesv1.Job, execution *typesv1.Execution) error {
+// AgentRun runs a job in the given agent, passing it the parent execution
+// that triggered the run, if any.
+func (grpcc *GRPCClient) AgentRun(addr string, job *typesv1.Job, execution *typesv1.Execution, parent *typesv1.ParentExecution) error {
 	defer metrics.MeasureSince([]string{"grpc_client", "agent_run"}, time.Now())
 
 	maxRetries := grpcc.agent.config.AgentRunMaxRetries
@@ -467,7 +675,7 @@ func (grpcc *GRPCClient) AgentRun(addr string, job *typesv1.Job, execution *type
 			time.Sleep(backoff)
 		}
 
-		err := grpcc.agentRunAttempt(addr, job, execution)
+		err := grpcc.agentRunAttempt(addr, job, execution, parent)
 		if err == nil {
 			// Success
 			if attempt > 0 {
@@ -482,6 +690,12 @@ func (grpcc *GRPCClient) AgentRun(addr string, job *typesv1.Job, execution *type
 
 		lastErr = err
 
+		// Saturated nodes rejected the run before starting it, the caller
+		// waits for them to finish an execution instead of retrying.
+		if status.Code(err) == codes.ResourceExhausted {
+			return err
+		}
+
 		// Check if error is retryable
 		if !isRetryableError(err) {
 			grpcc.logger.WithError(err).WithFields(logrus.Fields{
@@ -529,7 +743,7 @@ func isRetryableError(err error) bool {
 }
 
 // agentRunAttempt performs a single attempt of AgentRun
-func (grpcc *GRPCClient) agentRunAttempt(addr string, job *typesv1.Job, execution *typesv1.Execution) error {
+func (grpcc *GRPCClient) agentRunAttempt(addr string, job *typesv1.Job, execution *typesv1.Execution, parent *typesv1.ParentExecution) error {
 	var conn *grpc.ClientConn
 
 	// Initiate a connection with the server
@@ -548,6 +762,7 @@ func (grpcc *GRPCClient) agentRunAttempt(addr string, job *typesv1.Job, executio
 	stream, err := a.AgentRun(context.Background(), &typesv1.AgentRunRequest{
 		Job:       job,
 		Execution: execution,
+		Parent:    parent,
 	})
 	if err != nil {
 		return err
@@ -559,6 +774,9 @@ func (grpcc *GRPCClient) agentRunAttempt(addr string, job *typesv1.Job, executio
 
 		// Stream ends
 		if err == io.EOF {
+			// The execution is no longer running, runs of the job waiting
+			// for it start when it's done.
+			grpcc.agent.activeExecutions.Delete(execution.Key())
 			addr := grpcc.agent.raft.Leader()
 			if err := grpcc.ExecutionDone(string(addr), NewExecutionFromProto(execution)); err != nil {
 				return err
@@ -568,6 +786,11 @@ func (grpcc *GRPCClient) agentRunAttempt(addr string, job *typesv1.Job, executio
 
 		// Error received from the stream
 		if err != nil {
+			// The node rejected the job before starting it, it can run it later
+			if !first && status.Code(err) == codes.ResourceExhausted {
+				return err
+			}
+
 			// At this point the execution status will be unknown, set the FinishedAt time and an explanatory message
 			execution.FinishedAt = timestamppb.Now()
 			execution.Success = false
@@ -575,6 +798,7 @@ func (grpcc *GRPCClient) agentRunAttempt(addr string, job *typesv1.Job, executio
 
 			grpcc.logger.WithError(err).Error(ErrBrokenStream)
 
+			grpcc.agent.activeExecutions.Delete(execution.Key())
 			addr := grpcc.agent.raft.Leader()
 			if err := grpcc.ExecutionDone(string(addr), NewExecutionFromProto(execution)); err != nil {
 				return err
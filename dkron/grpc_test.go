@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/distribworks/dkron/v4/extcron"
 	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
 	"github.com/hashicorp/serf/testutil"
 	"github.com/spf13/viper"
@@ -80,7 +81,9 @@ func TestGRPCExecutionDone(t *testing.T) {
 		FinishedAt: time.Now(),
 		Success:    true,
 		Output:     "test",
+		Result:     `["a.csv","b.csv"]`,
 	}
+	testExecution.WorkflowRunID = workflowRunID(testJob.Name, testExecution.Group)
 
 	log := getTestLogger()
 	rc := NewGRPCClient(nil, a, log)
@@ -101,6 +104,129 @@ func TestGRPCExecutionDone(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.Len(t, execs, 1)
+		assert.Equal(t, testExecution.WorkflowRunID, execs[0].WorkflowRunID)
+
+		parent := parentFromMetadata(execs[0].Metadata)
+		require.NotNil(t, parent)
+		assert.Equal(t, testJob.Name, parent.JobName)
+		assert.Equal(t, testExecution.Key(), parent.ExecutionId)
+		assert.Equal(t, testExecution.Group, parent.Group)
+		assert.Equal(t, "testNode", parent.NodeName)
+		assert.True(t, parent.Success)
+
+		// The parent result is read from the stored parent execution
+		// instead of copied to the metadata of the dependent job
+		assert.NotContains(t, execs[0].Metadata, "parent_result")
+		assert.Equal(t, testExecution.Result, string(a.parentExecution(ctx, execs[0]).Result))
+	})
+
+	t.Run("Should run dependent jobs once the parent finished in all its nodes", func(t *testing.T) {
+		parent := &Job{
+			Name:           "multi",
+			Schedule:       "@manually",
+			Executor:       "shell",
+			ExecutorConfig: map[string]string{"command": "/bin/true"},
+			Disabled:       true,
+		}
+		require.NoError(t, a.Store.SetJob(ctx, parent, true))
+		child := &Job{
+			Name:             "multi-cleanup",
+			ParentJob:        parent.Name,
+			ParentConditions: map[string]string{parent.Name: ConditionOnFailure},
+			Executor:         "shell",
+			ExecutorConfig:   map[string]string{"command": "/bin/true"},
+		}
+		require.NoError(t, a.Store.SetJob(ctx, child, true))
+
+		// The parent run is dispatched to two nodes, one still running
+		group := time.Now().UnixNano()
+		execution := func(node string, success bool) *Execution {
+			return &Execution{
+				JobName:       parent.Name,
+				Group:         group,
+				StartedAt:     time.Now(),
+				NodeName:      node,
+				FinishedAt:    time.Now(),
+				Success:       success,
+				Attempt:       1,
+				WorkflowRunID: workflowRunID(parent.Name, group),
+			}
+		}
+		first, second := execution("node1", true), execution("node2", false)
+		a.runs.start(first, 2)
+		running := *second
+		running.FinishedAt = time.Time{}
+		_, err := a.Store.SetExecution(ctx, &running)
+		require.NoError(t, err)
+
+		require.NoError(t, rc.ExecutionDone(a.advertiseRPCAddr(), first))
+		execs, _ := a.Store.GetExecutions(ctx, child.Name, &ExecutionOptions{})
+		assert.Empty(t, execs)
+
+		// The run failed in one of its nodes once the last one finished
+		require.NoError(t, rc.ExecutionDone(a.advertiseRPCAddr(), second))
+		execs, err = a.Store.GetExecutions(ctx, child.Name, &ExecutionOptions{})
+		require.NoError(t, err)
+		assert.Len(t, execs, 1)
+
+		_, err = a.Store.DeleteJob(ctx, child.Name)
+		require.NoError(t, err)
+		_, err = a.Store.DeleteJob(ctx, parent.Name)
+		require.NoError(t, err)
+	})
+
+	t.Run("Should arm @every-after jobs again once the run finished in all its nodes", func(t *testing.T) {
+		group := time.Now().UnixNano()
+		finished := time.Now().Truncate(time.Second)
+		loop := &Job{
+			Name:           "loop",
+			Schedule:       "@every-after 1h",
+			Executor:       "shell",
+			ExecutorConfig: map[string]string{"command": "/bin/true"},
+		}
+		loop.LastSuccess.Set(finished.Add(-2 * time.Hour))
+		require.NoError(t, a.Store.SetJob(ctx, loop, true))
+
+		// The job already fired, its entry waits for the run to finish
+		fired := extcron.EveryAfter(time.Hour)
+		fired.Anchor = finished.Add(-2 * time.Hour)
+		fired.Next(finished.Add(-3 * time.Hour))
+		loop.Agent = a
+		a.sched.Cron.Schedule(fired, loop)
+		defer a.sched.RemoveJob(loop.Name)
+
+		execution := func(node string) *Execution {
+			return &Execution{
+				JobName:       loop.Name,
+				Group:         group,
+				StartedAt:     finished.Add(-time.Minute),
+				NodeName:      node,
+				FinishedAt:    finished,
+				Success:       true,
+				Attempt:       1,
+				WorkflowRunID: workflowRunID(loop.Name, group),
+			}
+		}
+		first, second := execution("node1"), execution("node2")
+		a.runs.start(first, 2)
+
+		require.NoError(t, rc.ExecutionDone(a.advertiseRPCAddr(), first))
+		ej, ok := a.sched.GetEntryJob(loop.Name)
+		require.True(t, ok)
+		assert.True(t, ej.entry.Next.IsZero())
+
+		require.NoError(t, rc.ExecutionDone(a.advertiseRPCAddr(), second))
+		ej, ok = a.sched.GetEntryJob(loop.Name)
+		require.True(t, ok)
+		assert.True(t, finished.Add(time.Hour).Equal(ej.entry.Next), "got %s", ej.entry.Next)
+
+		stored, err := a.Store.GetJob(ctx, loop.Name, nil)
+		require.NoError(t, err)
+		assert.True(t, finished.Add(time.Hour).Equal(stored.Next), "got %s", stored.Next)
+
+		a.sched.RemoveJob(loop.Name)
+		_, err = a.Store.DeleteJob(ctx, loop.Name)
+		require.NoError(t, err)
 	})
 
 	t.Run("Should store execution on a deleted job", func(t *testing.T) {
@@ -0,0 +1,61 @@
+package dkron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// jitterDelay returns how long the run scheduled at t is delayed, a random
+// duration within the job jitter window, or one derived from the jitter
+// seed so the same run is always delayed the same.
+func (j *Job) jitterDelay(t time.Time) time.Duration {
+	if j.Jitter == "" {
+		return 0
+	}
+	window, err := time.ParseDuration(j.Jitter)
+	if err != nil || window <= 0 {
+		return 0
+	}
+
+	if j.JitterSeed == 0 {
+		return time.Duration(rand.Int63n(int64(window)))
+	}
+
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%d:%s:%d", j.JitterSeed, j.Name, t.Unix())
+	return time.Duration(h.Sum64() % uint64(window))
+}
+
+// minRunInterval returns the shortest time between the fire times of the
+// job after from, or zero if it has less than two.
+func (j *Job) minRunInterval(from time.Time) time.Duration {
+	runs, err := j.NextRuns(from, MaxPreviewCount, nil)
+	if err != nil {
+		return 0
+	}
+
+	var interval time.Duration
+	for i := 1; i < len(runs); i++ {
+		if d := runs[i].Sub(runs[i-1]); interval == 0 || d < interval {
+			interval = d
+		}
+	}
+	return interval
+}
+
+// validateJitter checks that the job jitter is shorter than the time
+// between its fire times after from, so delayed runs don't overlap the
+// next ones. It depends on the reference time, so it is only checked
+// when the job is submitted.
+func (j *Job) validateJitter(from time.Time) error {
+	d, err := time.ParseDuration(j.Jitter)
+	if err != nil || d <= 0 {
+		return nil
+	}
+	if interval := j.minRunInterval(from); interval > 0 && d >= interval {
+		return fmt.Errorf("job jitter %s must be shorter than the %s between the job runs", j.Jitter, interval)
+	}
+	return nil
+}
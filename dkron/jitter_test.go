@@ -0,0 +1,131 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobJitterDelay(t *testing.T) {
+	scheduled := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	job := &Job{Name: "test_job"}
+	assert.Zero(t, job.jitterDelay(scheduled))
+
+	job.Jitter = "30s"
+	for i := 0; i < 100; i++ {
+		delay := job.jitterDelay(scheduled)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.Less(t, delay, 30*time.Second)
+	}
+
+	// Seeded delays are stable for the same run and spread across jobs
+	job.JitterSeed = 42
+	delay := job.jitterDelay(scheduled)
+	assert.Less(t, delay, 30*time.Second)
+	assert.Equal(t, delay, job.jitterDelay(scheduled))
+
+	delays := map[time.Duration]bool{}
+	for _, name := range []string{"job_a", "job_b", "job_c", "job_d", "job_e"} {
+		other := &Job{Name: name, Jitter: "1h", JitterSeed: 42}
+		delays[other.jitterDelay(scheduled)] = true
+	}
+	assert.Len(t, delays, 5)
+}
+
+func TestJobValidateJitter(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := &Job{
+		Name:     "test_job",
+		Schedule: "0 0 * * * *",
+		Jitter:   "5m",
+	}
+	assert.NoError(t, job.Validate())
+	assert.NoError(t, job.validateJitter(from))
+
+	job.Jitter = "-5m"
+	assert.Error(t, job.Validate())
+
+	job.Jitter = "five minutes"
+	assert.Error(t, job.Validate())
+
+	// Jitter can't reach the next run, checked only against a given time
+	job.Jitter = "1h"
+	assert.NoError(t, job.Validate())
+	assert.Error(t, job.validateJitter(from))
+
+	job.Schedule = "@every 1m"
+	job.Jitter = "10m"
+	assert.Error(t, job.validateJitter(from))
+
+	job.Jitter = "59s"
+	assert.NoError(t, job.validateJitter(from))
+
+	// The interval depends on the reference time, the last run
+	// before the job expires has no next one
+	job.Schedule = "0 0 * * * *"
+	job.Jitter = "2h"
+	job.ExpiresAt.Set(from.Add(150 * time.Minute))
+	assert.Error(t, job.validateJitter(from))
+	assert.NoError(t, job.validateJitter(from.Add(time.Hour)))
+}
+
+func TestJobDeferredRunResumes(t *testing.T) {
+	sched := NewScheduler(getTestLogger())
+	require.NoError(t, sched.Start(nil, &Agent{}))
+	defer sched.Stop()
+	a := &Agent{sched: sched}
+
+	now := time.Now()
+	job := &Job{
+		Name:            "test_job",
+		Agent:           a,
+		RunWindowPolicy: RunWindowDefer,
+		RunWindows: []*RunWindow{{
+			Start: now.Add(2 * time.Hour).UTC().Format("15:04"),
+			End:   now.Add(3 * time.Hour).UTC().Format("15:04"),
+		}},
+	}
+
+	// Runs deferred to the next run window start again through resume
+	resumed := make(chan struct{}, 1)
+	assert.False(t, job.isRunnableAt(getTestLogger(), now, func() { resumed <- struct{}{} }))
+	a.deferredMu.Lock()
+	timer := a.deferredRuns[deferredRunKey{job: job.Name}]
+	a.deferredMu.Unlock()
+	require.NotNil(t, timer)
+	timer.Reset(0)
+
+	select {
+	case <-resumed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("deferred run not resumed")
+	}
+}
+
+func TestJobScheduledAt(t *testing.T) {
+	job := &Job{
+		Name:     "test_job",
+		Schedule: "0 0 * * * *",
+	}
+
+	started := time.Date(2024, 1, 1, 10, 0, 0, 3e6, time.UTC)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), job.scheduledAt(started).UTC())
+
+	// Too far from any fire time
+	started = time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC)
+	assert.Equal(t, started, job.scheduledAt(started))
+}
+
+func TestExecutionScheduledAtProto(t *testing.T) {
+	ex := NewExecution("test_job")
+	assert.True(t, NewExecutionFromProto(ex.ToProto()).ScheduledAt.IsZero())
+
+	ex.ScheduledAt = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	ex.JitterDelay = "12s"
+	got := NewExecutionFromProto(ex.ToProto())
+	require.True(t, ex.ScheduledAt.Equal(got.ScheduledAt))
+	assert.Equal(t, "12s", got.JitterDelay)
+}
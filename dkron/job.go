@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +35,86 @@ const (
 	ConcurrencyAllow = "allow"
 	// ConcurrencyForbid forbids a job from executing concurrency.
 	ConcurrencyForbid = "forbid"
+	// ConcurrencyQueue holds the runs of a job exceeding its concurrency
+	// limit until a running execution finishes.
+	ConcurrencyQueue = "queue"
+
+	// MisfireSkip drops any runs missed while the cluster had no leader.
+	MisfireSkip = "skip"
+	// MisfireRunOnce runs a job once if it missed one or more runs.
+	MisfireRunOnce = "run_once"
+	// MisfireRunAll runs a job once for every missed run, up to MaxMisfireRuns.
+	MisfireRunAll = "run_all"
+
+	// ScheduleFormatDkron is the default schedule format, cron specs have
+	// a mandatory seconds field.
+	ScheduleFormatDkron = "dkron6"
+	// ScheduleFormatCrontab is the classic crontab format, cron specs have
+	// five fields and run at second zero.
+	ScheduleFormatCrontab = "crontab5"
+
+	// DSTSkip drops the runs whose time doesn't exist or is repeated
+	// due to a daylight saving time change.
+	DSTSkip = "skip"
+	// DSTRunOnce runs at the transition the runs whose time doesn't exist
+	// and once the runs whose time is repeated.
+	DSTRunOnce = "run_once"
+	// DSTRunTwice runs at the transition the runs whose time doesn't exist
+	// and twice the runs whose time is repeated.
+	DSTRunTwice = "run_twice"
+	// DSTShiftForward shifts the runs whose time doesn't exist by the
+	// length of the gap, and runs once the runs whose time is repeated.
+	DSTShiftForward = "shift_forward"
+
+	// RunWindowSkip drops the runs outside of the job run windows.
+	RunWindowSkip = "skip"
+	// RunWindowDefer delays the runs outside of the job run windows
+	// until the next window opens.
+	RunWindowDefer = "defer"
+
+	// SelectionRandom picks the target nodes at random.
+	SelectionRandom = "random"
+	// SelectionRoundRobin picks the target nodes in turns, by node name.
+	SelectionRoundRobin = "round_robin"
+	// SelectionLeastLoaded picks the target nodes running the fewest executions.
+	SelectionLeastLoaded = "least_loaded"
+	// SelectionConsistentHash always picks the same target nodes for a job
+	// while they are available.
+	SelectionConsistentHash = "consistent_hash"
+	// SelectionSpread picks the target nodes spreading them across the
+	// values of a node tag, like availability zones.
+	SelectionSpread = "spread"
+
+	// TriggerAllSuccess runs a job after all its parent jobs succeed.
+	TriggerAllSuccess = "all_success"
+	// TriggerAnySuccess runs a job after the first of its parent jobs succeeds.
+	TriggerAnySuccess = "any_success"
+	// TriggerAllDone runs a job after all its parent jobs finish, whatever
+	// their result.
+	TriggerAllDone = "all_done"
+
+	// ConditionOnSuccess meets the dependency when the parent job succeeds.
+	ConditionOnSuccess = "on_success"
+	// ConditionOnFailure meets the dependency when the parent job fails in
+	// any of its nodes, partial failures included.
+	ConditionOnFailure = "on_failure"
+	// ConditionOnPartialFailure meets the dependency when the parent job
+	// succeeds in only some of its nodes.
+	ConditionOnPartialFailure = "on_partial_failure"
+	// ConditionAlways meets the dependency when the parent job finishes,
+	// whatever its result.
+	ConditionAlways = "always"
+
+	// DefaultPreviewCount is the number of fire times returned by a
+	// schedule preview when none is requested.
+	DefaultPreviewCount = 10
+	// MaxPreviewCount is the maximum number of fire times returned by a
+	// schedule preview.
+	MaxPreviewCount = 100
+
+	// MaxMisfireRuns is the maximum number of missed runs dispatched for a job
+	// using the run_all misfire policy.
+	MaxMisfireRuns = 100
 
 	// HashSymbol is the "magic" character used in scheduled to be replaced with a value based on job name
 	HashSymbol = "~"
@@ -45,6 +126,10 @@ const (
 	DefaultStaleExecutionThreshold = 4 * time.Hour
 )
 
+// scheduleTolerance is how late a job can start running after
+// its fire time and still be matched to it.
+const scheduleTolerance = 10 * time.Second
+
 var (
 	// ErrParentJobNotFound is returned when the parent job is not found.
 	ErrParentJobNotFound = errors.New("specified parent job not found")
@@ -57,7 +142,29 @@ var (
 	// ErrNoCommand is returned when attempting to store a job that has no command.
 	ErrNoCommand = errors.New("unspecified command for job")
 	// ErrWrongConcurrency is returned when Concurrency is set to a non existing setting.
-	ErrWrongConcurrency = errors.New("invalid concurrency policy value, use \"allow\" or \"forbid\"")
+	ErrWrongConcurrency = errors.New("invalid concurrency policy value, use \"allow\", \"forbid\" or \"queue\"")
+	// ErrWrongMaxConcurrency is returned when MaxConcurrency is set with a forbid concurrency policy.
+	ErrWrongMaxConcurrency = errors.New("max concurrency can't be greater than one with the forbid concurrency policy")
+	// ErrWrongMisfirePolicy is returned when MisfirePolicy is set to a non existing setting.
+	ErrWrongMisfirePolicy = errors.New("invalid misfire policy value, use \"skip\", \"run_once\" or \"run_all\"")
+	// ErrWrongScheduleFormat is returned when ScheduleFormat is set to a non existing setting.
+	ErrWrongScheduleFormat = errors.New("invalid schedule format value, use \"dkron6\" or \"crontab5\"")
+	// ErrWrongDSTPolicy is returned when DSTPolicy is set to a non existing setting.
+	ErrWrongDSTPolicy = errors.New("invalid dst policy value, use \"skip\", \"run_once\", \"run_twice\" or \"shift_forward\"")
+	// ErrWrongRunWindowPolicy is returned when RunWindowPolicy is set to a non existing setting.
+	ErrWrongRunWindowPolicy = errors.New("invalid run window policy value, use \"skip\" or \"defer\"")
+	// ErrWrongSelectionStrategy is returned when SelectionStrategy is set to a non existing setting.
+	ErrWrongSelectionStrategy = errors.New("invalid selection strategy value, use \"random\", \"round_robin\", \"least_loaded\", \"consistent_hash\" or \"spread\"")
+	// ErrWrongTriggerRule is returned when TriggerRule is set to a non existing setting.
+	ErrWrongTriggerRule = errors.New("invalid trigger rule value, use \"all_success\", \"any_success\" or \"all_done\"")
+	// ErrDependencyCycle is returned when the parent jobs of a job depend on it.
+	ErrDependencyCycle = errors.New("the job can not depend on its own dependent jobs")
+	// ErrWrongParentCondition is returned when a parent condition is set to a non existing setting.
+	ErrWrongParentCondition = errors.New("invalid parent condition value, use \"on_success\", \"on_failure\", \"on_partial_failure\" or \"always\"")
+	// ErrMapNoParent is returned when a map job has no parent job generating its items.
+	ErrMapNoParent = errors.New("map jobs need a parent job generating their items")
+	// ErrWrongMapSuccessRule is returned when MapSuccessRule is set to a non existing setting.
+	ErrWrongMapSuccessRule = errors.New("invalid map success rule value, use \"all_success\", \"any_success\" or a percentage like \"90%\"")
 )
 
 // Job describes a scheduled Job.
@@ -78,6 +185,9 @@ type Job struct {
 	// Cron expression for the job. When to run the job.
 	Schedule string `json:"schedule"`
 
+	// Format of the cron expression (dkron6, crontab5). Empty means dkron6.
+	ScheduleFormat string `json:"schedule_format"`
+
 	// Arbitrary string indicating the owner of the job.
 	Owner string `json:"owner"`
 
@@ -114,18 +224,69 @@ type Job struct {
 	// Jobs that are dependent upon this one will be run after this job runs.
 	DependentJobs []string `json:"dependent_jobs"`
 
+	// Condition of each dependent job upon this one, by job name.
+	DependentConditions map[string]string `json:"dependent_conditions"`
+
 	// Job pointer that are dependent upon this one
 	ChildJobs []*Job `json:"-"`
 
 	// Job id of job that this job is dependent upon.
 	ParentJob string `json:"parent_job"`
 
+	// Jobs that this job is dependent upon, it runs after them according
+	// to its trigger rule.
+	ParentJobs []string `json:"parent_jobs"`
+
+	// When the job runs after its parent jobs (all_success, any_success,
+	// all_done). Empty runs it after all of them succeed.
+	TriggerRule string `json:"trigger_rule"`
+
+	// Result of each parent job that meets its dependency (on_success,
+	// on_failure, on_partial_failure, always), by parent name. Parents not
+	// in the map are met when they succeed.
+	ParentConditions map[string]string `json:"parent_conditions"`
+
+	// Run the job once for every item of the JSON array in the result of
+	// the parent execution that triggers it.
+	Map bool `json:"map"`
+
+	// Maximum number of items of a map job running at the same time.
+	// Zero means no limit.
+	MaxParallel uint `json:"max_parallel"`
+
+	// Items of a map job that have to succeed for the job to succeed
+	// (all_success, any_success or a percentage like 90%). Empty means
+	// all_success.
+	MapSuccessRule string `json:"map_success_rule"`
+
 	// Processors to use for this job.
 	Processors map[string]plugin.Config `json:"processors"`
 
-	// Concurrency policy for this job (allow, forbid).
+	// Concurrency policy for this job (allow, forbid, queue).
 	Concurrency string `json:"concurrency"`
 
+	// Maximum number of runs of this job executing at the same time.
+	// Zero means no limit, or one with the forbid and queue policies.
+	MaxConcurrency uint `json:"max_concurrency"`
+
+	// Resource pool shared with other jobs this job takes slots of.
+	Pool string `json:"pool"`
+
+	// Slots of the pool a run of this job takes in each node. Zero means one.
+	PoolSlots uint `json:"pool_slots"`
+
+	// Priority of this job runs waiting in the dispatch queue, higher first.
+	Priority int `json:"priority"`
+
+	// How to pick the target nodes and the node of retries (random,
+	// round_robin, least_loaded, consistent_hash, spread). Empty picks
+	// them at random and retries in the same node.
+	SelectionStrategy string `json:"selection_strategy"`
+
+	// Node tag the spread strategy balances the target nodes across,
+	// zone by default.
+	SpreadTag string `json:"spread_tag"`
+
 	// Executor plugin to be used in this job.
 	Executor string `json:"executor"`
 
@@ -147,34 +308,104 @@ type Job struct {
 	// The job will not be executed after this time.
 	ExpiresAt ntime.NullableTime `json:"expires_at"`
 
+	// What to do with runs missed during leader failover or downtime
+	// (skip, run_once, run_all). Empty means skip.
+	MisfirePolicy string `json:"misfire_policy"`
+
+	// Missed runs older than this duration are not caught up.
+	// Empty means no limit.
+	MisfireGrace string `json:"misfire_grace"`
+
+	// Calendars with the days in which the job is not executed.
+	Calendars []string `json:"calendars"`
+
+	// How runs falling in a daylight saving time change are handled
+	// (skip, run_once, run_twice, shift_forward). Empty keeps the cron
+	// behaviour: runs in skipped hours are lost and runs in repeated
+	// hours happen twice.
+	DSTPolicy string `json:"dst_policy"`
+
+	// Maximum delay added to each scheduled run, to spread jobs with
+	// the same schedule. Empty means no delay.
+	Jitter string `json:"jitter"`
+
+	// If not zero, the delay is derived from this seed, the job name and
+	// the scheduled time instead of being random.
+	JitterSeed int64 `json:"jitter_seed"`
+
+	// Additional schedules of the job, each one can use its own
+	// timezone and executor config.
+	Schedules []*JobSchedule `json:"schedules"`
+
+	// Weekday and time ranges, in the job timezone, in which the job is
+	// allowed to start. Empty means always.
+	RunWindows []*RunWindow `json:"run_windows"`
+
+	// What to do with runs outside of the run windows (skip, defer).
+	// Empty means skip.
+	RunWindowPolicy string `json:"run_window_policy"`
+
+	// Position plus one in the parent job Schedules of the additional
+	// schedule this copy of the job runs, zero for the main schedule.
+	extraSchedule int
+
+	// workflow run this copy of the job runs in, when run by its parents.
+	workflowRunID string
+
+	// parent execution that triggered this copy of the job.
+	parentExecution *Execution
+
 	logger *logrus.Entry
 }
 
 // NewJobFromProto create a new Job from a PB Job struct
 func NewJobFromProto(in *proto.Job, logger *logrus.Entry) *Job {
 	job := &Job{
-		ID:             in.Name,
-		Name:           in.Name,
-		DisplayName:    in.Displayname,
-		Timezone:       in.Timezone,
-		Schedule:       in.Schedule,
-		Owner:          in.Owner,
-		OwnerEmail:     in.OwnerEmail,
-		SuccessCount:   int(in.SuccessCount),
-		ErrorCount:     int(in.ErrorCount),
-		Disabled:       in.Disabled,
-		Tags:           in.Tags,
-		Retries:        uint(in.Retries),
-		DependentJobs:  in.DependentJobs,
-		ParentJob:      in.ParentJob,
-		Concurrency:    in.Concurrency,
-		Executor:       in.Executor,
-		ExecutorConfig: in.ExecutorConfig,
-		Status:         in.Status,
-		Metadata:       in.Metadata,
-		Next:           in.GetNext().AsTime(),
-		Ephemeral:      in.Ephemeral,
-		logger:         logger,
+		ID:                  in.Name,
+		Name:                in.Name,
+		DisplayName:         in.Displayname,
+		Timezone:            in.Timezone,
+		Schedule:            in.Schedule,
+		ScheduleFormat:      in.ScheduleFormat,
+		Owner:               in.Owner,
+		OwnerEmail:          in.OwnerEmail,
+		SuccessCount:        int(in.SuccessCount),
+		ErrorCount:          int(in.ErrorCount),
+		Disabled:            in.Disabled,
+		Tags:                in.Tags,
+		Retries:             uint(in.Retries),
+		DependentJobs:       in.DependentJobs,
+		ParentJob:           in.ParentJob,
+		Concurrency:         in.Concurrency,
+		Executor:            in.Executor,
+		ExecutorConfig:      in.ExecutorConfig,
+		Status:              in.Status,
+		Metadata:            in.Metadata,
+		Next:                in.GetNext().AsTime(),
+		Ephemeral:           in.Ephemeral,
+		MisfirePolicy:       in.MisfirePolicy,
+		MisfireGrace:        in.MisfireGrace,
+		Calendars:           in.Calendars,
+		DSTPolicy:           in.DstPolicy,
+		Jitter:              in.Jitter,
+		JitterSeed:          in.JitterSeed,
+		Schedules:           newJobSchedulesFromProto(in.Schedules),
+		RunWindows:          newRunWindowsFromProto(in.RunWindows),
+		RunWindowPolicy:     in.RunWindowPolicy,
+		MaxConcurrency:      uint(in.MaxConcurrency),
+		Pool:                in.Pool,
+		PoolSlots:           uint(in.PoolSlots),
+		Priority:            int(in.Priority),
+		SelectionStrategy:   in.SelectionStrategy,
+		SpreadTag:           in.SpreadTag,
+		ParentJobs:          in.ParentJobs,
+		TriggerRule:         in.TriggerRule,
+		ParentConditions:    in.ParentConditions,
+		Map:                 in.Map,
+		MaxParallel:         uint(in.MaxParallel),
+		MapSuccessRule:      in.MapSuccessRule,
+		DependentConditions: in.DependentConditions,
+		logger:              logger,
 	}
 	if in.GetLastSuccess().GetHasValue() {
 		t := in.GetLastSuccess().GetTime().AsTime()
@@ -241,35 +472,59 @@ func (j *Job) ToProto() *proto.Job {
 		processors[k] = &proto.PluginConfig{Config: v}
 	}
 	return &proto.Job{
-		Name:           j.Name,
-		Displayname:    j.DisplayName,
-		Timezone:       j.Timezone,
-		Schedule:       j.Schedule,
-		Owner:          j.Owner,
-		OwnerEmail:     j.OwnerEmail,
-		SuccessCount:   int32(j.SuccessCount),
-		ErrorCount:     int32(j.ErrorCount),
-		Disabled:       j.Disabled,
-		Tags:           j.Tags,
-		Retries:        uint32(j.Retries),
-		DependentJobs:  j.DependentJobs,
-		ParentJob:      j.ParentJob,
-		Concurrency:    j.Concurrency,
-		Processors:     processors,
-		Executor:       j.Executor,
-		ExecutorConfig: j.ExecutorConfig,
-		Status:         j.Status,
-		Metadata:       j.Metadata,
-		LastSuccess:    lastSuccess,
-		LastError:      lastError,
-		Next:           next,
-		Ephemeral:      j.Ephemeral,
-		ExpiresAt:      expiresAt,
-		StartsAt:       startsAt,
+		Name:                j.Name,
+		Displayname:         j.DisplayName,
+		Timezone:            j.Timezone,
+		Schedule:            j.Schedule,
+		ScheduleFormat:      j.ScheduleFormat,
+		Owner:               j.Owner,
+		OwnerEmail:          j.OwnerEmail,
+		SuccessCount:        int32(j.SuccessCount),
+		ErrorCount:          int32(j.ErrorCount),
+		Disabled:            j.Disabled,
+		Tags:                j.Tags,
+		Retries:             uint32(j.Retries),
+		DependentJobs:       j.DependentJobs,
+		ParentJob:           j.ParentJob,
+		Concurrency:         j.Concurrency,
+		Processors:          processors,
+		Executor:            j.Executor,
+		ExecutorConfig:      j.ExecutorConfig,
+		Status:              j.Status,
+		Metadata:            j.Metadata,
+		LastSuccess:         lastSuccess,
+		LastError:           lastError,
+		Next:                next,
+		Ephemeral:           j.Ephemeral,
+		ExpiresAt:           expiresAt,
+		StartsAt:            startsAt,
+		MisfirePolicy:       j.MisfirePolicy,
+		MisfireGrace:        j.MisfireGrace,
+		Calendars:           j.Calendars,
+		DstPolicy:           j.DSTPolicy,
+		Jitter:              j.Jitter,
+		JitterSeed:          j.JitterSeed,
+		Schedules:           jobSchedulesToProto(j.Schedules),
+		RunWindows:          runWindowsToProto(j.RunWindows),
+		RunWindowPolicy:     j.RunWindowPolicy,
+		MaxConcurrency:      uint32(j.MaxConcurrency),
+		Pool:                j.Pool,
+		PoolSlots:           uint32(j.PoolSlots),
+		Priority:            int32(j.Priority),
+		SelectionStrategy:   j.SelectionStrategy,
+		SpreadTag:           j.SpreadTag,
+		ParentJobs:          j.ParentJobs,
+		TriggerRule:         j.TriggerRule,
+		ParentConditions:    j.ParentConditions,
+		Map:                 j.Map,
+		MaxParallel:         uint32(j.MaxParallel),
+		MapSuccessRule:      j.MapSuccessRule,
+		DependentConditions: j.DependentConditions,
 	}
 }
 
-// Run the job
+// Run the job, called by the scheduler at its fire times. Scheduled
+// runs are delayed by the job jitter.
 func (j *Job) Run() {
 	// As this function should comply with the Job interface of the cron package we will use
 	// the agent property on execution, this is why it need to check if it's set and otherwise fail.
@@ -277,8 +532,46 @@ func (j *Job) Run() {
 		j.logger.Fatal("job: agent not set")
 	}
 
+	scheduledAt := j.scheduledAt(time.Now())
+	delay := j.jitterDelay(scheduledAt)
+	if delay > 0 {
+		j.logger.WithFields(logrus.Fields{
+			"job":   j.Name,
+			"delay": delay,
+		}).Debug("job: Delaying run by jitter")
+		time.Sleep(delay)
+
+		// Leadership could have been lost in the meantime
+		if !j.Agent.sched.Started() {
+			j.logger.WithField("job", j.Name).
+				Debug("job: Skipping execution because scheduler stopped during jitter delay")
+			return
+		}
+	}
+
+	j.run(scheduledAt, time.Now(), delay)
+}
+
+// run starts the run of the job scheduled at scheduledAt and delayed by
+// delay, checking its calendars and run windows at the given time. Runs
+// deferred to the next run window or queued until a running execution
+// finishes start again through it, keeping their scheduled time and delay.
+func (j *Job) run(scheduledAt, at time.Time, delay time.Duration) {
+	resume := func() { j.run(scheduledAt, time.Now(), delay) }
+
 	// Check if it's runnable
-	if j.isRunnable(j.logger) {
+	if j.isRunnableAt(j.logger, at, resume) {
+		dst, adjusted := j.dstRun(scheduledAt)
+		if adjusted && dst.adjustment == dstSkipped {
+			j.logger.WithFields(logrus.Fields{
+				"job":            j.Name,
+				"scheduled_time": dst.wall.Format(time.DateTime),
+			}).Info("job: Skipping execution because of daylight saving time change")
+			j.Agent.recordSkippedExecution(j, fmt.Sprintf("Execution skipped by dst policy %s, scheduled time %s changed by daylight saving time",
+				j.DSTPolicy, dst.wall.Format(time.DateTime)), dst.metadata())
+			return
+		}
+
 		j.logger.WithFields(logrus.Fields{
 			"job":      j.Name,
 			"schedule": j.Schedule,
@@ -288,11 +581,28 @@ func (j *Job) Run() {
 
 		// Simple execution wrapper
 		ex := NewExecution(j.Name)
+		ex.ScheduledAt = scheduledAt
+		if delay > 0 {
+			ex.JitterDelay = delay.String()
+		}
+		if adjusted {
+			ex.Metadata = dst.metadata()
+		}
+		j.setScheduleMetadata(ex)
+		ex.WorkflowRunID = j.workflowRunID
+		j.setParentMetadata(ex)
 
 		if _, err := j.Agent.Run(context.Background(), j.Name, ex); err != nil {
 			j.logger.WithError(err).Error("job: Error running job")
 		}
 	}
+
+	// If no execution finished to arm the job again, count from now
+	if j.completionAnchored() {
+		if err := j.Agent.sched.rearmJob(j, time.Now()); err != nil {
+			j.logger.WithError(err).WithField("job", j.Name).Error("job: Error arming job again")
+		}
+	}
 }
 
 // Friendly format a job
@@ -300,6 +610,54 @@ func (j *Job) String() string {
 	return fmt.Sprintf("\"Job: %s, scheduled at: %s, tags:%v\"", j.Name, j.Schedule, j.Tags)
 }
 
+// parents returns the names of the jobs this job is dependent upon.
+func (j *Job) parents() []string {
+	if j.ParentJob == "" {
+		return j.ParentJobs
+	}
+	parents := []string{j.ParentJob}
+	for _, p := range j.ParentJobs {
+		if p != j.ParentJob {
+			parents = append(parents, p)
+		}
+	}
+	return parents
+}
+
+// parentCondition returns the result of the parent job that meets the
+// dependency of this job.
+func (j *Job) parentCondition(parent string) string {
+	if c := j.ParentConditions[parent]; c != "" {
+		return c
+	}
+	return ConditionOnSuccess
+}
+
+// validateParentCondition checks the parent condition is a known one.
+func validateParentCondition(condition string) error {
+	switch condition {
+	case "", ConditionOnSuccess, ConditionOnFailure, ConditionOnPartialFailure, ConditionAlways:
+		return nil
+	}
+	return ErrWrongParentCondition
+}
+
+// conditionMet returns whether a parent job that finished with the given
+// status meets the condition. Partially failed runs meet both on_failure
+// and on_partial_failure.
+func conditionMet(condition, status string) bool {
+	switch condition {
+	case ConditionAlways:
+		return true
+	case ConditionOnFailure:
+		return status == StatusFailed || status == StatusPartiallyFailed
+	case ConditionOnPartialFailure:
+		return status == StatusPartiallyFailed
+	default:
+		return status == StatusSuccess
+	}
+}
+
 // GetParent returns the parent job of a job
 func (j *Job) GetParent(ctx context.Context, store *Store) (*Job, error) {
 	if j.Name == j.ParentJob {
@@ -351,6 +709,10 @@ func (j *Job) scheduleHash() string {
 	hash := j.nameHash()
 	parts := strings.Split(spec, " ")
 	partIndex := 0
+	if j.ScheduleFormat == ScheduleFormatCrontab {
+		// crontab specs start at the minutes field
+		partIndex = 1
+	}
 	for index, part := range parts {
 		if strings.HasPrefix(part, "@") {
 			// this is a pre-defined scheduled, ignore everything
@@ -384,14 +746,52 @@ func (j *Job) scheduleHash() string {
 	return strings.Join(parts, " ")
 }
 
-// GetNext returns the job's next schedule from now
-func (j *Job) GetNext() (time.Time, error) {
+// scheduleSpec returns the job schedule with hashes replaced, and the
+// seconds field added if the schedule is in crontab format.
+func (j *Job) scheduleSpec() string {
+	spec := j.scheduleHash()
+	if j.ScheduleFormat != ScheduleFormatCrontab {
+		return spec
+	}
+
+	parts := strings.Split(spec, " ")
+	for i, part := range parts {
+		if strings.HasPrefix(part, "@") {
+			return spec
+		}
+		if strings.HasPrefix(part, "TZ=") || strings.HasPrefix(part, "CRON_TZ=") {
+			continue
+		}
+		parts = slices.Insert(parts, i, "0")
+		break
+	}
+	return strings.Join(parts, " ")
+}
+
+// cronSpec returns the job schedule with hashes replaced and, if Timezone
+// is set on the job and not explicitly in its schedule, AND its not a
+// descriptor (that don't support timezones) other than @rrule, the timezone
+// prepended so robfig/cron knows about it.
+func (j *Job) cronSpec() string {
+	schedule := j.scheduleSpec()
+	if j.Timezone != "" &&
+		(!strings.HasPrefix(schedule, "@") || strings.HasPrefix(schedule, "@rrule ")) &&
+		!strings.HasPrefix(schedule, "TZ=") &&
+		!strings.HasPrefix(schedule, "CRON_TZ=") {
+		schedule = "CRON_TZ=" + j.Timezone + " " + schedule
+	}
+	return schedule
+}
+
+// GetNext returns the job's next schedule from now, skipping
+// the days excluded by the given calendars.
+func (j *Job) GetNext(calendars []*Calendar) (time.Time, error) {
 	if j.StartsAt.HasValue() && time.Now().Before(j.StartsAt.Get()) {
 		return j.StartsAt.Get(), nil
 	}
 
-	if j.Schedule != "" {
-		s, err := extcron.Parse(j.scheduleHash())
+	if j.hasSchedule() {
+		s, err := j.schedule(calendars)
 		if err != nil {
 			return time.Time{}, err
 		}
@@ -401,7 +801,93 @@ func (j *Job) GetNext() (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// completionAnchored returns whether the job runs a delay after its
+// previous execution finished, with any @every-after schedule.
+func (j *Job) completionAnchored() bool {
+	if strings.HasPrefix(j.Schedule, "@every-after ") {
+		return true
+	}
+	for _, s := range j.Schedules {
+		if strings.HasPrefix(s.Schedule, "@every-after ") {
+			return true
+		}
+	}
+	return false
+}
+
+// lastFinished returns when the last execution of the job finished.
+func (j *Job) lastFinished() time.Time {
+	var t time.Time
+	if j.LastSuccess.HasValue() {
+		t = j.LastSuccess.Get()
+	}
+	if j.LastError.HasValue() && j.LastError.Get().After(t) {
+		t = j.LastError.Get()
+	}
+	return t
+}
+
+// scheduledAt returns the fire time of the run starting at now,
+// or now if the job has no fire time close enough.
+func (j *Job) scheduledAt(now time.Time) time.Time {
+	s, err := j.cronSchedule()
+	if err != nil {
+		return now
+	}
+	t := s.Next(now.Add(-scheduleTolerance))
+	if t.IsZero() || t.After(now) {
+		return now
+	}
+	for next := s.Next(t); !next.IsZero() && !next.After(now); next = s.Next(next) {
+		t = next
+	}
+	return t
+}
+
+// NextRuns returns up to count fire times of the job after from, skipping
+// the days excluded by the given calendars and the times outside of the
+// job starts_at and expires_at range.
+func (j *Job) NextRuns(from time.Time, count int, calendars []*Calendar) ([]time.Time, error) {
+	if !j.hasSchedule() {
+		return []time.Time{}, nil
+	}
+
+	s, err := j.schedule(calendars)
+	if err != nil {
+		return nil, err
+	}
+
+	t := from
+	if j.StartsAt.HasValue() && t.Before(j.StartsAt.Get()) {
+		t = j.StartsAt.Get().Add(-time.Nanosecond)
+	}
+
+	runs := []time.Time{}
+	for len(runs) < count {
+		next := s.Next(t)
+		if next.IsZero() || !next.After(t) {
+			break
+		}
+		if j.ExpiresAt.HasValue() && next.After(j.ExpiresAt.Get()) {
+			break
+		}
+		runs = append(runs, next)
+		t = next
+	}
+
+	return runs, nil
+}
+
 func (j *Job) isRunnable(logger *logrus.Entry) bool {
+	now := time.Now()
+	return j.isRunnableAt(logger, now, func() { j.run(now, time.Now(), 0) })
+}
+
+// isRunnableAt returns whether a run of the job scheduled at the given time
+// can start now. Calendars and run windows are checked at the scheduled
+// time, the other conditions at the current time. Runs deferred to the
+// next run window or queued call resume to start again.
+func (j *Job) isRunnableAt(logger *logrus.Entry, at time.Time, resume func()) bool {
 	if j.Disabled {
 		logger.WithField("job", j.Name).
 			Debug("job: Skipping execution because job is disabled")
@@ -420,54 +906,59 @@ func (j *Job) isRunnable(logger *logrus.Entry) bool {
 		return false
 	}
 
+	if len(j.Calendars) > 0 {
+		calendars := j.Agent.getCalendars(context.Background(), j)
+		if c, _ := excludingCalendar(calendars, at, j.location()); c != nil {
+			logger.WithFields(logrus.Fields{
+				"job":      j.Name,
+				"calendar": c.Name,
+			}).Info("job: Skipping execution because day is excluded by calendar")
+			j.Agent.recordSkippedExecution(j, fmt.Sprintf("Execution skipped, day excluded by calendar %s", c.Name), nil)
+			return false
+		}
+	}
+
+	if runnable, _ := j.checkRunWindow(logger, at, 0, resume); !runnable {
+		return false
+	}
+
 	if j.Agent.GlobalLock {
 		logger.WithField("job", j.Name).
 			Warning("job: Skipping execution because active global lock")
 		return false
 	}
 
-	if j.Concurrency == ConcurrencyForbid {
-		// Check in-memory active executions first - these are definitely running
-		exs, err := j.Agent.GetActiveExecutions()
-		if err != nil {
-			logger.WithError(err).Error("job: Error querying for active executions")
-			return false
-		}
-
-		for _, e := range exs {
-			if e.JobName == j.Name {
-				logger.WithFields(logrus.Fields{
-					"job":         j.Name,
-					"concurrency": j.Concurrency,
-					"job_status":  j.Status,
-				}).Info("job: Skipping concurrent execution (found in active executions)")
-				return false
-			}
-		}
+	if mw := j.Agent.activeMaintenanceWindow(context.Background(), j, time.Now()); mw != nil {
+		logger.WithFields(logrus.Fields{
+			"job":         j.Name,
+			"maintenance": mw.Name,
+		}).Info("job: Skipping execution because of maintenance window")
+		j.Agent.recordSkippedExecution(j, fmt.Sprintf("Execution skipped, maintenance window %s active: %s", mw.Name, mw.Reason), nil)
+		return false
+	}
 
-		// Check persistent storage for running executions
-		// This catches executions that might be running on nodes after a leader change
-		ctx := context.Background()
-		runningExecs, err := j.Agent.cleanupStaleRunningExecutions(ctx, j.Name, activeExecutionKeys(exs), logger, "job: Cleaning up stale execution from storage")
+	if limit := j.concurrencyLimit(); limit > 0 {
+		running, err := j.runningExecutions(logger)
 		if err != nil {
-			logger.WithError(err).Error("job: Error querying for running executions in storage")
+			logger.WithError(err).Error("job: Error querying for running executions")
 			return false
 		}
 
-		for _, exec := range runningExecs {
-			// Execution is not in active memory but hasn't exceeded the stale threshold.
-			// Conservatively block to avoid potential concurrent execution.
-			runningFor := time.Now().UTC().Sub(exec.StartedAt)
-			logger.WithFields(logrus.Fields{
-				"job":           j.Name,
-				"concurrency":   j.Concurrency,
-				"job_status":    j.Status,
-				"running_count": len(runningExecs),
-				"execution":     exec.Key(),
-				"node":          exec.NodeName,
-				"started_at":    exec.StartedAt,
-				"running_for":   runningFor.String(),
-			}).Info("job: Skipping concurrent execution (found running execution in storage)")
+		if running >= limit {
+			fields := logrus.Fields{
+				"job":             j.Name,
+				"concurrency":     j.Concurrency,
+				"max_concurrency": limit,
+				"running_count":   running,
+				"job_status":      j.Status,
+			}
+			if j.Concurrency != ConcurrencyQueue {
+				logger.WithFields(fields).Info("job: Skipping concurrent execution")
+			} else if j.Agent.queueRun(j, resume) {
+				logger.WithFields(fields).Info("job: Queueing execution until a running one finishes")
+			} else {
+				logger.WithFields(fields).Warning("job: Skipping execution because the job queue is full")
+			}
 			return false
 		}
 	}
@@ -485,21 +976,111 @@ func (j *Job) Validate() error {
 		return fmt.Errorf("name contains illegal character '%s'", chr)
 	}
 
-	if j.ParentJob == j.Name {
-		return ErrSameParent
+	for _, p := range j.parents() {
+		if p == j.Name {
+			return ErrSameParent
+		}
 	}
 
-	// Validate schedule, allow empty schedule if parent job set.
-	if j.Schedule != "" || j.ParentJob == "" {
-		if _, err := extcron.Parse(j.scheduleHash()); err != nil {
+	if j.ScheduleFormat != "" && j.ScheduleFormat != ScheduleFormatDkron && j.ScheduleFormat != ScheduleFormatCrontab {
+		return ErrWrongScheduleFormat
+	}
+
+	// Validate schedule, allow empty schedule if parent job or additional schedules set.
+	if j.Schedule != "" || (len(j.parents()) == 0 && len(j.Schedules) == 0) {
+		if _, err := extcron.Parse(j.scheduleSpec()); err != nil {
 			return fmt.Errorf("%s: %s", ErrScheduleParse.Error(), err)
 		}
 	}
 
-	if j.Concurrency != ConcurrencyAllow && j.Concurrency != ConcurrencyForbid && j.Concurrency != "" {
+	for i, s := range j.Schedules {
+		if s == nil || s.Schedule == "" {
+			return fmt.Errorf("%s: schedules[%d]: schedule cannot be empty", ErrScheduleParse.Error(), i)
+		}
+		if _, err := extcron.Parse(j.withSchedule(i).scheduleSpec()); err != nil {
+			return fmt.Errorf("%s: schedules[%d]: %s", ErrScheduleParse.Error(), i, err)
+		}
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("schedules[%d]: %s", i, err)
+		}
+	}
+
+	if j.Concurrency != ConcurrencyAllow && j.Concurrency != ConcurrencyForbid && j.Concurrency != ConcurrencyQueue && j.Concurrency != "" {
 		return ErrWrongConcurrency
 	}
 
+	if j.Concurrency == ConcurrencyForbid && j.MaxConcurrency > 1 {
+		return ErrWrongMaxConcurrency
+	}
+
+	switch j.MisfirePolicy {
+	case "", MisfireSkip, MisfireRunOnce, MisfireRunAll:
+	default:
+		return ErrWrongMisfirePolicy
+	}
+
+	switch j.DSTPolicy {
+	case "", DSTSkip, DSTRunOnce, DSTRunTwice, DSTShiftForward:
+	default:
+		return ErrWrongDSTPolicy
+	}
+
+	switch j.RunWindowPolicy {
+	case "", RunWindowSkip, RunWindowDefer:
+	default:
+		return ErrWrongRunWindowPolicy
+	}
+
+	if err := validateTags(j.Tags); err != nil {
+		return err
+	}
+
+	switch j.SelectionStrategy {
+	case "", SelectionRandom, SelectionRoundRobin, SelectionLeastLoaded, SelectionConsistentHash, SelectionSpread:
+	default:
+		return ErrWrongSelectionStrategy
+	}
+
+	switch j.TriggerRule {
+	case "", TriggerAllSuccess, TriggerAnySuccess, TriggerAllDone:
+	default:
+		return ErrWrongTriggerRule
+	}
+
+	parents := j.parents()
+	for p, c := range j.ParentConditions {
+		if !slices.Contains(parents, p) {
+			return fmt.Errorf("parent_conditions[%s]: %s", p, ErrParentJobNotFound)
+		}
+		if err := validateParentCondition(c); err != nil {
+			return fmt.Errorf("parent_conditions[%s]: %s", p, err)
+		}
+	}
+
+	if j.Map {
+		if err := j.validateMap(); err != nil {
+			return err
+		}
+	}
+
+	for i, w := range j.RunWindows {
+		if err := w.validate(); err != nil {
+			return fmt.Errorf("run_windows[%d]: %s", i, err)
+		}
+	}
+
+	if j.MisfireGrace != "" {
+		if _, err := time.ParseDuration(j.MisfireGrace); err != nil {
+			return fmt.Errorf("Error parsing job misfire grace value: %v", err)
+		}
+	}
+
+	if j.Jitter != "" {
+		if d, err := time.ParseDuration(j.Jitter); err != nil || d < 0 {
+			return fmt.Errorf("Error parsing job jitter value: %s", j.Jitter)
+		}
+	}
+
 	// An empty string is a valid timezone for LoadLocation
 	if _, err := time.LoadLocation(j.Timezone); err != nil {
 		return err
@@ -546,7 +1127,60 @@ func generateJobTree(jobs []*Job) ([]*Job, error) {
 		}
 		jobs = rejobs
 	}
-	return jobs, nil
+	return attachFanInJobs(jobs)
+}
+
+// attachFanInJobs moves the jobs with several parents under the parent
+// that comes last in the tree, so all their parents are created first.
+func attachFanInJobs(jobs []*Job) ([]*Job, error) {
+	var tree, pending []*Job
+	for _, j := range jobs {
+		if len(j.parents()) > 1 {
+			pending = append(pending, j)
+		} else {
+			tree = append(tree, j)
+		}
+	}
+
+	for len(pending) > 0 {
+		// Position of the jobs in the tree, in creation order
+		order := make(map[string]int)
+		byName := make(map[string]*Job)
+		var walk func([]*Job)
+		walk = func(jobs []*Job) {
+			for _, j := range jobs {
+				order[j.Name] = len(order)
+				byName[j.Name] = j
+				walk(j.ChildJobs)
+			}
+		}
+		walk(tree)
+
+		attached := false
+		for i, j := range pending {
+			var last *Job
+			for _, p := range j.parents() {
+				pos, ok := order[p]
+				if !ok {
+					last = nil
+					break
+				}
+				if last == nil || pos > order[last.Name] {
+					last = byName[p]
+				}
+			}
+			if last != nil {
+				last.ChildJobs = append(last.ChildJobs, j)
+				pending = append(pending[:i], pending[i+1:]...)
+				attached = true
+				break
+			}
+		}
+		if !attached {
+			return nil, ErrNoParent
+		}
+	}
+	return tree, nil
 }
 
 // findParentJobAndValidateJob...
@@ -556,14 +1190,16 @@ func findParentJobAndValidateJob(jobs []*Job, index int) ([]*Job, bool, error) {
 	if err := childJob.Validate(); err != nil {
 		return nil, false, err
 	}
-	if childJob.ParentJob == "" {
+	// Jobs with several parents are attached once the tree is built
+	parents := childJob.parents()
+	if len(parents) != 1 {
 		return jobs, true, nil
 	}
 	for _, parentJob := range jobs {
 		if parentJob.Name == childJob.Name {
 			continue
 		}
-		if childJob.ParentJob == parentJob.Name {
+		if parents[0] == parentJob.Name {
 			parentJob.ChildJobs = append(parentJob.ChildJobs, childJob)
 			jobs = append(jobs[:index], jobs[index+1:]...)
 			return jobs, false, nil
@@ -581,7 +1217,7 @@ func findParentJobAndValidateJob(jobs []*Job, index int) ([]*Job, bool, error) {
 
 func findParentJobInChildJobs(jobs []*Job, job *Job) bool {
 	for _, parentJob := range jobs {
-		if job.ParentJob == parentJob.Name {
+		if job.parents()[0] == parentJob.Name {
 			parentJob.ChildJobs = append(parentJob.ChildJobs, job)
 			return true
 		} else {
@@ -168,6 +168,34 @@ func Test_isRunnable(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "running below max concurrency",
+			job: &Job{
+				Name:           "test_job",
+				Agent:          a,
+				MaxConcurrency: 2,
+			},
+			want: true,
+		},
+		{
+			name: "running max concurrency",
+			job: &Job{
+				Name:           "test_job",
+				Agent:          a,
+				Concurrency:    ConcurrencyAllow,
+				MaxConcurrency: 1,
+			},
+			want: false,
+		},
+		{
+			name: "running queue",
+			job: &Job{
+				Name:        "test_job",
+				Agent:       a,
+				Concurrency: ConcurrencyQueue,
+			},
+			want: false,
+		},
 		{
 			name: "disabled",
 			job: &Job{
@@ -195,6 +223,25 @@ func Test_isRunnable(t *testing.T) {
 			assert.Equal(t, tt.want, tt.job.isRunnable(log))
 		})
 	}
+
+	// The run over the limit is held until a running execution finishes
+	assert.Equal(t, 1, a.queue.len("test_job"))
+
+	t.Run("maintenance window", func(t *testing.T) {
+		mw := &MaintenanceWindow{
+			Name:     "freeze",
+			StartsAt: time.Now().Add(-time.Minute),
+			EndsAt:   time.Now().Add(time.Hour),
+		}
+		require.NoError(t, a.Store.SetMaintenanceWindow(context.Background(), mw))
+		defer a.Store.DeleteMaintenanceWindow(context.Background(), mw.Name) // nolint: errcheck
+
+		job := &Job{
+			Name:  "test_job",
+			Agent: a,
+		}
+		assert.False(t, job.isRunnable(log))
+	})
 }
 
 func Test_scheduleHash(t *testing.T) {
@@ -207,19 +254,144 @@ func Test_scheduleHash(t *testing.T) {
 	assert.Equal(t, "TZ=Europe/Madrid 0 0 1 * 7 *", job.scheduleHash())
 	job.Schedule = "TZ=Europe/Madrid @at something with ~"
 	assert.Equal(t, "TZ=Europe/Madrid @at something with ~", job.scheduleHash())
+
+	job.ScheduleFormat = ScheduleFormatCrontab
+	job.Schedule = "0 ~ * * *"
+	assert.Equal(t, "0 18 * * *", job.scheduleHash())
+	job.Schedule = "TZ=Europe/Madrid 0 1 * ~ *"
+	assert.Equal(t, "TZ=Europe/Madrid 0 1 * 7 *", job.scheduleHash())
+}
+
+func Test_cronSpec(t *testing.T) {
+	job := &Job{
+		Name:     "test_job",
+		Timezone: "Europe/Madrid",
+	}
+	job.Schedule = "0 0 1 * * *"
+	assert.Equal(t, "CRON_TZ=Europe/Madrid 0 0 1 * * *", job.cronSpec())
+	job.Schedule = "@every 1h"
+	assert.Equal(t, "@every 1h", job.cronSpec())
+	job.Schedule = "@rrule DTSTART:20240101T090000 RRULE:FREQ=DAILY"
+	assert.Equal(t, "CRON_TZ=Europe/Madrid @rrule DTSTART:20240101T090000 RRULE:FREQ=DAILY", job.cronSpec())
+
+	job.ScheduleFormat = ScheduleFormatCrontab
+	job.Schedule = "30 2 * * MON-FRI"
+	assert.Equal(t, "CRON_TZ=Europe/Madrid 0 30 2 * * MON-FRI", job.cronSpec())
+	job.Schedule = "TZ=UTC 30 2 * * *"
+	assert.Equal(t, "TZ=UTC 0 30 2 * * *", job.cronSpec())
+	job.Schedule = "@daily"
+	assert.Equal(t, "@daily", job.cronSpec())
+}
+
+func TestJobValidateScheduleFormat(t *testing.T) {
+	job := &Job{
+		Name:           "test_job",
+		Schedule:       "30 2 * * *",
+		ScheduleFormat: ScheduleFormatCrontab,
+	}
+	assert.NoError(t, job.Validate())
+
+	job.Schedule = "0 30 2 * * *"
+	assert.Error(t, job.Validate())
+
+	job.ScheduleFormat = "crontab"
+	assert.Equal(t, ErrWrongScheduleFormat, job.Validate())
+}
+
+func TestJobEveryAfterSchedule(t *testing.T) {
+	job := &Job{
+		Name:     "test_job",
+		Schedule: "@every-after 10m",
+	}
+	require.NoError(t, job.Validate())
+	assert.True(t, job.completionAnchored())
+
+	success := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	job.LastSuccess.Set(success)
+	job.LastError.Set(success.Add(-time.Hour))
+
+	s, err := job.schedule(nil)
+	require.NoError(t, err)
+	assert.Equal(t, success.Add(10*time.Minute), s.Next(success))
+
+	job.Schedule = "@every 10m"
+	assert.False(t, job.completionAnchored())
+}
+
+func TestJobQuartzSchedule(t *testing.T) {
+	job := &Job{
+		Name:     "test_job",
+		Schedule: "0 0 12 LW * *",
+		Timezone: "UTC",
+	}
+	require.NoError(t, job.Validate())
+
+	next, err := job.GetNext(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 12, next.Hour())
+	assert.NotContains(t, []time.Weekday{time.Saturday, time.Sunday}, next.Weekday())
+	assert.Greater(t, next.Day()+7, time.Date(next.Year(), next.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day())
+
+	job.Schedule = "0 0 12 ? * FRI#9"
+	assert.Error(t, job.Validate())
+}
+
+func TestJobNextRuns(t *testing.T) {
+	from := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	var startsAt, expiresAt ntime.NullableTime
+	startsAt.Set(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+	expiresAt.Set(time.Date(2024, 3, 12, 23, 0, 0, 0, time.UTC))
+
+	job := &Job{
+		Name:      "test_job",
+		Schedule:  "0 30 2 * * *",
+		Timezone:  "America/New_York",
+		StartsAt:  startsAt,
+		ExpiresAt: expiresAt,
+	}
+
+	runs, err := job.NextRuns(from, 10, nil)
+	require.NoError(t, err)
+	// 02:30 doesn't exist in New York on March 10th 2024
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 3, 11, 6, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 12, 6, 30, 0, 0, time.UTC),
+	}, utcTimes(runs))
+
+	job.Schedule = "@at 2024-03-11T00:00:00Z"
+	runs, err = job.NextRuns(from, 10, nil)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+
+	job.Schedule = "@every 1h"
+	job.ExpiresAt = ntime.NullableTime{}
+	runs, err = job.NextRuns(from, 3, nil)
+	require.NoError(t, err)
+	assert.Len(t, runs, 3)
+}
+
+func utcTimes(times []time.Time) []time.Time {
+	out := make([]time.Time, len(times))
+	for i, t := range times {
+		out[i] = t.UTC()
+	}
+	return out
 }
 
 type gRPCClientMock struct {
 }
 
-func (gRPCClientMock) Connect(s string) (*grpc.ClientConn, error) { return nil, nil }
-func (gRPCClientMock) ExecutionDone(s string, e *Execution) error { return nil }
-func (gRPCClientMock) GetJob(s string, a string) (*Job, error)    { return nil, nil }
-func (gRPCClientMock) SetJob(j *Job) error                        { return nil }
-func (gRPCClientMock) DeleteJob(s string) (*Job, error)           { return nil, nil }
-func (gRPCClientMock) DeleteExecutions(s string) (*Job, error)    { return nil, nil }
-func (gRPCClientMock) Leave(s string) error                       { return nil }
-func (gRPCClientMock) RunJob(s string) (*Job, error)              { return nil, nil }
+func (gRPCClientMock) Connect(s string) (*grpc.ClientConn, error)  { return nil, nil }
+func (gRPCClientMock) ExecutionDone(s string, e *Execution) error  { return nil }
+func (gRPCClientMock) GetJob(s string, a string) (*Job, error)     { return nil, nil }
+func (gRPCClientMock) SetJob(j *Job) error                         { return nil }
+func (gRPCClientMock) DeleteJob(s string) (*Job, error)            { return nil, nil }
+func (gRPCClientMock) DeleteExecutions(s string) (*Job, error)     { return nil, nil }
+func (gRPCClientMock) SetCalendar(c *Calendar) error               { return nil }
+func (gRPCClientMock) DeleteCalendar(s string) (*Calendar, error)  { return nil, nil }
+func (gRPCClientMock) Leave(s string) error                        { return nil }
+func (gRPCClientMock) RunJob(s string, t *RunTarget) (*Job, error) { return nil, nil }
 func (gRPCClientMock) RaftGetConfiguration(s string) (*proto.RaftGetConfigurationResponse, error) {
 	return nil, nil
 }
@@ -232,9 +404,17 @@ func (gRPCClientMock) GetActiveExecutions(s string) ([]*proto.Execution, error)
 	}, nil
 }
 func (gRPCClientMock) SetExecution(execution *proto.Execution) error { return nil }
-func (gRPCClientMock) AgentRun(addr string, job *proto.Job, execution *proto.Execution) error {
+func (gRPCClientMock) AgentRun(addr string, job *proto.Job, execution *proto.Execution, parent *proto.ParentExecution) error {
 	return nil
 }
+func (gRPCClientMock) SetMaintenanceWindow(mw *MaintenanceWindow) error { return nil }
+func (gRPCClientMock) DeleteMaintenanceWindow(s string) (*MaintenanceWindow, error) {
+	return nil, nil
+}
+func (gRPCClientMock) SetPool(pool *Pool) error { return nil }
+func (gRPCClientMock) DeletePool(s string) (*Pool, error) {
+	return nil, nil
+}
 
 func Test_generateJobTree(t *testing.T) {
 	jsonString := `[
@@ -514,3 +694,54 @@ func Test_generateJobTree(t *testing.T) {
 	}
 	assert.Equal(t, len(jobTree), 3)
 }
+
+func Test_generateJobTreeFanIn(t *testing.T) {
+	jobs := []*Job{
+		{Name: "report", ParentJob: "merge"},
+		{Name: "merge", ParentJobs: []string{"ingest_b", "ingest_a"}},
+		{Name: "ingest_a", Schedule: "@daily"},
+		{Name: "ingest_b", ParentJob: "ingest_a"},
+	}
+	jobTree, err := generateJobTree(jobs)
+	require.NoError(t, err)
+
+	// The merge job is created after both its parents
+	require.Len(t, jobTree, 1)
+	assert.Equal(t, "ingest_a", jobTree[0].Name)
+	require.Len(t, jobTree[0].ChildJobs, 1)
+	ingestB := jobTree[0].ChildJobs[0]
+	assert.Equal(t, "ingest_b", ingestB.Name)
+	require.Len(t, ingestB.ChildJobs, 1)
+	assert.Equal(t, "merge", ingestB.ChildJobs[0].Name)
+	assert.Equal(t, "report", ingestB.ChildJobs[0].ChildJobs[0].Name)
+
+	_, err = generateJobTree([]*Job{
+		{Name: "merge", ParentJobs: []string{"ingest_a", "missing"}},
+		{Name: "ingest_a", Schedule: "@daily"},
+	})
+	assert.Equal(t, ErrNoParent, err)
+}
+
+func TestJobValidateParentJobs(t *testing.T) {
+	job := &Job{Name: "merge", ParentJobs: []string{"ingest_a", "ingest_b"}}
+	assert.NoError(t, job.Validate())
+
+	job.TriggerRule = "first"
+	assert.Equal(t, ErrWrongTriggerRule, job.Validate())
+
+	for _, r := range []string{TriggerAllSuccess, TriggerAnySuccess, TriggerAllDone} {
+		job.TriggerRule = r
+		assert.NoError(t, job.Validate())
+	}
+
+	job.ParentJobs = append(job.ParentJobs, "merge")
+	assert.Equal(t, ErrSameParent, job.Validate())
+
+	job.ParentJobs = []string{"ingest_b"}
+	job.ParentJob = "ingest_a"
+	assert.Equal(t, []string{"ingest_a", "ingest_b"}, job.parents())
+
+	pj := NewJobFromProto(job.ToProto(), getTestLogger())
+	assert.Equal(t, job.ParentJobs, pj.ParentJobs)
+	assert.Equal(t, job.TriggerRule, pj.TriggerRule)
+}
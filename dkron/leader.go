@@ -240,7 +240,24 @@ func (a *Agent) establishLeadership(stopCh chan struct{}) error {
 		a.logger.WithError(err).Warn("leader: Failed to reconcile running execution orphans")
 	}
 
-	return a.sched.Start(jobs, a)
+	a.queue.reset()
+	a.runs.reset()
+	a.capacity.reset()
+	a.dependencies.reset()
+	a.maps.reset()
+	a.reconcileMapRuns(ctx, jobs)
+	a.resetPoolSlots(ctx, jobs)
+
+	// Capture the time before starting the scheduler so runs the new
+	// scheduler fires itself are not counted as missed.
+	now := time.Now()
+	if err := a.sched.Start(jobs, a); err != nil {
+		return err
+	}
+
+	a.runMisfiredJobs(jobs, now)
+
+	return nil
 }
 
 func (a *Agent) reconcileRunningExecutionOrphans(ctx context.Context, jobs []*Job, activeExecutionKeys map[string]struct{}) error {
@@ -271,6 +288,8 @@ func (a *Agent) revokeLeadership() error {
 	// Stop the scheduler, running jobs will continue to finish but we
 	// can not actively wait for them blocking the execution here.
 	a.sched.Stop()
+	// Deferred runs only run on the leader
+	a.stopDeferredRuns()
 
 	return nil
 }
@@ -0,0 +1,126 @@
+package dkron
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	proto "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaintenanceWindow describes a period of time in which the scheduler
+// doesn't run the jobs it selects, like a change freeze or a blackout.
+type MaintenanceWindow struct {
+	// Maintenance window name. Must be unique, acts as the id.
+	Name string `json:"name"`
+
+	// Why the jobs don't run, stored in their skipped executions.
+	Reason string `json:"reason"`
+
+	// Start of the window.
+	StartsAt time.Time `json:"starts_at"`
+
+	// End of the window, not included in it.
+	EndsAt time.Time `json:"ends_at"`
+
+	// Tags the jobs must have to be suppressed, ignoring their
+	// cardinality. Empty matches every job.
+	Tags map[string]string `json:"tags"`
+
+	// Metadata the jobs must have to be suppressed. Empty matches
+	// every job.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// NewMaintenanceWindowFromProto creates a new MaintenanceWindow from a PB MaintenanceWindow struct
+func NewMaintenanceWindowFromProto(in *proto.MaintenanceWindow) *MaintenanceWindow {
+	mw := &MaintenanceWindow{
+		Name:     in.Name,
+		Reason:   in.Reason,
+		Tags:     in.Tags,
+		Metadata: in.Metadata,
+	}
+	if in.StartsAt != nil {
+		mw.StartsAt = in.StartsAt.AsTime()
+	}
+	if in.EndsAt != nil {
+		mw.EndsAt = in.EndsAt.AsTime()
+	}
+	return mw
+}
+
+// ToProto returns the corresponding representation of this MaintenanceWindow in proto struct
+func (mw *MaintenanceWindow) ToProto() *proto.MaintenanceWindow {
+	return &proto.MaintenanceWindow{
+		Name:     mw.Name,
+		Reason:   mw.Reason,
+		StartsAt: timestamppb.New(mw.StartsAt),
+		EndsAt:   timestamppb.New(mw.EndsAt),
+		Tags:     mw.Tags,
+		Metadata: mw.Metadata,
+	}
+}
+
+// Validate validates whether all values in the maintenance window are acceptable.
+func (mw *MaintenanceWindow) Validate() error {
+	if mw.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	if valid, chr := isSlug(mw.Name); !valid {
+		return fmt.Errorf("name contains illegal character '%s'", chr)
+	}
+
+	if mw.StartsAt.IsZero() || mw.EndsAt.IsZero() {
+		return fmt.Errorf("starts_at and ends_at are required")
+	}
+
+	if !mw.EndsAt.After(mw.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+
+	return nil
+}
+
+// Active returns whether the window is in effect at t.
+func (mw *MaintenanceWindow) Active(t time.Time) bool {
+	return !t.Before(mw.StartsAt) && t.Before(mw.EndsAt)
+}
+
+// Matches returns whether the window suppresses the given job.
+func (mw *MaintenanceWindow) Matches(job *Job) bool {
+	for k, v := range mw.Tags {
+		// Job tag values can have a cardinality suffix (value:2)
+		jv, ok := job.Tags[k]
+		if !ok || strings.Split(jv, ":")[0] != v {
+			return false
+		}
+	}
+
+	for k, v := range mw.Metadata {
+		if jv, ok := job.Metadata[k]; !ok || jv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// activeMaintenanceWindow returns the maintenance window suppressing
+// the job at t, if any.
+func (a *Agent) activeMaintenanceWindow(ctx context.Context, job *Job, t time.Time) *MaintenanceWindow {
+	windows, err := a.Store.GetMaintenanceWindows(ctx)
+	if err != nil {
+		a.logger.WithError(err).Error("agent: Error retrieving maintenance windows")
+		return nil
+	}
+
+	for _, mw := range windows {
+		if mw.Active(t) && mw.Matches(job) {
+			return mw
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package dkron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+	"go.opentelemetry.io/otel"
+)
+
+func TestMaintenanceWindowMatches(t *testing.T) {
+	mw := &MaintenanceWindow{
+		Name:     "db-upgrade",
+		StartsAt: time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2024, 6, 2, 2, 0, 0, 0, time.UTC),
+	}
+
+	assert.False(t, mw.Active(time.Date(2024, 6, 1, 21, 59, 59, 0, time.UTC)))
+	assert.True(t, mw.Active(mw.StartsAt))
+	assert.True(t, mw.Active(time.Date(2024, 6, 2, 1, 0, 0, 0, time.UTC)))
+	assert.False(t, mw.Active(mw.EndsAt))
+
+	job := &Job{
+		Name:     "report",
+		Tags:     map[string]string{"role": "db:2", "region": "eu"},
+		Metadata: map[string]string{"team": "billing"},
+	}
+
+	// No selector matches every job
+	assert.True(t, mw.Matches(job))
+
+	mw.Tags = map[string]string{"role": "db"}
+	assert.True(t, mw.Matches(job))
+	mw.Tags["region"] = "us"
+	assert.False(t, mw.Matches(job))
+
+	mw.Tags = nil
+	mw.Metadata = map[string]string{"team": "billing"}
+	assert.True(t, mw.Matches(job))
+	mw.Metadata["team"] = "search"
+	assert.False(t, mw.Matches(job))
+}
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	start := time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		window *MaintenanceWindow
+	}{
+		{"empty name", &MaintenanceWindow{StartsAt: start, EndsAt: start.Add(time.Hour)}},
+		{"invalid name", &MaintenanceWindow{Name: "db upgrade", StartsAt: start, EndsAt: start.Add(time.Hour)}},
+		{"no start", &MaintenanceWindow{Name: "m", EndsAt: start}},
+		{"no end", &MaintenanceWindow{Name: "m", StartsAt: start}},
+		{"end before start", &MaintenanceWindow{Name: "m", StartsAt: start, EndsAt: start.Add(-time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.window.Validate())
+		})
+	}
+
+	mw := &MaintenanceWindow{Name: "m", StartsAt: start, EndsAt: start.Add(time.Hour)}
+	assert.NoError(t, mw.Validate())
+}
+
+func TestStoreMaintenanceWindows(t *testing.T) {
+	s, err := NewStore(getTestLogger(), otel.Tracer("test"))
+	require.NoError(t, err)
+	defer s.Shutdown() // nolint: errcheck
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	mw := &MaintenanceWindow{
+		Name:     "db-upgrade",
+		Reason:   "Database upgrade",
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+		Tags:     map[string]string{"role": "db"},
+	}
+	require.NoError(t, s.SetMaintenanceWindow(ctx, mw))
+	assert.Error(t, s.SetMaintenanceWindow(ctx, &MaintenanceWindow{Name: "invalid"}))
+
+	stored, err := s.GetMaintenanceWindow(ctx, mw.Name)
+	require.NoError(t, err)
+	assert.Equal(t, mw, stored)
+
+	windows, err := s.GetMaintenanceWindows(ctx)
+	require.NoError(t, err)
+	assert.Len(t, windows, 1)
+
+	a := &Agent{Store: s, logger: getTestLogger()}
+	dbJob := &Job{Name: "backup", Tags: map[string]string{"role": "db"}}
+	webJob := &Job{Name: "cache-warmup", Tags: map[string]string{"role": "web"}}
+	assert.Equal(t, mw.Name, a.activeMaintenanceWindow(ctx, dbJob, now).Name)
+	assert.Nil(t, a.activeMaintenanceWindow(ctx, webJob, now))
+	assert.Nil(t, a.activeMaintenanceWindow(ctx, dbJob, now.Add(2*time.Hour)))
+
+	deleted, err := s.DeleteMaintenanceWindow(ctx, mw.Name)
+	require.NoError(t, err)
+	assert.Equal(t, mw.Name, deleted.Name)
+
+	_, err = s.GetMaintenanceWindow(ctx, mw.Name)
+	assert.Equal(t, buntdb.ErrNotFound, err)
+	_, err = s.DeleteMaintenanceWindow(ctx, mw.Name)
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
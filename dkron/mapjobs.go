@@ -0,0 +1,371 @@
+package dkron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/distribworks/dkron/v4/plugin"
+)
+
+const (
+	// Execution metadata keys of the items of a map job run.
+	mapIndexMetadata = "map_index"
+	mapItemMetadata  = "map_item"
+	mapItemsMetadata = "map_items"
+)
+
+// mapItemData is the item available to the executor config templates of
+// a map job.
+type mapItemData struct {
+	// Item decoded from JSON.
+	Item interface{}
+
+	// Item in JSON.
+	ItemJSON string
+
+	// Position of the item in the parent result.
+	Index int
+}
+
+// validateMap checks the map settings and executor config templates of
+// a map job.
+func (j *Job) validateMap() error {
+	if len(j.parents()) == 0 {
+		return ErrMapNoParent
+	}
+	if err := validateMapSuccessRule(j.MapSuccessRule); err != nil {
+		return err
+	}
+	for k, v := range j.ExecutorConfig {
+		if _, err := template.New(k).Parse(v); err != nil {
+			return fmt.Errorf("executor_config[%s]: %s", k, err)
+		}
+	}
+	return nil
+}
+
+// validateMapSuccessRule checks that the rule is all_success, any_success
+// or a percentage between 1% and 100%.
+func validateMapSuccessRule(rule string) error {
+	switch rule {
+	case "", TriggerAllSuccess, TriggerAnySuccess:
+		return nil
+	}
+	p, err := strconv.Atoi(strings.TrimSuffix(rule, "%"))
+	if !strings.HasSuffix(rule, "%") || err != nil || p < 1 || p > 100 {
+		return ErrWrongMapSuccessRule
+	}
+	return nil
+}
+
+// mapSuccessRuleMet returns whether the items of a map job run that
+// succeeded meet the success rule.
+func mapSuccessRuleMet(rule string, items, succeeded int) bool {
+	switch rule {
+	case "", TriggerAllSuccess:
+		return succeeded == items
+	case TriggerAnySuccess:
+		return succeeded > 0
+	}
+	p, _ := strconv.Atoi(strings.TrimSuffix(rule, "%"))
+	return succeeded*100 >= p*items
+}
+
+// mapStatus returns the status of a map job run from the executions of
+// its items, using the last attempt of every item.
+func mapStatus(rule string, execs []*Execution) string {
+	items := 0
+	last := make(map[string]*Execution)
+	for _, ex := range execs {
+		if n, err := strconv.Atoi(ex.Metadata[mapItemsMetadata]); err == nil {
+			items = n
+		}
+		i := ex.Metadata[mapIndexMetadata]
+		if l, ok := last[i]; !ok || ex.Attempt > l.Attempt {
+			last[i] = ex
+		}
+	}
+
+	succeeded := 0
+	for _, ex := range last {
+		if ex.Success {
+			succeeded++
+		}
+	}
+	if mapSuccessRuleMet(rule, items, succeeded) {
+		return StatusSuccess
+	}
+	return StatusFailed
+}
+
+// isMapItem returns whether the execution runs an item of a map job.
+func isMapItem(ex *Execution) bool {
+	_, ok := ex.Metadata[mapIndexMetadata]
+	return ok
+}
+
+// mapItems returns the items in the result of the parent execution that
+// triggered the run, which must be a JSON array.
+func mapItems(result string) ([]json.RawMessage, error) {
+	if result == "" {
+		return nil, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(result), &items); err != nil {
+		return nil, fmt.Errorf("the parent result is not a JSON array: %w", err)
+	}
+	return items, nil
+}
+
+// executorTemplates returns the executor config keys the executor of the
+// job renders as templates itself, with the map item passed to it. They
+// are not rendered for every item, so items are only rendered once.
+func (j *Job) executorTemplates() map[string]bool {
+	if bodyTemplate, _ := strconv.ParseBool(j.ExecutorConfig["bodyTemplate"]); j.Executor == "http" && bodyTemplate {
+		return map[string]bool{"body": true}
+	}
+	return nil
+}
+
+// withMapItem returns a copy of the job running the item of the execution,
+// with the item rendered in its executor config.
+func (j *Job) withMapItem(ex *Execution) (*Job, error) {
+	raw := ex.Metadata[mapItemMetadata]
+	data := mapItemData{ItemJSON: raw}
+	if err := json.Unmarshal([]byte(raw), &data.Item); err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", mapItemMetadata, err)
+	}
+	data.Index, _ = strconv.Atoi(ex.Metadata[mapIndexMetadata])
+
+	job := *j
+	job.ExecutorConfig = make(plugin.ExecutorPluginConfig, len(j.ExecutorConfig))
+	skip := j.executorTemplates()
+	for k, v := range j.ExecutorConfig {
+		if skip[k] {
+			job.ExecutorConfig[k] = v
+			continue
+		}
+		tmpl, err := template.New(k).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("executor_config[%s]: %w", k, err)
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("executor_config[%s]: %w", k, err)
+		}
+		job.ExecutorConfig[k] = b.String()
+	}
+	return &job, nil
+}
+
+// mapItemFromMetadata returns the map item recorded in the execution
+// metadata, to pass it to the executor, or nil if the execution doesn't
+// run an item of a map job.
+func mapItemFromMetadata(md map[string]string) *typesv1.MapItem {
+	index, ok := md[mapIndexMetadata]
+	if !ok {
+		return nil
+	}
+	i, _ := strconv.ParseInt(index, 10, 64)
+	return &typesv1.MapItem{
+		Item:  []byte(md[mapItemMetadata]),
+		Index: i,
+	}
+}
+
+// runMap runs the map job once for every item in the result of the parent
+// execution that triggered it. The executions of the items share the
+// execution group of the run.
+func (a *Agent) runMap(ctx context.Context, job *Job, ex *Execution) error {
+	items, err := mapItems(a.parentResult(ctx, ex))
+	if err != nil {
+		a.recordSkippedExecution(job, fmt.Sprintf("Map job run skipped, %s", err), ex.Metadata)
+		return err
+	}
+	if len(items) == 0 {
+		a.recordSkippedExecution(job, "Map job run skipped, the parent result has no items", ex.Metadata)
+		return nil
+	}
+
+	execs := make([]*Execution, len(items))
+	for i, item := range items {
+		iex := *ex
+		iex.Metadata = maps.Clone(ex.Metadata)
+		iex.Metadata[mapIndexMetadata] = strconv.Itoa(i)
+		iex.Metadata[mapItemMetadata] = string(item)
+		iex.Metadata[mapItemsMetadata] = strconv.Itoa(len(items))
+		execs[i] = &iex
+	}
+
+	a.logger.WithField("job", job.Name).WithField("items", len(items)).Debug("agent: Running map job")
+	for _, iex := range a.maps.start(job, execs) {
+		a.runMapItem(job.Name, iex)
+	}
+	return nil
+}
+
+// runMapItem runs an item of a map job in the background, as running a
+// job waits for its executions.
+func (a *Agent) runMapItem(jobName string, ex *Execution) {
+	go func() {
+		if _, err := a.Run(context.Background(), jobName, ex); err != nil {
+			a.logger.WithError(err).WithField("job", jobName).Error("agent: Error running map item")
+			a.executionDropped(ex)
+		}
+	}()
+}
+
+// reconcileMapRuns records a stopped run for the map jobs whose last run
+// didn't start all its items. The pending items are only known to the
+// leader that started the run, so they are lost when it changes.
+func (a *Agent) reconcileMapRuns(ctx context.Context, jobs []*Job) {
+	for _, job := range jobs {
+		if !job.Map {
+			continue
+		}
+		execs, err := a.Store.GetExecutions(ctx, job.Name, &ExecutionOptions{})
+		if err != nil {
+			continue
+		}
+		if item, missing := stoppedMapRun(execs); item != nil {
+			a.recordMapRunStopped(job, item, missing)
+		}
+	}
+}
+
+// stoppedMapRun returns an item of the last run of a map job and how many
+// of its items never started, or nil if the last execution of the job is
+// not an item or all the items of its run started.
+func stoppedMapRun(execs []*Execution) (*Execution, int) {
+	var last *Execution
+	for _, ex := range execs {
+		if last == nil || ex.StartedAt.After(last.StartedAt) {
+			last = ex
+		}
+	}
+	if last == nil || !isMapItem(last) {
+		return nil, 0
+	}
+
+	items, _ := strconv.Atoi(last.Metadata[mapItemsMetadata])
+	started := make(map[string]bool)
+	for _, ex := range execs {
+		if ex.Group == last.Group && isMapItem(ex) {
+			started[ex.Metadata[mapIndexMetadata]] = true
+		}
+	}
+	if len(started) >= items {
+		return nil, 0
+	}
+	return last, items - len(started)
+}
+
+// recordMapRunStopped stores a skipped execution in the group of a map job
+// run that won't start all its items, with the reason in its output.
+func (a *Agent) recordMapRunStopped(job *Job, item *Execution, missing int) {
+	md := maps.Clone(item.Metadata)
+	delete(md, mapIndexMetadata)
+	delete(md, mapItemMetadata)
+
+	now := time.Now().UTC()
+	ex := NewExecution(job.Name)
+	ex.Group = item.Group
+	ex.WorkflowRunID = item.WorkflowRunID
+	ex.StartedAt = now
+	ex.FinishedAt = now
+	ex.NodeName = a.config.NodeName
+	ex.Skipped = true
+	ex.Output = fmt.Sprintf("Map job run stopped, %d of its %s items didn't run because the leader changed", missing, item.Metadata[mapItemsMetadata])
+	ex.Metadata = md
+
+	a.logger.WithField("job", job.Name).WithField("group", item.Group).Warning("agent: Map job run stopped by the leader change")
+	if err := a.applySetExecution(ex.ToProto()); err != nil {
+		a.logger.WithError(err).WithField("job", job.Name).Error("agent: Error storing stopped map job run")
+	}
+}
+
+// mapRun holds the items of a map job run.
+type mapRun struct {
+	items   int
+	pending []*Execution
+	done    map[string]bool
+}
+
+// mapTracker tracks the items of the running map jobs, to run the pending
+// ones as the running ones finish.
+type mapTracker struct {
+	mu   sync.Mutex
+	runs map[string]*mapRun
+}
+
+// mapRunKey returns the key of the map job run of the execution.
+func mapRunKey(ex *Execution) string {
+	return ex.JobName + ":" + ex.GetGroup()
+}
+
+// start records the items of a map job run and returns the ones to run
+// now, up to the job MaxParallel.
+func (t *mapTracker) start(job *Job, execs []*Execution) []*Execution {
+	n := len(execs)
+	if job.MaxParallel > 0 && int(job.MaxParallel) < n {
+		n = int(job.MaxParallel)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.runs == nil {
+		t.runs = make(map[string]*mapRun)
+	}
+	t.runs[mapRunKey(execs[0])] = &mapRun{
+		items:   len(execs),
+		pending: execs[n:],
+		done:    make(map[string]bool),
+	}
+	return execs[:n]
+}
+
+// itemDone records that an item of a map job run finished, and returns
+// the next item to run and whether all the items finished. Runs started
+// by another leader are unknown and never finish.
+func (t *mapTracker) itemDone(ex *Execution) (*Execution, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := mapRunKey(ex)
+	r, ok := t.runs[key]
+	if !ok {
+		return nil, false
+	}
+	index := ex.Metadata[mapIndexMetadata]
+	if r.done[index] {
+		return nil, false
+	}
+	r.done[index] = true
+
+	var next *Execution
+	if len(r.pending) > 0 {
+		next, r.pending = r.pending[0], r.pending[1:]
+	}
+	if len(r.done) == r.items {
+		delete(t.runs, key)
+		return next, true
+	}
+	return next, false
+}
+
+// reset forgets the running map jobs.
+func (t *mapTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.runs = nil
+}
@@ -0,0 +1,402 @@
+package dkron
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	typesv1 "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	dkhttp "github.com/distribworks/dkron/v4/plugin/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestJobValidateMap(t *testing.T) {
+	job := &Job{
+		Name:           "process_tenant",
+		Schedule:       "@every 1m",
+		Map:            true,
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "/opt/process.sh {{ .Item.tenant }}"},
+	}
+	assert.Equal(t, ErrMapNoParent, job.Validate())
+
+	job.Schedule = ""
+	job.ParentJob = "list_tenants"
+	assert.NoError(t, job.Validate())
+
+	for _, rule := range []string{"all_success", "any_success", "1%", "90%", "100%"} {
+		job.MapSuccessRule = rule
+		assert.NoError(t, job.Validate(), rule)
+	}
+	for _, rule := range []string{"all_done", "0%", "101%", "90", "half"} {
+		job.MapSuccessRule = rule
+		assert.Equal(t, ErrWrongMapSuccessRule, job.Validate(), rule)
+	}
+
+	job.MapSuccessRule = ""
+	job.ExecutorConfig["command"] = "/opt/process.sh {{ .Item"
+	assert.Error(t, job.Validate())
+}
+
+func TestMapSuccessRuleMet(t *testing.T) {
+	assert.True(t, mapSuccessRuleMet("", 3, 3))
+	assert.False(t, mapSuccessRuleMet(TriggerAllSuccess, 3, 2))
+	assert.True(t, mapSuccessRuleMet(TriggerAnySuccess, 3, 1))
+	assert.False(t, mapSuccessRuleMet(TriggerAnySuccess, 3, 0))
+	assert.True(t, mapSuccessRuleMet("50%", 4, 2))
+	assert.False(t, mapSuccessRuleMet("50%", 5, 2))
+}
+
+func TestMapStatus(t *testing.T) {
+	item := func(index int, attempt uint, success bool) *Execution {
+		return &Execution{
+			Attempt: attempt,
+			Success: success,
+			Metadata: map[string]string{
+				mapIndexMetadata: strconv.Itoa(index),
+				mapItemsMetadata: "3",
+			},
+		}
+	}
+
+	// Retries count with their last attempt
+	execs := []*Execution{item(0, 1, true), item(1, 1, false), item(1, 2, true), item(2, 1, true)}
+	assert.Equal(t, StatusSuccess, mapStatus("", execs))
+
+	// Items that didn't finish don't count as succeeded
+	execs = []*Execution{item(0, 1, true), item(1, 1, true)}
+	assert.Equal(t, StatusFailed, mapStatus("", execs))
+	assert.Equal(t, StatusSuccess, mapStatus("60%", execs))
+}
+
+func TestJobWithMapItem(t *testing.T) {
+	job := &Job{
+		Name: "process_tenant",
+		ExecutorConfig: map[string]string{
+			"command": "/opt/process.sh {{ .Item.tenant }} {{ .Index }}",
+			"env":     "ITEM={{ .ItemJSON }}",
+			"shell":   "true",
+		},
+	}
+	ex := &Execution{Metadata: map[string]string{
+		mapIndexMetadata: "1",
+		mapItemMetadata:  `{"tenant": "acme"}`,
+	}}
+
+	item, err := job.withMapItem(ex)
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/process.sh acme 1", item.ExecutorConfig["command"])
+	assert.Equal(t, `ITEM={"tenant": "acme"}`, item.ExecutorConfig["env"])
+	assert.Equal(t, "true", item.ExecutorConfig["shell"])
+	assert.Equal(t, "/opt/process.sh {{ .Item.tenant }} {{ .Index }}", job.ExecutorConfig["command"])
+
+	// Items missing the keys used by the templates fail
+	ex.Metadata[mapItemMetadata] = `{"name": "acme"}`
+	_, err = job.withMapItem(ex)
+	assert.Error(t, err)
+}
+
+func TestJobWithMapItemBodyTemplate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer ts.Close()
+
+	job := &Job{
+		Name:     "bill_tenant",
+		Executor: "http",
+		ExecutorConfig: map[string]string{
+			"method":       "POST",
+			"url":          ts.URL + "/tenants/{{ .Index }}",
+			"body":         `{"parent": "{{ .Parent.JobName }}", "tenant": "{{ .Item.tenant }}", "index": {{ .Index }}}`,
+			"bodyTemplate": "true",
+		},
+	}
+	ex := &Execution{Metadata: map[string]string{
+		parentJobMetadata: "list_tenants",
+		mapIndexMetadata:  "1",
+		mapItemMetadata:   `{"tenant": "{{ acme }}"}`,
+	}}
+
+	// The body is rendered once, by the executor, with the map item
+	item, err := job.withMapItem(ex)
+	require.NoError(t, err)
+	assert.Equal(t, ts.URL+"/tenants/1", item.ExecutorConfig["url"])
+	assert.Equal(t, job.ExecutorConfig["body"], item.ExecutorConfig["body"])
+
+	resp, err := dkhttp.New().Execute(&typesv1.ExecuteRequest{
+		JobName: job.Name,
+		Config:  item.ExecutorConfig,
+		Parent:  parentFromMetadata(ex.Metadata),
+		MapItem: mapItemFromMetadata(ex.Metadata),
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, `{"parent": "list_tenants", "tenant": "{{ acme }}", "index": 1}`, string(resp.Output))
+}
+
+func TestMapItems(t *testing.T) {
+	items, err := mapItems(`["acme", {"tenant": "globex"}, 3]`)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, `{"tenant": "globex"}`, string(items[1]))
+
+	items, err = mapItems("")
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	_, err = mapItems(`{"tenant": "acme"}`)
+	assert.Error(t, err)
+}
+
+func TestMapTracker(t *testing.T) {
+	var m mapTracker
+	job := &Job{Name: "process_tenant", MaxParallel: 2}
+	execs := make([]*Execution, 3)
+	for i := range execs {
+		execs[i] = &Execution{JobName: job.Name, Group: 1, Metadata: map[string]string{mapIndexMetadata: strconv.Itoa(i)}}
+	}
+
+	running := m.start(job, execs)
+	assert.Equal(t, execs[:2], running)
+
+	// Finished items run the pending ones
+	next, finished := m.itemDone(execs[1])
+	assert.Equal(t, execs[2], next)
+	assert.False(t, finished)
+
+	// Items finishing twice count once
+	next, finished = m.itemDone(execs[1])
+	assert.Nil(t, next)
+	assert.False(t, finished)
+
+	next, finished = m.itemDone(execs[0])
+	assert.Nil(t, next)
+	assert.False(t, finished)
+	next, finished = m.itemDone(execs[2])
+	assert.Nil(t, next)
+	assert.True(t, finished)
+
+	// Unknown runs never finish
+	_, finished = m.itemDone(execs[2])
+	assert.False(t, finished)
+
+	// No limit runs all of them
+	job.MaxParallel = 0
+	assert.Len(t, m.start(job, execs), 3)
+}
+
+func TestStoppedMapRun(t *testing.T) {
+	now := time.Now()
+	item := func(group int64, index int) *Execution {
+		return &Execution{
+			Group:     group,
+			StartedAt: now.Add(time.Duration(group)*time.Minute + time.Duration(index)*time.Second),
+			Metadata: map[string]string{
+				mapIndexMetadata: strconv.Itoa(index),
+				mapItemsMetadata: "3",
+			},
+		}
+	}
+
+	// Runs that started all their items are not stopped
+	execs := []*Execution{item(1, 0), item(1, 1), item(1, 2)}
+	ex, _ := stoppedMapRun(execs)
+	assert.Nil(t, ex)
+
+	// Only the last run counts, items of earlier ones can be pruned
+	execs = append(execs, item(2, 0), item(2, 1))
+	ex, missing := stoppedMapRun(execs[1:])
+	require.NotNil(t, ex)
+	assert.Equal(t, int64(2), ex.Group)
+	assert.Equal(t, 1, missing)
+
+	// Once recorded, the run is not stopped again
+	execs = append(execs, &Execution{Group: 2, StartedAt: now.Add(time.Hour), Skipped: true})
+	ex, _ = stoppedMapRun(execs)
+	assert.Nil(t, ex)
+	ex, _ = stoppedMapRun(nil)
+	assert.Nil(t, ex)
+}
+
+func TestStore_MapJobStatus(t *testing.T) {
+	s := setupStore(t)
+	ctx := context.Background()
+
+	storeJob(t, s, "list_tenants")
+	job := scaffoldJob()
+	job.Name = "process_tenant"
+	job.ParentJob = "list_tenants"
+	job.Map = true
+	job.MapSuccessRule = "50%"
+	require.NoError(t, s.SetJob(ctx, job, false))
+
+	now := time.Now()
+	for i, success := range []bool{true, false} {
+		_, err := s.SetExecutionDone(ctx, &Execution{
+			JobName:    job.Name,
+			NodeName:   "node1",
+			Group:      1,
+			Attempt:    1,
+			StartedAt:  now.Add(time.Duration(i) * time.Second),
+			FinishedAt: now.Add(time.Minute),
+			Success:    success,
+			Metadata: map[string]string{
+				mapIndexMetadata: strconv.Itoa(i),
+				mapItemsMetadata: "2",
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	// Half of the items succeeded, unlike a partially failed run
+	assert.Equal(t, StatusSuccess, loadJob(t, s, job.Name).Status)
+}
+
+func TestStore_MapJobStatusManyItems(t *testing.T) {
+	s := setupStore(t)
+	ctx := context.Background()
+
+	storeJob(t, s, "list_tenants")
+	job := scaffoldJob()
+	job.Name = "process_tenant"
+	job.ParentJob = "list_tenants"
+	job.Map = true
+	job.MapSuccessRule = "99%"
+	require.NoError(t, s.SetJob(ctx, job, false))
+
+	// A previous run to be pruned
+	now := time.Now()
+	_, err := s.SetExecution(ctx, &Execution{JobName: job.Name, NodeName: "node1", Group: 1, StartedAt: now.Add(-time.Hour)})
+	require.NoError(t, err)
+
+	// Items run one after the other
+	items := MaxExecutions + 50
+	var ex *Execution
+	for i := 0; i < items; i++ {
+		ex = &Execution{
+			JobName:   job.Name,
+			NodeName:  "node1",
+			Group:     2,
+			Attempt:   1,
+			StartedAt: now.Add(time.Duration(i) * time.Millisecond),
+			Metadata: map[string]string{
+				mapIndexMetadata: strconv.Itoa(i),
+				mapItemsMetadata: strconv.Itoa(items),
+			},
+		}
+		_, err := s.SetExecution(ctx, ex)
+		require.NoError(t, err)
+
+		ex.FinishedAt = ex.StartedAt.Add(time.Second)
+		ex.Success = i > 0
+		_, err = s.SetExecutionDone(ctx, ex)
+		require.NoError(t, err)
+	}
+
+	// The items of the run are all kept while it runs
+	group, err := s.GetExecutionGroup(ctx, ex, &ExecutionOptions{})
+	require.NoError(t, err)
+	assert.Len(t, group, items)
+	all, err := s.GetExecutions(ctx, job.Name, &ExecutionOptions{})
+	require.NoError(t, err)
+	assert.Len(t, all, items)
+
+	// One failed item out of 150 meets the 99% rule
+	assert.Equal(t, StatusSuccess, loadJob(t, s, job.Name).Status)
+}
+
+func TestAgentMapItemsPoolAndCapacity(t *testing.T) {
+	s, err := NewStore(getTestLogger(), otel.Tracer("test"))
+	require.NoError(t, err)
+	defer s.Shutdown() // nolint: errcheck
+
+	ctx := context.Background()
+	require.NoError(t, s.SetPool(ctx, &Pool{Name: "db", Slots: 1}))
+
+	sched := NewScheduler(getTestLogger())
+	require.NoError(t, sched.Start(nil, &Agent{}))
+	defer sched.Stop()
+
+	rec := agentRunRecorder{ran: make(chan string, 3)}
+	a := &Agent{Store: s, GRPCClient: rec, logger: getTestLogger(), sched: sched}
+	job := &Job{Name: "map_job", Map: true, MaxParallel: 2, Pool: "db"}
+
+	// The node runs its maximum number of executions
+	node := Node{Name: "node1", Tags: map[string]string{
+		"rpc_addr":           "127.0.0.1:6868",
+		maxExecutionsTag:     "1",
+		runningExecutionsTag: "1",
+	}}
+	nodes := []Node{node}
+
+	group := time.Now().UnixNano()
+	execs := make([]*Execution, 3)
+	for i := range execs {
+		execs[i] = &Execution{
+			JobName:  job.Name,
+			Group:    group,
+			NodeName: node.Name,
+			Metadata: map[string]string{mapIndexMetadata: strconv.Itoa(i), mapItemsMetadata: "3"},
+		}
+	}
+	started := a.maps.start(job, execs)
+	require.Len(t, started, 2)
+	for _, ex := range started {
+		require.NoError(t, a.runInPool(ctx, job, ex, nodes))
+	}
+
+	// The first item waits for the node, the second for the pool slot
+	assert.Equal(t, "0", a.capacity.peek(node.Name).MapIndex)
+	assert.Equal(t, "1", a.pools.queue.peek("db").MapIndex)
+	assert.Equal(t, strconv.FormatInt(group, 10), a.pools.queue.peek("db").Group)
+
+	a.dequeueCapacityRun(node.Name)
+	assert.Equal(t, "0", <-rec.ran)
+
+	// The first item finishes, the second takes its slot and waits for the
+	// node, the third waits for the slot
+	a.releasePoolSlots(execs[0])
+	next, finished := a.maps.itemDone(execs[0])
+	require.False(t, finished)
+	require.NoError(t, a.runInPool(ctx, job, next, nodes))
+	assert.Eventually(t, func() bool {
+		r := a.capacity.peek(node.Name)
+		return r != nil && r.MapIndex == "1"
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "2", a.pools.queue.peek("db").MapIndex)
+
+	a.dequeueCapacityRun(node.Name)
+	assert.Equal(t, "1", <-rec.ran)
+
+	// The third item is dropped as the node queue is full, which finishes
+	// the run and starts the next queued one
+	for i := 0; i < MaxQueuedRuns; i++ {
+		require.True(t, a.capacity.push(&QueuedRun{JobName: "other", Reason: QueueReasonCapacity, Resource: node.Name}, MaxQueuedRuns))
+	}
+	ran := make(chan struct{}, 1)
+	require.True(t, a.queueRun(job, func() { ran <- struct{}{} }))
+
+	next, finished = a.maps.itemDone(execs[1])
+	assert.Nil(t, next)
+	require.False(t, finished)
+	a.releasePoolSlots(execs[1])
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued run not started")
+	}
+	a.maps.mu.Lock()
+	assert.Empty(t, a.maps.runs)
+	a.maps.mu.Unlock()
+	a.pools.mu.Lock()
+	assert.Equal(t, 0, a.pools.used["db"])
+	a.pools.mu.Unlock()
+}
@@ -0,0 +1,116 @@
+package dkron
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// misfiredRuns returns the fire times the job missed between its persisted
+// next execution and now, filtered by the job misfire policy and grace.
+// Fire times excluded by the given calendars or outside of the job run
+// windows are not considered missed.
+func (j *Job) misfiredRuns(now time.Time, calendars []*Calendar) ([]time.Time, error) {
+	if j.MisfirePolicy == "" || j.MisfirePolicy == MisfireSkip {
+		return nil, nil
+	}
+
+	if j.Next.IsZero() || !j.Next.Before(now) {
+		return nil, nil
+	}
+
+	s, err := j.schedule(calendars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Runs older than the grace period are dropped, start looking
+	// for missed runs from there.
+	from := j.nextRun(j.Next, calendars)
+	if j.MisfireGrace != "" {
+		grace, err := time.ParseDuration(j.MisfireGrace)
+		if err != nil {
+			return nil, err
+		}
+		if oldest := now.Add(-grace); from.Before(oldest) {
+			from = s.Next(oldest.Add(-time.Nanosecond))
+		}
+	}
+
+	var missed []time.Time
+	for t := from; !t.IsZero() && t.Before(now); {
+		if j.inRunWindow(t) {
+			missed = append(missed, t)
+		}
+		if j.MisfirePolicy == MisfireRunAll && len(missed) >= MaxMisfireRuns {
+			break
+		}
+
+		next := s.Next(t)
+		if !next.After(t) {
+			break
+		}
+		t = next
+	}
+
+	if j.MisfirePolicy == MisfireRunOnce && len(missed) > 1 {
+		missed = missed[len(missed)-1:]
+	}
+
+	return missed, nil
+}
+
+// runMisfiredJobs dispatches the runs that the given jobs missed while the
+// cluster had no leader, according to each job misfire policy.
+func (a *Agent) runMisfiredJobs(jobs []*Job, now time.Time) {
+	for _, job := range jobs {
+		if job.Disabled || len(job.parents()) > 0 {
+			continue
+		}
+
+		missed, err := job.misfiredRuns(now, a.getCalendars(context.Background(), job))
+		if err != nil {
+			a.logger.WithError(err).WithField("job", job.Name).Error("leader: Error computing missed runs")
+			continue
+		}
+		if len(missed) == 0 {
+			continue
+		}
+
+		a.logger.WithFields(logrus.Fields{
+			"job":            job.Name,
+			"misfire_policy": job.MisfirePolicy,
+			"missed_runs":    len(missed),
+			"first_missed":   missed[0],
+		}).Info("leader: Dispatching missed runs")
+		metrics.IncrCounterWithLabels([]string{"scheduler", "misfire"}, float32(len(missed)), []metrics.Label{{Name: "job", Value: job.Name}})
+
+		// Runs are dispatched in order. Agent.Run returns once the target
+		// nodes ran them, or as soon as they wait for pool slots or node
+		// capacity, so queued runs can overlap. Calendars and run windows
+		// are checked at the missed fire time, the rest at dispatch time.
+		go func(job *Job, missed []time.Time) {
+			for _, t := range missed {
+				resume := func() { job.scheduleJobAt(t).run(t, time.Now(), 0) }
+				if !job.isRunnableAt(a.logger, t, resume) {
+					return
+				}
+
+				a.logger.WithFields(logrus.Fields{
+					"job":       job.Name,
+					"scheduled": t,
+				}).Debug("leader: Running missed run")
+
+				ex := NewExecution(job.Name)
+				ex.ScheduledAt = t
+				job.scheduleJobAt(t).setScheduleMetadata(ex)
+				if _, err := a.Run(context.Background(), job.Name, ex); err != nil {
+					a.logger.WithError(err).WithField("job", job.Name).Error("leader: Error running missed run")
+					return
+				}
+			}
+		}(job, missed)
+	}
+}
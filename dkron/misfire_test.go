@@ -0,0 +1,144 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobMisfiredRuns(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		policy   string
+		grace    string
+		next     time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "default policy skips",
+			next:     now.Add(-2 * time.Hour),
+			expected: nil,
+		},
+		{
+			name:     "skip policy",
+			policy:   MisfireSkip,
+			next:     now.Add(-2 * time.Hour),
+			expected: nil,
+		},
+		{
+			name:     "next in the future",
+			policy:   MisfireRunAll,
+			next:     now.Add(time.Hour),
+			expected: nil,
+		},
+		{
+			name:   "run once keeps the latest missed run",
+			policy: MisfireRunOnce,
+			next:   time.Date(2024, 2, 29, 22, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "run all",
+			policy: MisfireRunAll,
+			next:   time.Date(2024, 2, 29, 22, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 2, 29, 22, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "run all within grace",
+			policy: MisfireRunAll,
+			grace:  "90m",
+			next:   time.Date(2024, 2, 29, 22, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "run once outside grace",
+			policy:   MisfireRunOnce,
+			grace:    "10m",
+			next:     time.Date(2024, 2, 29, 22, 0, 0, 0, time.UTC),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &Job{
+				Name:          "misfire_job",
+				Schedule:      "0 0 * * * *",
+				Timezone:      "UTC",
+				MisfirePolicy: tc.policy,
+				MisfireGrace:  tc.grace,
+				Next:          tc.next,
+			}
+
+			missed, err := job.misfiredRuns(now, nil)
+			require.NoError(t, err)
+			assert.Len(t, missed, len(tc.expected))
+			for i := range tc.expected {
+				assert.True(t, tc.expected[i].Equal(missed[i]), "expected %s, got %s", tc.expected[i], missed[i])
+			}
+		})
+	}
+}
+
+func TestJobMisfiredRunsLimit(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	job := &Job{
+		Name:          "misfire_job",
+		Schedule:      "@every 1m",
+		MisfirePolicy: MisfireRunAll,
+		Next:          now.Add(-24 * time.Hour),
+	}
+
+	missed, err := job.misfiredRuns(now, nil)
+	require.NoError(t, err)
+	assert.Len(t, missed, MaxMisfireRuns)
+}
+
+func TestJobMisfiredRunsRunWindows(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+	job := &Job{
+		Name:          "misfire_job",
+		Schedule:      "0 0 * * * *",
+		Timezone:      "UTC",
+		MisfirePolicy: MisfireRunAll,
+		Next:          time.Date(2024, 2, 29, 21, 0, 0, 0, time.UTC),
+		RunWindows:    []*RunWindow{{Start: "22:00", End: "23:30"}},
+	}
+
+	// Missed fire times outside of the run windows are not replayed
+	missed, err := job.misfiredRuns(now, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 2, 29, 22, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC),
+	}, missed)
+}
+
+func TestJobValidateMisfire(t *testing.T) {
+	job := &Job{
+		Name:          "misfire_job",
+		Schedule:      "@every 1m",
+		MisfirePolicy: "sometimes",
+	}
+	assert.Equal(t, ErrWrongMisfirePolicy, job.Validate())
+
+	job.MisfirePolicy = MisfireRunAll
+	job.MisfireGrace = "one hour"
+	assert.Error(t, job.Validate())
+
+	job.MisfireGrace = "1h"
+	assert.NoError(t, job.Validate())
+}
@@ -0,0 +1,272 @@
+package dkron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	proto "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+)
+
+var (
+	// ErrPoolNotFound is returned when a job references a pool that doesn't exist.
+	ErrPoolNotFound = errors.New("specified pool not found")
+	// ErrPoolInUse is returned when deleting a pool that jobs still reference.
+	ErrPoolInUse = errors.New("store: could not delete pool used by jobs, remove it from the jobs first")
+)
+
+// Pool is a limited resource shared by several jobs, like a database. The
+// jobs in a pool only run while there are enough free slots for them.
+type Pool struct {
+	// Pool name. Must be unique, acts as the id.
+	Name string `json:"name"`
+
+	// Description of the pool.
+	Description string `json:"description"`
+
+	// Number of slots of the pool.
+	Slots uint `json:"slots"`
+}
+
+// NewPoolFromProto creates a new Pool from a PB Pool struct
+func NewPoolFromProto(in *proto.Pool) *Pool {
+	return &Pool{
+		Name:        in.Name,
+		Description: in.Description,
+		Slots:       uint(in.Slots),
+	}
+}
+
+// ToProto returns the corresponding representation of this Pool in proto struct
+func (p *Pool) ToProto() *proto.Pool {
+	return &proto.Pool{
+		Name:        p.Name,
+		Description: p.Description,
+		Slots:       uint32(p.Slots),
+	}
+}
+
+// Validate validates whether all values in the pool are acceptable.
+func (p *Pool) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	if valid, chr := isSlug(p.Name); !valid {
+		return fmt.Errorf("name contains illegal character '%s'", chr)
+	}
+
+	if p.Slots == 0 {
+		return fmt.Errorf("slots must be greater than zero")
+	}
+
+	return nil
+}
+
+// poolSlots returns the slots of its pool a run of the job takes in each node.
+func (j *Job) poolSlots() int {
+	if j.PoolSlots == 0 {
+		return 1
+	}
+	return int(j.PoolSlots)
+}
+
+// poolLeaseKey returns the key of the slots taken by the execution in the
+// given node. Items of map jobs take their own slots.
+func poolLeaseKey(ex *Execution, node string) string {
+	return ex.runItem() + "-" + node
+}
+
+// poolLease is the slots of a pool taken by an execution.
+type poolLease struct {
+	pool  string
+	slots int
+}
+
+// poolLedger tracks in the leader the pool slots taken by running executions,
+// and the runs waiting for free slots.
+type poolLedger struct {
+	mu     sync.Mutex
+	size   map[string]int
+	used   map[string]int
+	leases map[string]poolLease
+	queue  dispatchQueue
+}
+
+func (l *poolLedger) init() {
+	if l.leases == nil {
+		l.size = make(map[string]int)
+		l.used = make(map[string]int)
+		l.leases = make(map[string]poolLease)
+	}
+}
+
+// acquire takes the slots of the pool the run needs for each of its keys,
+// and returns whether they were free. If they were not, the run waits in
+// the queue and is started once they are taken.
+func (l *poolLedger) acquire(size int, r *QueuedRun) bool {
+	l.mu.Lock()
+	l.init()
+	l.size[r.Resource] = size
+
+	l.queue.push(r, 0)
+	started := l.startLocked(r.Resource)
+	l.mu.Unlock()
+
+	acquired := false
+	for _, s := range started {
+		if s == r {
+			acquired = true
+		} else {
+			go s.run()
+		}
+	}
+	return acquired
+}
+
+// release frees the slots taken with the given key, starting the
+// waiting runs that fit.
+func (l *poolLedger) release(key string) {
+	l.mu.Lock()
+	l.init()
+	lease, ok := l.leases[key]
+	if !ok {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.leases, key)
+	l.used[lease.pool] -= lease.slots
+
+	started := l.startLocked(lease.pool)
+	l.mu.Unlock()
+
+	for _, s := range started {
+		go s.run()
+	}
+}
+
+// resize updates the slots of the pool, starting the waiting runs that
+// fit when it grows.
+func (l *poolLedger) resize(pool string, size int) {
+	l.mu.Lock()
+	l.init()
+	l.size[pool] = size
+
+	started := l.startLocked(pool)
+	l.mu.Unlock()
+
+	for _, s := range started {
+		go s.run()
+	}
+}
+
+// startLocked takes the slots for the waiting runs of the pool, in order,
+// while they fit, and returns them.
+func (l *poolLedger) startLocked(pool string) []*QueuedRun {
+	var started []*QueuedRun
+	for {
+		r := l.queue.peek(pool)
+		if r == nil {
+			break
+		}
+		need := r.slots * len(r.keys)
+		if l.used[pool]+need > l.size[pool] {
+			break
+		}
+		l.queue.pop(pool)
+		l.used[pool] += need
+		for _, k := range r.keys {
+			l.leases[k] = poolLease{pool: pool, slots: r.slots}
+		}
+		started = append(started, r)
+	}
+	return started
+}
+
+// reset drops every lease and waiting run, keeping the given leases.
+func (l *poolLedger) reset(leases map[string]poolLease) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.leases = nil
+	l.init()
+	for k, lease := range leases {
+		l.leases[k] = lease
+		l.used[lease.pool] += lease.slots
+	}
+	l.queue.reset()
+}
+
+// runInPool dispatches the run of a job in a pool if there are enough free
+// slots for it in every target node, or holds it until there are.
+func (a *Agent) runInPool(ctx context.Context, job *Job, ex *Execution, nodes []Node) error {
+	pool, err := a.Store.GetPool(ctx, job.Pool)
+	if err != nil {
+		if err == buntdb.ErrNotFound {
+			err = ErrPoolNotFound
+		}
+		return fmt.Errorf("agent: Run error retrieving pool %s of job %s: %w", job.Pool, job.Name, err)
+	}
+
+	slots := job.poolSlots()
+	if slots*len(nodes) > int(pool.Slots) {
+		return fmt.Errorf("agent: Run error job %s needs %d slots of pool %s with %d slots", job.Name, slots*len(nodes), pool.Name, pool.Slots)
+	}
+
+	keys := make([]string, len(nodes))
+	for i, n := range nodes {
+		keys[i] = poolLeaseKey(ex, n.Name)
+	}
+
+	r := &QueuedRun{
+		JobName:  job.Name,
+		Priority: job.Priority,
+		Reason:   QueueReasonPool,
+		Resource: pool.Name,
+		Group:    ex.GetGroup(),
+		MapIndex: ex.Metadata[mapIndexMetadata],
+		keys:     keys,
+		slots:    slots,
+		run: func() {
+			a.dispatchRun(job, ex, nodes)
+		},
+	}
+	if a.pools.acquire(int(pool.Slots), r) {
+		r.run()
+		return nil
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"job":  job.Name,
+		"pool": pool.Name,
+	}).Info("agent: Waiting for free slots in pool")
+	return nil
+}
+
+// releasePoolSlots frees the pool slots taken by a finished execution.
+func (a *Agent) releasePoolSlots(ex *Execution) {
+	a.pools.release(poolLeaseKey(ex, ex.NodeName))
+}
+
+// resetPoolSlots rebuilds the pool slots taken from the executions still
+// running, dropping the runs waiting for free slots.
+func (a *Agent) resetPoolSlots(ctx context.Context, jobs []*Job) {
+	leases := make(map[string]poolLease)
+	for _, job := range jobs {
+		if job.Pool == "" {
+			continue
+		}
+		running, err := a.Store.GetRunningExecutions(ctx, job.Name)
+		if err != nil {
+			a.logger.WithError(err).WithField("job", job.Name).Error("agent: Error retrieving running executions")
+			continue
+		}
+		for _, ex := range running {
+			leases[poolLeaseKey(ex, ex.NodeName)] = poolLease{pool: job.Pool, slots: job.poolSlots()}
+		}
+	}
+	a.pools.reset(leases)
+}
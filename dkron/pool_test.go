@@ -0,0 +1,235 @@
+package dkron
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	proto "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+	"go.opentelemetry.io/otel"
+)
+
+func TestPoolValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		pool *Pool
+	}{
+		{"empty name", &Pool{Slots: 1}},
+		{"invalid name", &Pool{Name: "main db", Slots: 1}},
+		{"no slots", &Pool{Name: "db"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.pool.Validate())
+		})
+	}
+
+	assert.NoError(t, (&Pool{Name: "db", Slots: 2}).Validate())
+}
+
+func TestPoolLedger(t *testing.T) {
+	var l poolLedger
+	var order []string
+	queued := func(name string, keys []string, slots, priority int) *QueuedRun {
+		return &QueuedRun{
+			JobName:  name,
+			Priority: priority,
+			Reason:   QueueReasonPool,
+			Resource: "db",
+			keys:     keys,
+			slots:    slots,
+			run:      func() { order = append(order, name) },
+		}
+	}
+
+	// Runs that fit start right away
+	assert.True(t, l.acquire(3, queued("first", []string{"1-a", "1-b"}, 1, 0)))
+	assert.False(t, l.acquire(3, queued("low", []string{"2-a"}, 2, 0)))
+	assert.False(t, l.acquire(3, queued("high", []string{"3-a"}, 2, 5)))
+	assert.False(t, l.acquire(3, queued("high-later", []string{"4-a"}, 1, 5)))
+
+	// The highest priority run waits first, and runs behind it wait
+	// even if they fit, so it is not starved.
+	assert.Equal(t, 2, l.used["db"])
+	assert.Equal(t, 3, l.queue.len("db"))
+	assert.Equal(t, "high", l.queue.peek("db").JobName)
+
+	// Releasing unknown keys is a no-op
+	l.release("9-a")
+	assert.Equal(t, 2, l.used["db"])
+
+	// Run the started waiters synchronously to check their order
+	l.mu.Lock()
+	l.used["db"] -= 2
+	delete(l.leases, "1-a")
+	delete(l.leases, "1-b")
+	for _, r := range l.startLocked("db") {
+		r.run()
+	}
+	l.mu.Unlock()
+	assert.Equal(t, []string{"high", "high-later"}, order)
+	assert.Equal(t, 3, l.used["db"])
+
+	l.mu.Lock()
+	l.used["db"] -= 2
+	delete(l.leases, "3-a")
+	for _, r := range l.startLocked("db") {
+		r.run()
+	}
+	l.mu.Unlock()
+	assert.Equal(t, []string{"high", "high-later", "low"}, order)
+	assert.Equal(t, 0, l.queue.len("db"))
+
+	// Pools don't share their slots
+	cache := queued("cache", []string{"5-a"}, 1, 0)
+	cache.Resource = "cache"
+	assert.True(t, l.acquire(1, cache))
+
+	// Reset keeps only the given leases and drops the waiting runs
+	dropped := queued("dropped", []string{"6-a"}, 1, 0)
+	dropped.Resource = "cache"
+	assert.False(t, l.acquire(1, dropped))
+	l.reset(map[string]poolLease{"2-a": {pool: "db", slots: 2}})
+	assert.Equal(t, 2, l.used["db"])
+	assert.Equal(t, 0, l.used["cache"])
+	assert.Equal(t, 0, l.queue.len("cache"))
+
+	l.release("2-a")
+	assert.Equal(t, 0, l.used["db"])
+}
+
+func TestPoolLedgerResize(t *testing.T) {
+	var l poolLedger
+	ran := make(chan string, 1)
+	queued := func(name string) *QueuedRun {
+		return &QueuedRun{
+			JobName:  name,
+			Reason:   QueueReasonPool,
+			Resource: "db",
+			keys:     []string{name},
+			slots:    1,
+			run:      func() { ran <- name },
+		}
+	}
+
+	assert.True(t, l.acquire(1, queued("first")))
+	assert.False(t, l.acquire(1, queued("second")))
+
+	// Shrinking pools don't start anything
+	l.resize("db", 1)
+	assert.Equal(t, 1, l.queue.len("db"))
+
+	// Growing pools start the waiting runs that fit
+	l.resize("db", 2)
+	assert.Equal(t, "second", <-ran)
+	assert.Equal(t, 2, l.used["db"])
+	assert.Equal(t, 0, l.queue.len("db"))
+}
+
+// agentRunRecorder records the map items the nodes are asked to run.
+type agentRunRecorder struct {
+	gRPCClientMock
+	ran chan string
+}
+
+func (r agentRunRecorder) AgentRun(addr string, job *proto.Job, execution *proto.Execution, parent *proto.ParentExecution) error {
+	r.ran <- execution.Metadata[mapIndexMetadata]
+	return nil
+}
+
+func TestAgentRunInPoolMapItems(t *testing.T) {
+	s, err := NewStore(getTestLogger(), otel.Tracer("test"))
+	require.NoError(t, err)
+	defer s.Shutdown() // nolint: errcheck
+
+	ctx := context.Background()
+	require.NoError(t, s.SetPool(ctx, &Pool{Name: "db", Slots: 2}))
+
+	rec := agentRunRecorder{ran: make(chan string, 3)}
+	a := &Agent{Store: s, GRPCClient: rec, logger: getTestLogger()}
+	job := &Job{Name: "map_job", Pool: "db", Map: true}
+	node := Node{Name: "node1", Tags: map[string]string{"rpc_addr": "127.0.0.1:6868"}}
+
+	// The items of a run share its group and run in the same node
+	group := time.Now().UnixNano()
+	items := make([]*Execution, 3)
+	for i := range items {
+		items[i] = &Execution{
+			JobName:  job.Name,
+			Group:    group,
+			NodeName: node.Name,
+			Metadata: map[string]string{mapIndexMetadata: strconv.Itoa(i), mapItemsMetadata: "3"},
+		}
+		require.NoError(t, a.runInPool(ctx, job, items[i], []Node{node}))
+	}
+
+	// Every item takes its own slot, the third one waits
+	assert.ElementsMatch(t, []string{"0", "1"}, []string{<-rec.ran, <-rec.ran})
+	assert.Equal(t, 2, a.pools.used["db"])
+	assert.Equal(t, 1, a.pools.queue.len("db"))
+
+	a.releasePoolSlots(items[0])
+	assert.Equal(t, "2", <-rec.ran)
+
+	a.releasePoolSlots(items[1])
+	a.releasePoolSlots(items[2])
+	a.pools.mu.Lock()
+	defer a.pools.mu.Unlock()
+	assert.Equal(t, 0, a.pools.used["db"])
+	assert.Empty(t, a.pools.leases)
+}
+
+func TestStorePools(t *testing.T) {
+	s, err := NewStore(getTestLogger(), otel.Tracer("test"))
+	require.NoError(t, err)
+	defer s.Shutdown() // nolint: errcheck
+
+	ctx := context.Background()
+	job := &Job{
+		Name:      "pool_job",
+		Schedule:  "@every 1h",
+		Pool:      "db",
+		PoolSlots: 2,
+		Priority:  10,
+	}
+
+	// Jobs can't use missing pools
+	assert.Equal(t, ErrPoolNotFound, s.SetJob(ctx, job, false))
+
+	pool := &Pool{Name: "db", Description: "Main database", Slots: 4}
+	require.NoError(t, s.SetPool(ctx, pool))
+	assert.Error(t, s.SetPool(ctx, &Pool{Name: "invalid"}))
+
+	stored, err := s.GetPool(ctx, pool.Name)
+	require.NoError(t, err)
+	assert.Equal(t, pool, stored)
+
+	pools, err := s.GetPools(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pools, 1)
+
+	require.NoError(t, s.SetJob(ctx, job, false))
+	storedJob, err := s.GetJob(ctx, job.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "db", storedJob.Pool)
+	assert.Equal(t, uint(2), storedJob.PoolSlots)
+	assert.Equal(t, 10, storedJob.Priority)
+
+	// Pools used by jobs can't be deleted
+	_, err = s.DeletePool(ctx, pool.Name)
+	assert.Equal(t, ErrPoolInUse, err)
+
+	_, err = s.DeleteJob(ctx, job.Name)
+	require.NoError(t, err)
+
+	deleted, err := s.DeletePool(ctx, pool.Name)
+	require.NoError(t, err)
+	assert.Equal(t, pool.Name, deleted.Name)
+
+	_, err = s.GetPool(ctx, pool.Name)
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
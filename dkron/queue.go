@@ -0,0 +1,193 @@
+package dkron
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-metrics"
+)
+
+const (
+	// QueueReasonConcurrency is used for runs waiting for an execution
+	// of the job to finish.
+	QueueReasonConcurrency = "concurrency"
+	// QueueReasonPool is used for runs waiting for free slots in a pool.
+	QueueReasonPool = "pool"
+	// QueueReasonCapacity is used for runs waiting for a node to finish
+	// one of its executions.
+	QueueReasonCapacity = "capacity"
+)
+
+// QueuedRun is a run of a job waiting in the leader to be dispatched.
+type QueuedRun struct {
+	// Name of the job.
+	JobName string `json:"job_name"`
+
+	// Priority of the job, higher runs first.
+	Priority int `json:"priority"`
+
+	// What the run is waiting for, concurrency, pool or capacity.
+	Reason string `json:"reason"`
+
+	// Name of the job, pool or node the run is waiting for.
+	Resource string `json:"resource"`
+
+	// When the run was queued.
+	QueuedAt time.Time `json:"queued_at"`
+
+	// Execution group of the run, empty for runs not dispatched yet.
+	Group string `json:"group,omitempty"`
+
+	// Index of the item, for the items of map jobs, which share the
+	// group of their run.
+	MapIndex string `json:"map_index,omitempty"`
+
+	keys  []string
+	slots int
+	run   func()
+}
+
+// dispatchQueue holds the runs waiting for a resource, one queue per
+// resource ordered by priority, highest first, and FIFO within the
+// same priority.
+type dispatchQueue struct {
+	mu   sync.Mutex
+	runs map[string][]*QueuedRun
+}
+
+// push queues the run unless there are already max runs waiting for
+// the same resource, zero meaning no limit. It returns whether the run
+// was queued.
+func (q *dispatchQueue) push(r *QueuedRun, max int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.runs == nil {
+		q.runs = make(map[string][]*QueuedRun)
+	}
+	runs := q.runs[r.Resource]
+	if max > 0 && len(runs) >= max {
+		return false
+	}
+
+	if r.QueuedAt.IsZero() {
+		r.QueuedAt = time.Now()
+	}
+	// Runs queued again keep their place
+	i := sort.Search(len(runs), func(i int) bool {
+		if runs[i].Priority != r.Priority {
+			return runs[i].Priority < r.Priority
+		}
+		return runs[i].QueuedAt.After(r.QueuedAt)
+	})
+	runs = append(runs, nil)
+	copy(runs[i+1:], runs[i:])
+	runs[i] = r
+	q.runs[r.Resource] = runs
+
+	q.measureDepth(r.Reason)
+	return true
+}
+
+// peek returns the next run waiting for the resource, if any.
+func (q *dispatchQueue) peek(resource string) *QueuedRun {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if runs := q.runs[resource]; len(runs) > 0 {
+		return runs[0]
+	}
+	return nil
+}
+
+// pop removes and returns the next run waiting for the resource, if any.
+func (q *dispatchQueue) pop(resource string) *QueuedRun {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	runs := q.runs[resource]
+	if len(runs) == 0 {
+		return nil
+	}
+	r := runs[0]
+	if len(runs) == 1 {
+		delete(q.runs, resource)
+	} else {
+		q.runs[resource] = runs[1:]
+	}
+
+	metrics.MeasureSinceWithLabels([]string{"queue", "wait"}, r.QueuedAt, []metrics.Label{{Name: "reason", Value: r.Reason}})
+	q.measureDepth(r.Reason)
+	return r
+}
+
+// len returns how many runs are waiting for the resource.
+func (q *dispatchQueue) len(resource string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.runs[resource])
+}
+
+// list returns all the waiting runs, in the order they would run if
+// they waited for the same resource.
+func (q *dispatchQueue) list() []*QueuedRun {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	runs := make([]*QueuedRun, 0)
+	for _, rs := range q.runs {
+		runs = append(runs, rs...)
+	}
+	sortQueuedRuns(runs)
+	return runs
+}
+
+// reset drops all the waiting runs.
+func (q *dispatchQueue) reset() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	reasons := make(map[string]struct{})
+	for _, rs := range q.runs {
+		for _, r := range rs {
+			reasons[r.Reason] = struct{}{}
+		}
+	}
+	q.runs = nil
+	for reason := range reasons {
+		q.measureDepth(reason)
+	}
+}
+
+// measureDepth reports how many runs are waiting for the given reason.
+func (q *dispatchQueue) measureDepth(reason string) {
+	depth := 0
+	for _, rs := range q.runs {
+		for _, r := range rs {
+			if r.Reason == reason {
+				depth++
+			}
+		}
+	}
+	metrics.SetGaugeWithLabels([]string{"queue", "depth"}, float32(depth), []metrics.Label{{Name: "reason", Value: reason}})
+}
+
+func sortQueuedRuns(runs []*QueuedRun) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		if runs[i].Priority != runs[j].Priority {
+			return runs[i].Priority > runs[j].Priority
+		}
+		return runs[i].QueuedAt.Before(runs[j].QueuedAt)
+	})
+}
+
+// QueuedRuns returns the runs waiting in this node to be dispatched,
+// highest priority first. Only the leader dispatches runs.
+func (a *Agent) QueuedRuns() []*QueuedRun {
+	runs := append(a.queue.list(), a.pools.queue.list()...)
+	runs = append(runs, a.capacity.list()...)
+	sortQueuedRuns(runs)
+	return runs
+}
@@ -0,0 +1,68 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatchQueue(t *testing.T) {
+	var q dispatchQueue
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	queued := func(name, resource string, priority, minute int) *QueuedRun {
+		return &QueuedRun{
+			JobName:  name,
+			Priority: priority,
+			Reason:   QueueReasonPool,
+			Resource: resource,
+			QueuedAt: start.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+
+	assert.True(t, q.push(queued("low", "db", 0, 0), 3))
+	assert.True(t, q.push(queued("high", "db", 10, 1), 3))
+	assert.True(t, q.push(queued("high-later", "db", 10, 2), 3))
+	assert.False(t, q.push(queued("full", "db", 20, 3), 3))
+	assert.True(t, q.push(queued("cache", "cache", 5, 4), 3))
+
+	// Highest priority first, FIFO within the same priority
+	assert.Equal(t, "high", q.peek("db").JobName)
+	assert.Equal(t, 3, q.len("db"))
+
+	var names []string
+	for _, r := range q.list() {
+		names = append(names, r.JobName)
+	}
+	assert.Equal(t, []string{"high", "high-later", "cache", "low"}, names)
+
+	assert.Equal(t, "high", q.pop("db").JobName)
+	assert.Equal(t, "high-later", q.pop("db").JobName)
+	assert.Equal(t, "low", q.pop("db").JobName)
+	assert.Nil(t, q.pop("db"))
+	assert.Nil(t, q.peek("db"))
+
+	q.reset()
+	assert.Empty(t, q.list())
+}
+
+func TestAgentQueuedRuns(t *testing.T) {
+	a := &Agent{}
+	assert.Empty(t, a.QueuedRuns())
+
+	assert.True(t, a.queueRun(&Job{Name: "reports", Priority: 1}, func() {}))
+	a.pools.queue.push(&QueuedRun{
+		JobName:  "backup",
+		Priority: 5,
+		Reason:   QueueReasonPool,
+		Resource: "db",
+	}, 0)
+
+	runs := a.QueuedRuns()
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "backup", runs[0].JobName)
+	assert.Equal(t, QueueReasonPool, runs[0].Reason)
+	assert.Equal(t, "reports", runs[1].JobName)
+	assert.Equal(t, QueueReasonConcurrency, runs[1].Reason)
+	assert.False(t, runs[1].QueuedAt.IsZero())
+}
@@ -2,14 +2,126 @@ package dkron
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/serf/serf"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const (
+	// targetNodesMetadata is the execution metadata key recording the nodes
+	// a run was requested on.
+	targetNodesMetadata = "target_nodes"
+	// targetTagsMetadata is the execution metadata key recording the tags
+	// a run was requested with, in JSON.
+	targetTagsMetadata = "target_tags"
+)
+
+// ErrWrongRunTarget is returned when a run targets both nodes and tags.
+var ErrWrongRunTarget = errors.New("a run can target either nodes or tags, not both")
+
+// RunTarget overrides the nodes that run a job for a single run, instead
+// of the ones matching the job tags.
+type RunTarget struct {
+	// Names of the nodes to run the job on.
+	Nodes []string `json:"nodes,omitempty"`
+
+	// Tags of the nodes to run the job on, with the same format as the job tags.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Validate checks the run target is well formed.
+func (t *RunTarget) Validate() error {
+	if len(t.Nodes) > 0 && len(t.Tags) > 0 {
+		return ErrWrongRunTarget
+	}
+	return validateTags(t.Tags)
+}
+
+// record stores the run target in the execution metadata, so it's kept
+// by all the executions of the group.
+func (t *RunTarget) record(ex *Execution) {
+	if t == nil || (len(t.Nodes) == 0 && len(t.Tags) == 0) {
+		return
+	}
+	if ex.Metadata == nil {
+		ex.Metadata = make(map[string]string)
+	}
+	if len(t.Nodes) > 0 {
+		ex.Metadata[targetNodesMetadata] = strings.Join(t.Nodes, ",")
+	}
+	if len(t.Tags) > 0 {
+		tags, _ := json.Marshal(t.Tags)
+		ex.Metadata[targetTagsMetadata] = string(tags)
+	}
+}
+
+// runTargetFromExecution returns the run target recorded in the execution
+// metadata, or nil if the run uses the job tags.
+func runTargetFromExecution(ex *Execution) (*RunTarget, error) {
+	nodes, tags := ex.Metadata[targetNodesMetadata], ex.Metadata[targetTagsMetadata]
+	if nodes == "" && tags == "" {
+		return nil, nil
+	}
+
+	t := &RunTarget{}
+	if nodes != "" {
+		t.Nodes = strings.Split(nodes, ",")
+	}
+	if tags != "" {
+		if err := json.Unmarshal([]byte(tags), &t.Tags); err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: %w", targetTagsMetadata, err)
+		}
+	}
+	return t, nil
+}
+
+// getNodesByName returns the given nodes, that must be alive and in this
+// agent's region.
+func (a *Agent) getNodesByName(names []string) ([]Node, error) {
+	members := make(map[string]Node)
+	for _, m := range a.serf.Members() {
+		members[m.Name] = m
+	}
+
+	nodes := make([]Node, 0, len(names))
+	for _, name := range names {
+		m, ok := members[name]
+		if !ok || m.Tags["region"] != a.config.Region {
+			return nil, fmt.Errorf("target node not found: %s", name)
+		}
+		if m.Status != serf.StatusAlive {
+			return nil, fmt.Errorf("target node is not alive: %s", name)
+		}
+		nodes = append(nodes, m)
+	}
+	return nodes, nil
+}
+
+// scheduledNext returns the next run of the job from its scheduler entries,
+// skipping the days excluded by its calendars and the times skipped by its
+// DST policy, and whether the job is scheduled.
+func (a *Agent) scheduledNext(ctx context.Context, job *Job) (time.Time, bool) {
+	ej, ok := a.sched.GetEntryJob(job.Name)
+	if !ok {
+		return time.Time{}, false
+	}
+	next := ej.entry.Next
+	if len(job.Calendars) > 0 || job.DSTPolicy != "" {
+		next = job.nextRun(next, a.getCalendars(ctx, job))
+	}
+	return next, true
+}
+
 // Run call the agents to run a job. Returns a job with its new status and next schedule.
 func (a *Agent) Run(ctx context.Context, jobName string, ex *Execution) (*Job, error) {
 	ctx, span := a.tracer.Start(ctx, "agent.Run", trace.WithAttributes(attribute.String("job_name", jobName)))
@@ -21,9 +133,9 @@ func (a *Agent) Run(ctx context.Context, jobName string, ex *Execution) (*Job, e
 	}
 
 	// In case the job is not a child job, compute the next execution time
-	if job.ParentJob == "" {
-		if ej, ok := a.sched.GetEntryJob(jobName); ok {
-			job.Next = ej.entry.Next
+	if len(job.parents()) == 0 {
+		if next, ok := a.scheduledNext(ctx, job); ok {
+			job.Next = next
 			if err := a.applySetJob(job.ToProto()); err != nil {
 				return nil, fmt.Errorf("agent: Run error storing job %s before running: %w", jobName, err)
 			}
@@ -32,11 +144,50 @@ func (a *Agent) Run(ctx context.Context, jobName string, ex *Execution) (*Job, e
 		}
 	}
 
+	// Jobs not run by their parents start a new workflow run
+	if ex.WorkflowRunID == "" {
+		ex.WorkflowRunID = workflowRunID(job.Name, ex.Group)
+	}
+
+	// Runs of additional schedules use their executor config overrides
+	if i, err := strconv.Atoi(ex.Metadata["schedule_index"]); err == nil {
+		job = job.withSchedule(i)
+	}
+
+	// Map jobs run once for every item, with the item in their executor config
+	if job.Map {
+		if !isMapItem(ex) {
+			return job, a.runMap(ctx, job, ex)
+		}
+		if job, err = job.withMapItem(ex); err != nil {
+			return nil, err
+		}
+	}
+
+	// Runs requested on explicit nodes or tags don't use the job tags
+	target, err := runTargetFromExecution(ex)
+	if err != nil {
+		return nil, err
+	}
+	tags := job.Tags
+	if target != nil && len(target.Tags) > 0 {
+		tags = target.Tags
+	}
+
 	// In the first execution attempt we build and filter the target nodes
 	// but we use the existing node target in case of retry.
 	var targetNodes []Node
-	if ex.Attempt <= 1 {
-		targetNodes = a.getTargetNodes(job.Tags, defaultSelector)
+	if ex.Attempt <= 1 && target != nil && len(target.Nodes) > 0 {
+		if targetNodes, err = a.getNodesByName(target.Nodes); err != nil {
+			return nil, err
+		}
+	} else if ex.Attempt <= 1 {
+		targetNodes = a.getTargetNodes(tags, a.nodeSelector(job))
+	} else if job.SelectionStrategy != "" && (target == nil || len(target.Nodes) == 0) {
+		// Retries pick a node with the job selection strategy
+		bareTags, _ := cleanTags(tags, a.logger)
+		nodes := a.getQualifyingNodes(a.serf.Members(), bareTags)
+		targetNodes = selectNodes(nodes, 1, a.nodeSelector(job))
 	} else {
 		// In case of retrying, find the node or return with an error
 		for _, m := range a.serf.Members() {
@@ -51,39 +202,82 @@ func (a *Agent) Run(ctx context.Context, jobName string, ex *Execution) (*Job, e
 		}
 	}
 
+	// Every item of a map job runs in a single node
+	if isMapItem(ex) && len(targetNodes) > 1 {
+		targetNodes = targetNodes[:1]
+	}
+
 	// In case no nodes found, return reporting the error
 	if len(targetNodes) < 1 {
 		return nil, fmt.Errorf("no target nodes found to run job %s", ex.JobName)
 	}
 	a.logger.WithField("nodes", targetNodes).Debug("agent: Filtered nodes to run")
 
+	// Jobs in a resource pool wait for free slots before running
+	if job.Pool != "" {
+		return job, a.runInPool(ctx, job, ex, targetNodes)
+	}
+
+	a.dispatchRun(job, ex, targetNodes)
+	return job, nil
+}
+
+// dispatchRun calls the target nodes to run the job and waits for them
+// to start it. Saturated nodes run it once they finish an execution.
+func (a *Agent) dispatchRun(job *Job, ex *Execution, targetNodes []Node) {
+	// Runs finish once the executions in all of their nodes finish,
+	// retries and map items are part of a run already started.
+	if ex.Attempt <= 1 && !isMapItem(ex) {
+		a.runs.start(ex, len(targetNodes))
+	}
+
 	var wg sync.WaitGroup
 	for _, v := range targetNodes {
-		// Determine node address
-		addr, ok := v.Tags["rpc_addr"]
-		if !ok {
-			addr = v.Addr.String()
+		if nodeSaturated(v) {
+			a.waitForCapacity(job, ex, v, nil)
+			continue
 		}
 
 		// Call here client GRPC AgentRun
 		wg.Add(1)
-		go func(node string, wg *sync.WaitGroup) {
+		go func(node Node, wg *sync.WaitGroup) {
 			defer wg.Done()
-			a.logger.WithFields(map[string]interface{}{
-				"job_name": job.Name,
-				"node":     node,
-			}).Info("agent: Calling AgentRun")
-
-			err := a.GRPCClient.AgentRun(node, job.ToProto(), ex.ToProto())
-			if err != nil {
-				a.logger.WithFields(map[string]interface{}{
-					"job_name": job.Name,
-					"node":     node,
-				}).Error("agent: Error calling AgentRun")
-			}
-		}(addr, &wg)
+			a.agentRun(job, ex, node, nil)
+		}(v, &wg)
 	}
 
 	wg.Wait()
-	return job, nil
+}
+
+// agentRun calls the node to run the job, queueing the run if the node
+// is running its maximum number of executions.
+func (a *Agent) agentRun(job *Job, ex *Execution, v Node, queued *QueuedRun) {
+	// Determine node address
+	node, ok := v.Tags["rpc_addr"]
+	if !ok {
+		node = v.Addr.String()
+	}
+
+	a.logger.WithFields(map[string]interface{}{
+		"job_name": job.Name,
+		"node":     node,
+	}).Info("agent: Calling AgentRun")
+
+	parent := a.parentExecution(context.Background(), ex)
+	err := a.GRPCClient.AgentRun(node, job.ToProto(), ex.ToProto(), parent)
+	if err != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			a.waitForCapacity(job, ex, v, queued)
+			return
+		}
+
+		a.logger.WithFields(map[string]interface{}{
+			"job_name": job.Name,
+			"node":     node,
+		}).Error("agent: Error calling AgentRun")
+
+		// The execution won't finish, free its pool slots
+		a.pools.release(poolLeaseKey(ex, v.Name))
+		a.executionDropped(ex)
+	}
 }
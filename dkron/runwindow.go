@@ -0,0 +1,263 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	proto "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// runWindowDays are the valid weekday names of a run window, indexed
+// by time.Weekday.
+var runWindowDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// RunWindow is a range of time of some weekdays in which a job is allowed
+// to start. A window ending before its start time ends the following day.
+type RunWindow struct {
+	// Weekdays the window starts on (mon, tue, ...). Empty means every day.
+	Days []string `json:"days"`
+
+	// Time of the day the window starts, as HH:MM.
+	Start string `json:"start"`
+
+	// Time of the day the window ends, as HH:MM. Equal to the start
+	// means the window lasts the whole day.
+	End string `json:"end"`
+}
+
+func newRunWindowsFromProto(in []*proto.RunWindow) []*RunWindow {
+	if len(in) == 0 {
+		return nil
+	}
+	windows := make([]*RunWindow, len(in))
+	for i, w := range in {
+		windows[i] = &RunWindow{
+			Days:  w.Days,
+			Start: w.Start,
+			End:   w.End,
+		}
+	}
+	return windows
+}
+
+func runWindowsToProto(in []*RunWindow) []*proto.RunWindow {
+	if len(in) == 0 {
+		return nil
+	}
+	windows := make([]*proto.RunWindow, len(in))
+	for i, w := range in {
+		windows[i] = &proto.RunWindow{
+			Days:  w.Days,
+			Start: w.Start,
+			End:   w.End,
+		}
+	}
+	return windows
+}
+
+func (w *RunWindow) validate() error {
+	if w == nil {
+		return errors.New("window cannot be empty")
+	}
+	for _, d := range w.Days {
+		if !slices.Contains(runWindowDays, strings.ToLower(d)) {
+			return fmt.Errorf("invalid day %q, use mon, tue, wed, thu, fri, sat or sun", d)
+		}
+	}
+	if _, err := parseWindowTime(w.Start); err != nil {
+		return fmt.Errorf("invalid start: %s", err)
+	}
+	if _, err := parseWindowTime(w.End); err != nil {
+		return fmt.Errorf("invalid end: %s", err)
+	}
+	return nil
+}
+
+// parseWindowTime returns the time of the day of a HH:MM value.
+func parseWindowTime(v string) (time.Duration, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// onDay returns whether the window starts on the given weekday.
+func (w *RunWindow) onDay(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if strings.ToLower(day) == runWindowDays[d] {
+			return true
+		}
+	}
+	return false
+}
+
+// bounds returns when the window starting on the day of t opens and
+// closes, in the location of t.
+func (w *RunWindow) bounds(t time.Time) (time.Time, time.Time) {
+	start, _ := parseWindowTime(w.Start)
+	end, _ := parseWindowTime(w.End)
+	if end <= start {
+		end += 24 * time.Hour
+	}
+
+	// Wall clock times, time.Date normalizes the minutes past midnight
+	at := func(d time.Duration) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, int(d/time.Minute), 0, 0, t.Location())
+	}
+	return at(start), at(end)
+}
+
+// inRunWindow returns whether the job is allowed to start at t.
+func (j *Job) inRunWindow(t time.Time) bool {
+	if len(j.RunWindows) == 0 {
+		return true
+	}
+
+	t = t.In(j.location())
+	for _, w := range j.RunWindows {
+		// Windows started the day before can still be open
+		for _, day := range []time.Time{t, t.AddDate(0, 0, -1)} {
+			if !w.onDay(day.Weekday()) {
+				continue
+			}
+			start, end := w.bounds(day)
+			if !t.Before(start) && t.Before(end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextRunWindow returns when the next run window of the job after t opens,
+// or the zero time if it has none.
+func (j *Job) nextRunWindow(t time.Time) time.Time {
+	var next time.Time
+
+	t = t.In(j.location())
+	for _, w := range j.RunWindows {
+		for i := 0; i <= 7; i++ {
+			day := t.AddDate(0, 0, i)
+			if !w.onDay(day.Weekday()) {
+				continue
+			}
+			start, _ := w.bounds(day)
+			if start.After(t) {
+				if next.IsZero() || start.Before(next) {
+					next = start
+				}
+				break
+			}
+		}
+	}
+	return next
+}
+
+// checkRunWindow returns whether a run of the job scheduled at the given
+// time can start, and whether it was deferred. Runs outside of the job run
+// windows are skipped or, if the policy is defer, run is called when the
+// next window opens. Retries pass the group of their execution, scheduled
+// runs pass zero.
+func (j *Job) checkRunWindow(logger *logrus.Entry, at time.Time, group int64, run func()) (bool, bool) {
+	if j.inRunWindow(at) {
+		return true, false
+	}
+
+	if j.RunWindowPolicy == RunWindowDefer {
+		next := j.nextRunWindow(time.Now())
+		if j.Agent.deferRun(j.Name, group, next, run) {
+			logger.WithFields(logrus.Fields{
+				"job":   j.Name,
+				"group": group,
+				"until": next,
+			}).Info("job: Deferring execution until the next run window")
+			return false, true
+		}
+		logger.WithField("job", j.Name).
+			Debug("job: Skipping execution because one is already deferred")
+		return false, false
+	}
+
+	logger.WithField("job", j.Name).
+		Info("job: Skipping execution because it's outside of the run windows")
+	j.Agent.recordSkippedExecution(j, "Execution skipped, outside of the job run windows", nil)
+	return false, false
+}
+
+// deferredRunKey identifies a deferred run, scheduled runs of a job use
+// group zero and retries the group of their execution.
+type deferredRunKey struct {
+	job   string
+	group int64
+}
+
+// deferRun calls run for the job at the given time if this node is still
+// the leader, unless a run of the job with the same group is already
+// deferred. It returns whether the run was deferred.
+func (a *Agent) deferRun(jobName string, group int64, at time.Time, run func()) bool {
+	if at.IsZero() {
+		return false
+	}
+
+	a.deferredMu.Lock()
+	defer a.deferredMu.Unlock()
+
+	key := deferredRunKey{job: jobName, group: group}
+	if a.deferredRuns == nil {
+		a.deferredRuns = make(map[deferredRunKey]*time.Timer)
+	}
+	if _, ok := a.deferredRuns[key]; ok {
+		return false
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(at), func() {
+		a.deferredMu.Lock()
+		// Cancelled runs were replaced or removed in the meantime
+		if a.deferredRuns[key] != timer {
+			a.deferredMu.Unlock()
+			return
+		}
+		delete(a.deferredRuns, key)
+		a.deferredMu.Unlock()
+
+		if a.sched != nil && a.sched.Started() {
+			run()
+		}
+	})
+	a.deferredRuns[key] = timer
+	return true
+}
+
+// cancelDeferredRuns stops the deferred runs of the job, used when the
+// job is updated or deleted.
+func (a *Agent) cancelDeferredRuns(jobName string) {
+	a.deferredMu.Lock()
+	defer a.deferredMu.Unlock()
+
+	for key, timer := range a.deferredRuns {
+		if key.job == jobName {
+			timer.Stop()
+			delete(a.deferredRuns, key)
+		}
+	}
+}
+
+// stopDeferredRuns stops all the deferred runs, used when this node
+// stops being the leader.
+func (a *Agent) stopDeferredRuns() {
+	a.deferredMu.Lock()
+	defer a.deferredMu.Unlock()
+
+	for _, timer := range a.deferredRuns {
+		timer.Stop()
+	}
+	a.deferredRuns = nil
+}
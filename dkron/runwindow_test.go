@@ -0,0 +1,167 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobInRunWindow(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	job := &Job{
+		Name:     "batch_job",
+		Schedule: "@every 1m",
+		Timezone: "Europe/Madrid",
+		RunWindows: []*RunWindow{
+			// Weekday nights, outside of trading hours
+			{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "18:00", End: "08:00"},
+			// Weekends
+			{Days: []string{"Sat", "sun"}, Start: "00:00", End: "00:00"},
+		},
+	}
+	require.NoError(t, job.Validate())
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected bool
+	}{
+		{"trading hours", time.Date(2024, 6, 5, 10, 0, 0, 0, madrid), false},
+		{"window start", time.Date(2024, 6, 5, 18, 0, 0, 0, madrid), true},
+		{"after midnight", time.Date(2024, 6, 6, 2, 0, 0, 0, madrid), true},
+		{"window end", time.Date(2024, 6, 6, 8, 0, 0, 0, madrid), false},
+		{"friday night", time.Date(2024, 6, 8, 1, 0, 0, 0, madrid), true},
+		{"sunday", time.Date(2024, 6, 9, 14, 0, 0, 0, madrid), true},
+		{"monday morning", time.Date(2024, 6, 10, 9, 0, 0, 0, madrid), false},
+		{"other timezone", time.Date(2024, 6, 5, 16, 30, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, job.inRunWindow(tt.time))
+		})
+	}
+
+	job.RunWindows = nil
+	assert.True(t, job.inRunWindow(time.Date(2024, 6, 5, 10, 0, 0, 0, madrid)))
+}
+
+func TestJobNextRunWindow(t *testing.T) {
+	job := &Job{
+		Name:     "batch_job",
+		Schedule: "@every 1m",
+		RunWindows: []*RunWindow{
+			{Days: []string{"mon", "wed"}, Start: "22:00", End: "02:00"},
+			{Days: []string{"fri"}, Start: "06:30", End: "07:00"},
+		},
+	}
+
+	next := job.nextRunWindow(time.Date(2024, 6, 3, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 6, 5, 22, 0, 0, 0, time.UTC), next)
+
+	next = job.nextRunWindow(time.Date(2024, 6, 6, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 6, 7, 6, 30, 0, 0, time.UTC), next)
+
+	next = job.nextRunWindow(time.Date(2024, 6, 7, 6, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 6, 10, 22, 0, 0, 0, time.UTC), next)
+}
+
+func TestJobValidateRunWindows(t *testing.T) {
+	job := &Job{
+		Name:            "batch_job",
+		Schedule:        "@every 1m",
+		RunWindows:      []*RunWindow{{Start: "18:00", End: "08:00"}},
+		RunWindowPolicy: RunWindowDefer,
+	}
+	assert.NoError(t, job.Validate())
+
+	job.RunWindowPolicy = "wait"
+	assert.Equal(t, ErrWrongRunWindowPolicy, job.Validate())
+	job.RunWindowPolicy = RunWindowSkip
+
+	job.RunWindows[0].Days = []string{"monday"}
+	assert.ErrorContains(t, job.Validate(), "run_windows[0]: invalid day")
+	job.RunWindows[0].Days = nil
+
+	job.RunWindows[0].End = "25:00"
+	assert.ErrorContains(t, job.Validate(), "run_windows[0]: invalid end")
+}
+
+func TestJobRunWindowsProto(t *testing.T) {
+	job := &Job{
+		Name:            "batch_job",
+		Schedule:        "@every 1m",
+		RunWindows:      []*RunWindow{{Days: []string{"sat", "sun"}, Start: "00:00", End: "00:00"}},
+		RunWindowPolicy: RunWindowDefer,
+	}
+	pj := NewJobFromProto(job.ToProto(), getTestLogger())
+	assert.Equal(t, job.RunWindows, pj.RunWindows)
+	assert.Equal(t, job.RunWindowPolicy, pj.RunWindowPolicy)
+}
+
+func TestAgentDeferRun(t *testing.T) {
+	sched := NewScheduler(getTestLogger())
+	require.NoError(t, sched.Start(nil, &Agent{}))
+	defer sched.Stop()
+	a := &Agent{sched: sched}
+
+	ran := make(chan struct{}, 2)
+	run := func() { ran <- struct{}{} }
+
+	assert.True(t, a.deferRun("batch_job", 0, time.Now().Add(100*time.Millisecond), run))
+	// Only one run of a job is deferred at a time
+	assert.False(t, a.deferRun("batch_job", 0, time.Now().Add(50*time.Millisecond), run))
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("deferred run not called")
+	}
+
+	// Once it ran, new runs can be deferred again
+	assert.Eventually(t, func() bool {
+		return a.deferRun("batch_job", 0, time.Now().Add(time.Hour), run)
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, ran, 0)
+
+	// Retries are deferred apart from the scheduled runs
+	assert.True(t, a.deferRun("batch_job", 1, time.Now().Add(time.Hour), run))
+	assert.True(t, a.deferRun("batch_job", 2, time.Now().Add(time.Hour), run))
+	assert.False(t, a.deferRun("batch_job", 2, time.Now().Add(time.Hour), run))
+}
+
+func TestAgentCancelDeferredRuns(t *testing.T) {
+	sched := NewScheduler(getTestLogger())
+	require.NoError(t, sched.Start(nil, &Agent{}))
+	defer sched.Stop()
+	a := &Agent{sched: sched}
+
+	ran := make(chan string, 3)
+	deferRun := func(job string, group int64) {
+		require.True(t, a.deferRun(job, group, time.Now().Add(100*time.Millisecond), func() { ran <- job }))
+	}
+
+	// Updated or deleted jobs drop their deferred runs
+	deferRun("batch_job", 0)
+	deferRun("batch_job", 1)
+	deferRun("other_job", 0)
+	a.cancelDeferredRuns("batch_job")
+	assert.True(t, a.deferRun("batch_job", 0, time.Now().Add(time.Hour), func() { ran <- "batch_job" }))
+
+	select {
+	case job := <-ran:
+		assert.Equal(t, "other_job", job)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deferred run not called")
+	}
+
+	// Leaders stepping down drop all of them
+	deferRun("other_job", 0)
+	a.stopDeferredRuns()
+	time.Sleep(300 * time.Millisecond)
+	assert.Len(t, ran, 0)
+	assert.Empty(t, a.deferredRuns)
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 	"expvar"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-metrics"
 	"github.com/distribworks/dkron/v4/extcron"
@@ -124,21 +124,36 @@ func (s *Scheduler) Started() bool {
 
 // GetEntryJob returns a EntryJob object from a snapshot in
 // the current time, and whether or not the entry was found.
+// For jobs with several schedules it returns the entry that
+// fires first.
 func (s *Scheduler) GetEntryJob(jobName string) (EntryJob, bool) {
+	var found EntryJob
+	for _, ej := range s.entryJobs(jobName) {
+		if found.entry == nil || (!ej.entry.Next.IsZero() &&
+			(found.entry.Next.IsZero() || ej.entry.Next.Before(found.entry.Next))) {
+			found = ej
+		}
+	}
+	return found, found.entry != nil
+}
+
+// entryJobs returns the entries of every schedule of the job.
+func (s *Scheduler) entryJobs(jobName string) []EntryJob {
+	var ejs []EntryJob
 	for _, e := range s.Cron.Entries() {
 		if j, ok := e.Job.(*Job); !ok {
 			s.logger.Errorf("scheduler: Failed to cast job to *Job found type %T", e.Job)
 		} else {
 			j.logger = s.logger
 			if j.Name == jobName {
-				return EntryJob{
+				ejs = append(ejs, EntryJob{
 					entry: &e,
 					job:   j,
-				}, true
+				})
 			}
 		}
 	}
-	return EntryJob{}, false
+	return ejs
 }
 
 // AddJob Adds a job to the cron scheduler
@@ -148,7 +163,7 @@ func (s *Scheduler) AddJob(job *Job) error {
 		s.RemoveJob(job.Name)
 	}
 
-	if job.Disabled || job.ParentJob != "" {
+	if job.Disabled || len(job.parents()) > 0 {
 		return nil
 	}
 
@@ -156,24 +171,55 @@ func (s *Scheduler) AddJob(job *Job) error {
 		"job": job.Name,
 	}).Debug("scheduler: Adding job to cron")
 
-	// If Timezone is set on the job, and not explicitly in its schedule,
-	// AND its not a descriptor (that don't support timezones), add the
-	// timezone to the schedule so robfig/cron knows about it.
-	schedule := job.scheduleHash()
-	if job.Timezone != "" &&
-		!strings.HasPrefix(schedule, "@") &&
-		!strings.HasPrefix(schedule, "TZ=") &&
-		!strings.HasPrefix(schedule, "CRON_TZ=") {
-		schedule = "CRON_TZ=" + job.Timezone + " " + schedule
+	// One cron entry for every schedule of the job
+	jobs := job.scheduleJobs()
+	schedules := make([]cron.Schedule, len(jobs))
+	for i, j := range jobs {
+		schedule, err := j.cronSchedule()
+		if err != nil {
+			return err
+		}
+		schedules[i] = schedule
+	}
+	for i, j := range jobs {
+		s.Cron.Schedule(schedules[i], j)
 	}
 
-	_, err := s.Cron.AddJob(schedule, job)
+	cronInspect.Set(job.Name, job)
+	metrics.IncrCounterWithLabels([]string{"scheduler", "job_add"}, 1, []metrics.Label{{Name: "job", Value: job.Name}})
+
+	return nil
+}
+
+// rearmJob adds back a job with an @every-after schedule that already
+// fired and was not added again, counting its delay from the given time.
+func (s *Scheduler) rearmJob(job *Job, anchor time.Time) error {
+	if !s.Started() {
+		return nil
+	}
+	// Removed jobs stay removed and armed jobs don't need it
+	var ej EntryJob
+	for _, e := range s.entryJobs(job.Name) {
+		if e.job.extraSchedule == job.extraSchedule {
+			ej = e
+		}
+	}
+	if ej.entry == nil || !ej.entry.Next.IsZero() {
+		return nil
+	}
+
+	schedule, err := ej.job.cronSchedule()
 	if err != nil {
 		return err
 	}
+	ea, ok := schedule.(*extcron.EveryAfterSchedule)
+	if !ok {
+		return nil
+	}
+	ea.Anchor = anchor
 
-	cronInspect.Set(job.Name, job)
-	metrics.IncrCounterWithLabels([]string{"scheduler", "job_add"}, 1, []metrics.Label{{Name: "job", Value: job.Name}})
+	s.Cron.Remove(ej.entry.ID)
+	s.Cron.Schedule(ea, ej.job)
 
 	return nil
 }
@@ -184,8 +230,11 @@ func (s *Scheduler) RemoveJob(jobName string) {
 		"job": jobName,
 	}).Debug("scheduler: Removing job from cron")
 
-	if ej, ok := s.GetEntryJob(jobName); ok {
+	ejs := s.entryJobs(jobName)
+	for _, ej := range ejs {
 		s.Cron.Remove(ej.entry.ID)
+	}
+	if len(ejs) > 0 {
 		cronInspect.Delete(jobName)
 		metrics.IncrCounterWithLabels([]string{"scheduler", "job_delete"}, 1, []metrics.Label{{Name: "job", Value: jobName}})
 	}
@@ -117,3 +117,60 @@ func TestScheduleStop(t *testing.T) {
 	sched.Stop()
 	assert.False(t, sched.Started())
 }
+
+func TestScheduleEveryAfter(t *testing.T) {
+	log := getTestLogger()
+	sched := NewScheduler(log)
+
+	finished := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	testJob := &Job{
+		Name:           "loop_job",
+		Schedule:       "@every-after 1h",
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "echo 'test1'", "shell": "true"},
+	}
+	testJob.LastError.Set(finished)
+
+	err := sched.Start([]*Job{testJob}, &Agent{})
+	require.NoError(t, err)
+	defer sched.Stop()
+
+	ej, ok := sched.GetEntryJob(testJob.Name)
+	require.True(t, ok)
+	assert.True(t, finished.Add(time.Hour).Equal(ej.entry.Next), "got %s", ej.entry.Next)
+
+	// Jobs already armed are left alone
+	require.NoError(t, sched.rearmJob(testJob, time.Now()))
+	ej, _ = sched.GetEntryJob(testJob.Name)
+	assert.True(t, finished.Add(time.Hour).Equal(ej.entry.Next), "got %s", ej.entry.Next)
+}
+
+func TestScheduleMultipleSchedules(t *testing.T) {
+	log := getTestLogger()
+	sched := NewScheduler(log)
+
+	testJob := &Job{
+		Name:     "multi_job",
+		Schedule: "@every 1h",
+		Schedules: []*JobSchedule{
+			{Schedule: "@every 2s"},
+		},
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "echo 'test1'", "shell": "true"},
+	}
+
+	err := sched.Start([]*Job{testJob}, &Agent{})
+	require.NoError(t, err)
+	defer sched.Stop()
+
+	assert.Len(t, sched.Cron.Entries(), 2)
+
+	// The entry firing first is the job entry
+	ej, ok := sched.GetEntryJob(testJob.Name)
+	require.True(t, ok)
+	assert.Equal(t, 1, ej.job.extraSchedule)
+	assert.Equal(t, "@every 2s", ej.job.Schedule)
+
+	sched.RemoveJob(testJob.Name)
+	assert.Len(t, sched.Cron.Entries(), 0)
+}
@@ -0,0 +1,144 @@
+package dkron
+
+import (
+	"strconv"
+	"time"
+
+	proto "github.com/distribworks/dkron/v4/gen/proto/types/v1"
+	"github.com/robfig/cron/v3"
+)
+
+// JobSchedule is an additional schedule of a job. Its runs use the job
+// definition with the schedule timezone and executor config overrides.
+type JobSchedule struct {
+	// Cron expression or descriptor, in the job schedule format.
+	Schedule string `json:"schedule"`
+
+	// Timezone of the schedule, the job timezone if empty.
+	Timezone string `json:"timezone"`
+
+	// Executor config values overriding the job ones.
+	ExecutorConfig map[string]string `json:"executor_config"`
+}
+
+func newJobSchedulesFromProto(in []*proto.JobSchedule) []*JobSchedule {
+	if len(in) == 0 {
+		return nil
+	}
+	schedules := make([]*JobSchedule, len(in))
+	for i, s := range in {
+		schedules[i] = &JobSchedule{
+			Schedule:       s.Schedule,
+			Timezone:       s.Timezone,
+			ExecutorConfig: s.ExecutorConfig,
+		}
+	}
+	return schedules
+}
+
+func jobSchedulesToProto(in []*JobSchedule) []*proto.JobSchedule {
+	if len(in) == 0 {
+		return nil
+	}
+	schedules := make([]*proto.JobSchedule, len(in))
+	for i, s := range in {
+		schedules[i] = &proto.JobSchedule{
+			Schedule:       s.Schedule,
+			Timezone:       s.Timezone,
+			ExecutorConfig: s.ExecutorConfig,
+		}
+	}
+	return schedules
+}
+
+// hasSchedule returns whether the job has a main or additional schedule.
+func (j *Job) hasSchedule() bool {
+	return j.Schedule != "" || len(j.Schedules) > 0
+}
+
+// withSchedule returns a copy of the job running its additional schedule i,
+// or the job itself if there is no such schedule.
+func (j *Job) withSchedule(i int) *Job {
+	if i < 0 || i >= len(j.Schedules) || j.Schedules[i] == nil {
+		return j
+	}
+	s := j.Schedules[i]
+
+	job := *j
+	job.Schedule = s.Schedule
+	if s.Timezone != "" {
+		job.Timezone = s.Timezone
+	}
+	if len(s.ExecutorConfig) > 0 {
+		job.ExecutorConfig = make(map[string]string, len(j.ExecutorConfig)+len(s.ExecutorConfig))
+		for k, v := range j.ExecutorConfig {
+			job.ExecutorConfig[k] = v
+		}
+		for k, v := range s.ExecutorConfig {
+			job.ExecutorConfig[k] = v
+		}
+	}
+	job.Schedules = nil
+	job.extraSchedule = i + 1
+	return &job
+}
+
+// scheduleJobs returns a copy of the job for each of its schedules,
+// or the job itself if it only has the main one.
+func (j *Job) scheduleJobs() []*Job {
+	if len(j.Schedules) == 0 {
+		return []*Job{j}
+	}
+
+	var jobs []*Job
+	if j.Schedule != "" {
+		job := *j
+		job.Schedules = nil
+		jobs = append(jobs, &job)
+	}
+	for i := range j.Schedules {
+		jobs = append(jobs, j.withSchedule(i))
+	}
+	return jobs
+}
+
+// scheduleJobAt returns the copy of the job whose schedule fires at t,
+// the first one if none does.
+func (j *Job) scheduleJobAt(t time.Time) *Job {
+	jobs := j.scheduleJobs()
+	for _, job := range jobs {
+		s, err := job.schedule(nil)
+		if err == nil && s.Next(t.Add(-time.Nanosecond)).Equal(t) {
+			return job
+		}
+	}
+	return jobs[0]
+}
+
+// setScheduleMetadata records in the execution the additional
+// schedule of the job it runs for, if any.
+func (j *Job) setScheduleMetadata(ex *Execution) {
+	if j.extraSchedule == 0 {
+		return
+	}
+	if ex.Metadata == nil {
+		ex.Metadata = make(map[string]string)
+	}
+	ex.Metadata["schedule"] = j.Schedule
+	ex.Metadata["schedule_index"] = strconv.Itoa(j.extraSchedule - 1)
+}
+
+// multiSchedule fires at the fire times of all of its schedules.
+type multiSchedule []cron.Schedule
+
+// Next conforms to the Schedule interface, it returns the
+// earliest next fire time of the schedules.
+func (s multiSchedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, schedule := range s {
+		if n := schedule.Next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
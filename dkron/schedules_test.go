@@ -0,0 +1,111 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/distribworks/dkron/v4/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobWithSchedule(t *testing.T) {
+	job := &Job{
+		Name:           "test_job",
+		Schedule:       "0 0 9 * * 1-5",
+		Timezone:       "Europe/Madrid",
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "report", "shell": "true"},
+		Schedules: []*JobSchedule{
+			{
+				Schedule:       "0 0 12 * * 0,6",
+				Timezone:       "UTC",
+				ExecutorConfig: map[string]string{"command": "report --weekend"},
+			},
+		},
+	}
+	require.NoError(t, job.Validate())
+
+	view := job.withSchedule(0)
+	assert.Equal(t, "0 0 12 * * 0,6", view.Schedule)
+	assert.Equal(t, "UTC", view.Timezone)
+	assert.Equal(t, plugin.ExecutorPluginConfig{"command": "report --weekend", "shell": "true"}, view.ExecutorConfig)
+	assert.Nil(t, view.Schedules)
+
+	// The job is left untouched
+	assert.Equal(t, "report", job.ExecutorConfig["command"])
+	assert.Same(t, job, job.withSchedule(1))
+
+	jobs := job.scheduleJobs()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, 0, jobs[0].extraSchedule)
+	assert.Equal(t, 1, jobs[1].extraSchedule)
+
+	ex := NewExecution(job.Name)
+	job.scheduleJobAt(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)).setScheduleMetadata(ex)
+	assert.Equal(t, map[string]string{"schedule": "0 0 12 * * 0,6", "schedule_index": "0"}, ex.Metadata)
+
+	ex = NewExecution(job.Name)
+	job.scheduleJobAt(time.Date(2024, 6, 3, 7, 0, 0, 0, time.UTC)).setScheduleMetadata(ex)
+	assert.Nil(t, ex.Metadata)
+}
+
+func TestJobMultipleSchedulesNext(t *testing.T) {
+	job := &Job{
+		Name:     "test_job",
+		Schedule: "0 0 9 * * 1-5",
+		Timezone: "Europe/Madrid",
+		Schedules: []*JobSchedule{
+			{Schedule: "0 0 12 * * 0,6", Timezone: "UTC"},
+		},
+	}
+
+	s, err := job.schedule(nil)
+	require.NoError(t, err)
+
+	// Friday at 9:00 in Madrid, then the weekend at 12:00 UTC
+	next := s.Next(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 5, 31, 7, 0, 0, 0, time.UTC), next.UTC())
+	next = s.Next(next)
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), next.UTC())
+	next = s.Next(next)
+	assert.Equal(t, time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC), next.UTC())
+	next = s.Next(next)
+	assert.Equal(t, time.Date(2024, 6, 3, 7, 0, 0, 0, time.UTC), next.UTC())
+
+	// Jobs with only additional schedules
+	job.Schedule = ""
+	require.NoError(t, job.Validate())
+	next, err = job.GetNext(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 12, next.UTC().Hour())
+}
+
+func TestJobValidateSchedules(t *testing.T) {
+	job := &Job{
+		Name:     "test_job",
+		Schedule: "@every 1m",
+		Schedules: []*JobSchedule{
+			{Schedule: "@every 1h"},
+			{Schedule: "bad"},
+		},
+	}
+	assert.ErrorContains(t, job.Validate(), "schedules[1]")
+
+	job.Schedules[1] = &JobSchedule{Schedule: "@every 1h", Timezone: "Mars/Olympus"}
+	assert.ErrorContains(t, job.Validate(), "schedules[1]")
+
+	job.Schedules[1].Timezone = "America/New_York"
+	assert.NoError(t, job.Validate())
+}
+
+func TestJobSchedulesProto(t *testing.T) {
+	job := &Job{
+		Name:     "test_job",
+		Schedule: "@every 1m",
+		Schedules: []*JobSchedule{
+			{Schedule: "@every 1h", Timezone: "UTC", ExecutorConfig: map[string]string{"command": "ls"}},
+		},
+	}
+	assert.Equal(t, job.Schedules, NewJobFromProto(job.ToProto(), getTestLogger()).Schedules)
+}
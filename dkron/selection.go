@@ -0,0 +1,135 @@
+package dkron
+
+import (
+	"hash/fnv"
+	"math/rand"
+)
+
+// DefaultSpreadTag is the node tag the spread selection strategy balances
+// the target nodes across when the job doesn't set one.
+const DefaultSpreadTag = "zone"
+
+// spreadTag returns the node tag the job target nodes are spread across.
+func (j *Job) spreadTag() string {
+	if j.SpreadTag == "" {
+		return DefaultSpreadTag
+	}
+	return j.SpreadTag
+}
+
+// nodeSelector returns the function picking the target nodes of the job
+// with its selection strategy, for getTargetNodes/selectNodes.
+func (a *Agent) nodeSelector(job *Job) func([]Node) int {
+	switch job.SelectionStrategy {
+	case SelectionRoundRobin:
+		return a.roundRobinSelector(job.Name)
+	case SelectionLeastLoaded:
+		return leastLoadedSelector(a.nodeLoads())
+	case SelectionConsistentHash:
+		return consistentHashSelector(job.Name)
+	case SelectionSpread:
+		return spreadSelector(job.spreadTag())
+	default:
+		return defaultSelector
+	}
+}
+
+// roundRobinSelector picks the node following, by name, the last node
+// picked for the job, wrapping around.
+func (a *Agent) roundRobinSelector(jobName string) func([]Node) int {
+	return func(nodes []Node) int {
+		a.selectionMu.Lock()
+		defer a.selectionMu.Unlock()
+
+		last := a.roundRobin[jobName]
+		first, next := 0, -1
+		for i, n := range nodes {
+			if n.Name < nodes[first].Name {
+				first = i
+			}
+			if n.Name > last && (next < 0 || n.Name < nodes[next].Name) {
+				next = i
+			}
+		}
+		if next < 0 {
+			next = first
+		}
+
+		if a.roundRobin == nil {
+			a.roundRobin = make(map[string]string)
+		}
+		a.roundRobin[jobName] = nodes[next].Name
+		return next
+	}
+}
+
+// nodeLoads returns the number of executions running in each node.
+func (a *Agent) nodeLoads() map[string]int {
+	loads := make(map[string]int)
+	exs, err := a.GetActiveExecutions()
+	if err != nil {
+		a.logger.WithError(err).Error("agent: Error retrieving active executions")
+		return loads
+	}
+	for _, ex := range exs {
+		loads[ex.NodeName]++
+	}
+	return loads
+}
+
+// leastLoadedSelector picks the node running the fewest executions, at
+// random between equally loaded nodes. Picked nodes count as running one
+// more execution.
+func leastLoadedSelector(loads map[string]int) func([]Node) int {
+	return func(nodes []Node) int {
+		var candidates []int
+		for i, n := range nodes {
+			if len(candidates) == 0 || loads[n.Name] < loads[nodes[candidates[0]].Name] {
+				candidates = []int{i}
+			} else if loads[n.Name] == loads[nodes[candidates[0]].Name] {
+				candidates = append(candidates, i)
+			}
+		}
+		chosen := candidates[rand.Intn(len(candidates))]
+		loads[nodes[chosen].Name]++
+		return chosen
+	}
+}
+
+// consistentHashSelector picks the node with the highest hash of the job
+// and node names, so a job keeps running in the same nodes while they are
+// available, and only the jobs of a node that goes away move.
+func consistentHashSelector(jobName string) func([]Node) int {
+	return func(nodes []Node) int {
+		chosen := 0
+		var max uint64
+		for i, n := range nodes {
+			h := fnv.New64a()
+			_, _ = h.Write([]byte(jobName + "/" + n.Name))
+			if sum := h.Sum64(); i == 0 || sum > max {
+				chosen, max = i, sum
+			}
+		}
+		return chosen
+	}
+}
+
+// spreadSelector picks a node with the value of the given tag picked the
+// fewest times, at random between them.
+func spreadSelector(tag string) func([]Node) int {
+	picked := make(map[string]int)
+	return func(nodes []Node) int {
+		var candidates []int
+		for i, n := range nodes {
+			v := n.Tags[tag]
+			if len(candidates) == 0 || picked[v] < picked[nodes[candidates[0]].Tags[tag]] {
+				candidates = []int{i}
+			} else if picked[v] == picked[nodes[candidates[0]].Tags[tag]] {
+				candidates = append(candidates, i)
+			}
+		}
+		chosen := candidates[rand.Intn(len(candidates))]
+		picked[nodes[chosen].Tags[tag]]++
+		return chosen
+	}
+}
@@ -0,0 +1,121 @@
+package dkron
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nodeNames(nodes []Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		names = append(names, n.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func selectionNodes() []Node {
+	return []Node{
+		{Name: "c", Tags: map[string]string{"zone": "eu-1b"}},
+		{Name: "a", Tags: map[string]string{"zone": "eu-1a"}},
+		{Name: "d", Tags: map[string]string{"zone": "eu-1c"}},
+		{Name: "b", Tags: map[string]string{"zone": "eu-1a"}},
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	a := &Agent{}
+
+	var picked []string
+	for i := 0; i < 5; i++ {
+		picked = append(picked, nodeNames(selectNodes(selectionNodes(), 1, a.roundRobinSelector("job")))...)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "a"}, picked)
+
+	// Jobs take turns independently
+	assert.Equal(t, []string{"a", "b"}, nodeNames(selectNodes(selectionNodes(), 2, a.roundRobinSelector("other"))))
+	assert.Equal(t, []string{"c", "d"}, nodeNames(selectNodes(selectionNodes(), 2, a.roundRobinSelector("other"))))
+	assert.Equal(t, []string{"a", "b"}, nodeNames(selectNodes(selectionNodes(), 2, a.roundRobinSelector("other"))))
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	loads := map[string]int{"a": 3, "b": 1, "c": 2, "d": 1}
+	for i := 0; i < 10; i++ {
+		l := make(map[string]int)
+		for k, v := range loads {
+			l[k] = v
+		}
+		assert.Equal(t, []string{"b", "d"}, nodeNames(selectNodes(selectionNodes(), 2, leastLoadedSelector(l))))
+	}
+
+	// Picked nodes count as loaded
+	assert.Equal(t, []string{"b", "c", "d"}, nodeNames(selectNodes(selectionNodes(), 3, leastLoadedSelector(loads))))
+}
+
+func TestConsistentHashSelector(t *testing.T) {
+	chosen := nodeNames(selectNodes(selectionNodes(), 1, consistentHashSelector("backup")))
+	assert.Len(t, chosen, 1)
+
+	// The same node is picked whatever the order of the nodes
+	for i := 0; i < 10; i++ {
+		nodes := selectionNodes()
+		nodes[0], nodes[i%4] = nodes[i%4], nodes[0]
+		assert.Equal(t, chosen, nodeNames(selectNodes(nodes, 1, consistentHashSelector("backup"))))
+	}
+
+	// and while other nodes leave
+	var nodes []Node
+	for _, n := range selectionNodes() {
+		if n.Name == chosen[0] || len(nodes) == 0 {
+			nodes = append(nodes, n)
+		}
+	}
+	assert.Equal(t, chosen, nodeNames(selectNodes(nodes, 1, consistentHashSelector("backup"))))
+}
+
+func TestSpreadSelector(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		selected := selectNodes(selectionNodes(), 3, spreadSelector("zone"))
+		zones := make(map[string]bool)
+		for _, n := range selected {
+			zones[n.Tags["zone"]] = true
+		}
+		assert.Len(t, zones, 3)
+	}
+}
+
+func TestNodeSelector(t *testing.T) {
+	a := &Agent{}
+	job := &Job{Name: "report"}
+
+	// Retries pick the node with the strategy
+	job.SelectionStrategy = SelectionConsistentHash
+	assert.Equal(t,
+		nodeNames(selectNodes(selectionNodes(), 1, consistentHashSelector("report"))),
+		nodeNames(selectNodes(selectionNodes(), 1, a.nodeSelector(job))))
+
+	job.SelectionStrategy = SelectionRoundRobin
+	assert.Equal(t, []string{"a"}, nodeNames(selectNodes(selectionNodes(), 1, a.nodeSelector(job))))
+
+	assert.Equal(t, DefaultSpreadTag, job.spreadTag())
+	job.SpreadTag = "rack"
+	assert.Equal(t, "rack", job.spreadTag())
+}
+
+func TestJobValidateSelectionStrategy(t *testing.T) {
+	job := &Job{Name: "report", Schedule: "@every 1m", SelectionStrategy: "fastest"}
+	assert.Equal(t, ErrWrongSelectionStrategy, job.Validate())
+
+	for _, s := range []string{"", SelectionRandom, SelectionRoundRobin, SelectionLeastLoaded, SelectionConsistentHash, SelectionSpread} {
+		job.SelectionStrategy = s
+		assert.NoError(t, job.Validate())
+	}
+
+	job.SelectionStrategy = SelectionSpread
+	job.SpreadTag = "rack"
+	pj := NewJobFromProto(job.ToProto(), getTestLogger())
+	assert.Equal(t, job.SelectionStrategy, pj.SelectionStrategy)
+	assert.Equal(t, job.SpreadTag, pj.SpreadTag)
+}
@@ -18,6 +18,18 @@ type Storage interface {
 	SetExecutionDone(ctx context.Context, execution *Execution) (bool, error)
 	GetJobs(ctx context.Context, options *JobOptions) ([]*Job, error)
 	GetJob(ctx context.Context, name string, options *JobOptions) (*Job, error)
+	SetCalendar(ctx context.Context, calendar *Calendar) error
+	DeleteCalendar(ctx context.Context, name string) (*Calendar, error)
+	GetCalendars(ctx context.Context) ([]*Calendar, error)
+	GetCalendar(ctx context.Context, name string) (*Calendar, error)
+	SetMaintenanceWindow(ctx context.Context, mw *MaintenanceWindow) error
+	DeleteMaintenanceWindow(ctx context.Context, name string) (*MaintenanceWindow, error)
+	GetMaintenanceWindows(ctx context.Context) ([]*MaintenanceWindow, error)
+	GetMaintenanceWindow(ctx context.Context, name string) (*MaintenanceWindow, error)
+	SetPool(ctx context.Context, pool *Pool) error
+	DeletePool(ctx context.Context, name string) (*Pool, error)
+	GetPools(ctx context.Context) ([]*Pool, error)
+	GetPool(ctx context.Context, name string) (*Pool, error)
 	GetExecution(ctx context.Context, jobName string, executionName string) (*Execution, error)
 	GetExecutions(ctx context.Context, jobName string, opts *ExecutionOptions) ([]*Execution, error)
 	GetRunningExecutions(ctx context.Context, jobName string) ([]*Execution, error)
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,9 +27,12 @@ const (
 	// MaxExecutions to maintain in the storage
 	MaxExecutions = 100
 
-	jobsPrefix       = "jobs"
-	executionsPrefix = "executions"
-	statsPrefix      = "stats"
+	jobsPrefix        = "jobs"
+	executionsPrefix  = "executions"
+	statsPrefix       = "stats"
+	calendarsPrefix   = "calendars"
+	maintenancePrefix = "maintenance"
+	poolsPrefix       = "pools"
 )
 
 var (
@@ -132,11 +137,36 @@ func (s *Store) SetJob(ctx context.Context, job *Job, copyDependentJobs bool) er
 	}
 
 	// Abort if parent not found before committing job to the store
-	if job.ParentJob != "" {
-		if j, _ := s.GetJob(ctx, job.ParentJob, nil); j == nil {
+	for _, p := range job.parents() {
+		if j, _ := s.GetJob(ctx, p, nil); j == nil {
 			return ErrParentJobNotFound
 		}
 	}
+	if err := s.checkDependencyCycle(ctx, job); err != nil {
+		return err
+	}
+
+	// Same for the calendars, they are needed to compute the next execution
+	calendars := make([]*Calendar, 0, len(job.Calendars))
+	for _, name := range job.Calendars {
+		c, err := s.GetCalendar(ctx, name)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return ErrCalendarNotFound
+			}
+			return err
+		}
+		calendars = append(calendars, c)
+	}
+
+	if job.Pool != "" {
+		if _, err := s.GetPool(ctx, job.Pool); err != nil {
+			if err == buntdb.ErrNotFound {
+				return ErrPoolNotFound
+			}
+			return err
+		}
+	}
 
 	err := s.db.Update(func(tx *buntdb.Tx) error {
 		// Get if the requested job already exist
@@ -164,14 +194,17 @@ func (s *Store) SetJob(ctx context.Context, job *Job, copyDependentJobs bool) er
 			}
 			if len(ej.DependentJobs) != 0 && copyDependentJobs {
 				job.DependentJobs = ej.DependentJobs
+				job.DependentConditions = ej.DependentConditions
 			}
 			if ej.Status != "" {
 				job.Status = ej.Status
 			}
 		}
 
-		if job.Schedule != ej.Schedule {
-			job.Next, err = job.GetNext()
+		if job.Schedule != ej.Schedule || job.ScheduleFormat != ej.ScheduleFormat ||
+			job.DSTPolicy != ej.DSTPolicy || !slices.Equal(job.Calendars, ej.Calendars) ||
+			!reflect.DeepEqual(job.Schedules, ej.Schedules) {
+			job.Next, err = job.GetNext(calendars)
 			if err != nil {
 				return err
 			}
@@ -193,32 +226,64 @@ func (s *Store) SetJob(ctx context.Context, job *Job, copyDependentJobs bool) er
 		return err
 	}
 
-	// If the parent job changed update the parents of the old (if any) and new jobs
-	if job.ParentJob != ej.ParentJob {
-		if err := s.removeFromParent(ctx, ej); err != nil {
-			return err
+	// If the parent jobs changed update the old (if any) and new parents
+	oldParents, newParents := ej.parents(), job.parents()
+	for _, p := range oldParents {
+		if !slices.Contains(newParents, p) {
+			if err := s.removeFromParent(ctx, ej.Name, p); err != nil {
+				return err
+			}
 		}
-		if err := s.addToParent(ctx, job); err != nil {
-			return err
+	}
+	for _, p := range newParents {
+		if !slices.Contains(oldParents, p) || job.parentCondition(p) != ej.parentCondition(p) {
+			if err := s.addToParent(ctx, job.Name, p, job.parentCondition(p)); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-// Removes the given job from its parent.
-// Does nothing if nil is passed as child.
-func (s *Store) removeFromParent(ctx context.Context, child *Job) error {
-	ctx, span := s.tracer.Start(ctx, "buntdb.remove_from_parent")
-	defer span.End()
+// checkDependencyCycle returns an error if the job is an ancestor of its
+// parent jobs.
+func (s *Store) checkDependencyCycle(ctx context.Context, job *Job) error {
+	visited := make(map[string]bool)
+	pending := job.parents()
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if name == job.Name {
+			return ErrDependencyCycle
+		}
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
 
-	// Do nothing if no job was given or job has no parent
-	if child == nil || child.ParentJob == "" {
-		return nil
+		j, err := s.GetJob(ctx, name, nil)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				continue
+			}
+			return err
+		}
+		pending = append(pending, j.parents()...)
 	}
+	return nil
+}
 
-	parent, err := child.GetParent(ctx, s)
+// Removes the given job from one of its parents.
+func (s *Store) removeFromParent(ctx context.Context, child string, parentName string) error {
+	ctx, span := s.tracer.Start(ctx, "buntdb.remove_from_parent")
+	defer span.End()
+
+	parent, err := s.GetJob(ctx, parentName, nil)
 	if err != nil {
+		if err == buntdb.ErrNotFound {
+			return ErrParentJobNotFound
+		}
 		return err
 	}
 
@@ -226,11 +291,12 @@ func (s *Store) removeFromParent(ctx context.Context, child *Job) error {
 	// Due to an old bug (in v1), a parent can have the same child more than once.
 	djs := []string{}
 	for _, djn := range parent.DependentJobs {
-		if djn != child.Name {
+		if djn != child {
 			djs = append(djs, djn)
 		}
 	}
 	parent.DependentJobs = djs
+	delete(parent.DependentConditions, child)
 	if err := s.SetJob(ctx, parent, false); err != nil {
 		return err
 	}
@@ -238,22 +304,30 @@ func (s *Store) removeFromParent(ctx context.Context, child *Job) error {
 	return nil
 }
 
-// Adds the given job to its parent.
-func (s *Store) addToParent(ctx context.Context, child *Job) error {
+// Adds the given job to one of its parents, or updates its condition.
+func (s *Store) addToParent(ctx context.Context, child string, parentName string, condition string) error {
 	ctx, span := s.tracer.Start(ctx, "buntdb.add_to_parent")
 	defer span.End()
 
-	// Do nothing if job has no parent
-	if child.ParentJob == "" {
-		return nil
+	if err := validateParentCondition(condition); err != nil {
+		return err
 	}
 
-	parent, err := child.GetParent(ctx, s)
+	parent, err := s.GetJob(ctx, parentName, nil)
 	if err != nil {
+		if err == buntdb.ErrNotFound {
+			return ErrParentJobNotFound
+		}
 		return err
 	}
 
-	parent.DependentJobs = append(parent.DependentJobs, child.Name)
+	if !slices.Contains(parent.DependentJobs, child) {
+		parent.DependentJobs = append(parent.DependentJobs, child)
+	}
+	if parent.DependentConditions == nil {
+		parent.DependentConditions = make(map[string]string)
+	}
+	parent.DependentConditions[child] = condition
 	if err := s.SetJob(ctx, parent, false); err != nil {
 		return err
 	}
@@ -333,7 +407,13 @@ func (s *Store) SetExecutionDone(ctx context.Context, execution *Execution) (boo
 			JobExecutionsFailedTotal.WithLabelValues(execution.JobName).Inc()
 		}
 
-		status, err := s.computeStatus(pbj.Name, pbe.Group, tx)
+		// Map jobs use their success rule over the items of the run
+		var status string
+		if pbj.Map {
+			status, err = s.computeMapStatus(pbj.Name, pbe.Group, pbj.MapSuccessRule, tx)
+		} else {
+			status, err = s.computeStatus(pbj.Name, pbe.Group, tx)
+		}
 		if err != nil {
 			return err
 		}
@@ -495,9 +575,9 @@ func (s *Store) DeleteJob(ctx context.Context, name string) (*Job, error) {
 		return nil, err
 	}
 
-	// If the transaction succeeded, remove from parent
-	if job.ParentJob != "" {
-		if err := s.removeFromParent(ctx, job); err != nil {
+	// If the transaction succeeded, remove from parents
+	for _, p := range job.parents() {
+		if err := s.removeFromParent(ctx, job.Name, p); err != nil {
 			return nil, err
 		}
 	}
@@ -505,6 +585,367 @@ func (s *Store) DeleteJob(ctx context.Context, name string) (*Job, error) {
 	return job, nil
 }
 
+// SetCalendar stores a calendar and updates the next execution
+// of the jobs that use it.
+func (s *Store) SetCalendar(ctx context.Context, calendar *Calendar) error {
+	_, span := s.tracer.Start(ctx, "buntdb.set.calendar", trace.WithAttributes(attribute.String("calendar_name", calendar.Name)))
+	defer span.End()
+
+	if err := calendar.Validate(); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		cb, err := json.Marshal(calendar.ToProto())
+		if err != nil {
+			return err
+		}
+		s.logger.WithField("calendar", calendar.Name).Debug("store: Setting calendar")
+
+		if _, _, err := tx.Set(fmt.Sprintf("%s:%s", calendarsPrefix, calendar.Name), string(cb), nil); err != nil {
+			return err
+		}
+
+		jobs, err := s.calendarJobsTxFunc(calendar.Name, tx)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			var calendars []*Calendar
+			for _, name := range job.Calendars {
+				var pbc dkronpb.Calendar
+				if err := s.getCalendarTxFunc(name, &pbc)(tx); err != nil {
+					if err == buntdb.ErrNotFound {
+						continue
+					}
+					return err
+				}
+				calendars = append(calendars, NewCalendarFromProto(&pbc))
+			}
+
+			if job.Next, err = job.GetNext(calendars); err != nil {
+				return err
+			}
+			if err := s.setJobTxFunc(job.ToProto())(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetCalendars returns all the calendars in the store
+func (s *Store) GetCalendars(ctx context.Context) ([]*Calendar, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.get.calendars")
+	defer span.End()
+
+	calendars := make([]*Calendar, 0)
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		ascendErr := tx.AscendKeys(calendarsPrefix+":*", func(key, item string) bool {
+			var pbc dkronpb.Calendar
+			if err = json.Unmarshal([]byte(item), &pbc); err != nil {
+				return false
+			}
+			calendars = append(calendars, NewCalendarFromProto(&pbc))
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return ascendErr
+	})
+
+	return calendars, err
+}
+
+// GetCalendar finds and return a Calendar from the store
+func (s *Store) GetCalendar(ctx context.Context, name string) (*Calendar, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.get.calendar", trace.WithAttributes(attribute.String("calendar_name", name)))
+	defer span.End()
+
+	var pbc dkronpb.Calendar
+	if err := s.db.View(s.getCalendarTxFunc(name, &pbc)); err != nil {
+		return nil, err
+	}
+
+	return NewCalendarFromProto(&pbc), nil
+}
+
+func (s *Store) getCalendarTxFunc(name string, pbc *dkronpb.Calendar) func(tx *buntdb.Tx) error {
+	return func(tx *buntdb.Tx) error {
+		item, err := tx.Get(fmt.Sprintf("%s:%s", calendarsPrefix, name))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(item), pbc)
+	}
+}
+
+// calendarJobsTxFunc returns the jobs that use the given calendar.
+func (s *Store) calendarJobsTxFunc(name string, tx *buntdb.Tx) ([]*Job, error) {
+	var (
+		jobs []*Job
+		err  error
+	)
+	ascendErr := tx.AscendKeys(jobsPrefix+":*", func(key, item string) bool {
+		var pbj dkronpb.Job
+		if proto.Unmarshal([]byte(item), &pbj) != nil {
+			if err = json.Unmarshal([]byte(item), &pbj); err != nil {
+				return false
+			}
+		}
+		if slices.Contains(pbj.Calendars, name) {
+			jobs = append(jobs, NewJobFromProto(&pbj, s.logger))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, ascendErr
+}
+
+// DeleteCalendar deletes the given calendar from the store,
+// it fails if any job still uses it.
+func (s *Store) DeleteCalendar(ctx context.Context, name string) (*Calendar, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.delete.calendar", trace.WithAttributes(attribute.String("calendar_name", name)))
+	defer span.End()
+
+	var calendar *Calendar
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		var pbc dkronpb.Calendar
+		if err := s.getCalendarTxFunc(name, &pbc)(tx); err != nil {
+			return err
+		}
+
+		jobs, err := s.calendarJobsTxFunc(name, tx)
+		if err != nil {
+			return err
+		}
+		if len(jobs) > 0 {
+			return ErrCalendarInUse
+		}
+		calendar = NewCalendarFromProto(&pbc)
+
+		_, err = tx.Delete(fmt.Sprintf("%s:%s", calendarsPrefix, name))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return calendar, nil
+}
+
+// SetMaintenanceWindow stores a maintenance window
+func (s *Store) SetMaintenanceWindow(ctx context.Context, mw *MaintenanceWindow) error {
+	_, span := s.tracer.Start(ctx, "buntdb.set.maintenance", trace.WithAttributes(attribute.String("maintenance_name", mw.Name)))
+	defer span.End()
+
+	if err := mw.Validate(); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		mb, err := json.Marshal(mw.ToProto())
+		if err != nil {
+			return err
+		}
+		s.logger.WithField("maintenance", mw.Name).Debug("store: Setting maintenance window")
+
+		_, _, err = tx.Set(fmt.Sprintf("%s:%s", maintenancePrefix, mw.Name), string(mb), nil)
+		return err
+	})
+}
+
+// GetMaintenanceWindows returns all the maintenance windows in the store
+func (s *Store) GetMaintenanceWindows(ctx context.Context) ([]*MaintenanceWindow, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.get.maintenance_windows")
+	defer span.End()
+
+	windows := make([]*MaintenanceWindow, 0)
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		ascendErr := tx.AscendKeys(maintenancePrefix+":*", func(key, item string) bool {
+			var pmw dkronpb.MaintenanceWindow
+			if err = json.Unmarshal([]byte(item), &pmw); err != nil {
+				return false
+			}
+			windows = append(windows, NewMaintenanceWindowFromProto(&pmw))
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return ascendErr
+	})
+
+	return windows, err
+}
+
+// GetMaintenanceWindow finds and return a MaintenanceWindow from the store
+func (s *Store) GetMaintenanceWindow(ctx context.Context, name string) (*MaintenanceWindow, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.get.maintenance", trace.WithAttributes(attribute.String("maintenance_name", name)))
+	defer span.End()
+
+	var pmw dkronpb.MaintenanceWindow
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		item, err := tx.Get(fmt.Sprintf("%s:%s", maintenancePrefix, name))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(item), &pmw)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMaintenanceWindowFromProto(&pmw), nil
+}
+
+// DeleteMaintenanceWindow deletes the given maintenance window from the store
+func (s *Store) DeleteMaintenanceWindow(ctx context.Context, name string) (*MaintenanceWindow, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.delete.maintenance", trace.WithAttributes(attribute.String("maintenance_name", name)))
+	defer span.End()
+
+	var mw *MaintenanceWindow
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		item, err := tx.Delete(fmt.Sprintf("%s:%s", maintenancePrefix, name))
+		if err != nil {
+			return err
+		}
+
+		var pmw dkronpb.MaintenanceWindow
+		if err := json.Unmarshal([]byte(item), &pmw); err != nil {
+			return err
+		}
+		mw = NewMaintenanceWindowFromProto(&pmw)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mw, nil
+}
+
+// SetPool stores a resource pool
+func (s *Store) SetPool(ctx context.Context, pool *Pool) error {
+	_, span := s.tracer.Start(ctx, "buntdb.set.pool", trace.WithAttributes(attribute.String("pool_name", pool.Name)))
+	defer span.End()
+
+	if err := pool.Validate(); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		pb, err := json.Marshal(pool.ToProto())
+		if err != nil {
+			return err
+		}
+		s.logger.WithField("pool", pool.Name).Debug("store: Setting pool")
+
+		_, _, err = tx.Set(fmt.Sprintf("%s:%s", poolsPrefix, pool.Name), string(pb), nil)
+		return err
+	})
+}
+
+// GetPools returns all the resource pools in the store
+func (s *Store) GetPools(ctx context.Context) ([]*Pool, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.get.pools")
+	defer span.End()
+
+	pools := make([]*Pool, 0)
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		ascendErr := tx.AscendKeys(poolsPrefix+":*", func(key, item string) bool {
+			var pbp dkronpb.Pool
+			if err = json.Unmarshal([]byte(item), &pbp); err != nil {
+				return false
+			}
+			pools = append(pools, NewPoolFromProto(&pbp))
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return ascendErr
+	})
+
+	return pools, err
+}
+
+// GetPool finds and return a resource Pool from the store
+func (s *Store) GetPool(ctx context.Context, name string) (*Pool, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.get.pool", trace.WithAttributes(attribute.String("pool_name", name)))
+	defer span.End()
+
+	var pbp dkronpb.Pool
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		item, err := tx.Get(fmt.Sprintf("%s:%s", poolsPrefix, name))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(item), &pbp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPoolFromProto(&pbp), nil
+}
+
+// DeletePool deletes the given resource pool from the store,
+// it fails if any job still uses it.
+func (s *Store) DeletePool(ctx context.Context, name string) (*Pool, error) {
+	_, span := s.tracer.Start(ctx, "buntdb.delete.pool", trace.WithAttributes(attribute.String("pool_name", name)))
+	defer span.End()
+
+	var pool *Pool
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		item, err := tx.Get(fmt.Sprintf("%s:%s", poolsPrefix, name))
+		if err != nil {
+			return err
+		}
+		var pbp dkronpb.Pool
+		if err := json.Unmarshal([]byte(item), &pbp); err != nil {
+			return err
+		}
+
+		inUse := false
+		ascendErr := tx.AscendKeys(jobsPrefix+":*", func(key, item string) bool {
+			var pbj dkronpb.Job
+			if proto.Unmarshal([]byte(item), &pbj) != nil {
+				if err = json.Unmarshal([]byte(item), &pbj); err != nil {
+					return false
+				}
+			}
+			inUse = pbj.Pool == name
+			return !inUse
+		})
+		if err != nil {
+			return err
+		}
+		if ascendErr != nil {
+			return ascendErr
+		}
+		if inUse {
+			return ErrPoolInUse
+		}
+		pool = NewPoolFromProto(&pbp)
+
+		_, err = tx.Delete(fmt.Sprintf("%s:%s", poolsPrefix, name))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
 // GetExecutions returns the executions given a Job name.
 func (s *Store) GetExecutions(ctx context.Context, jobName string, opts *ExecutionOptions) ([]*Execution, error) {
 	ctx, span := s.tracer.Start(ctx, "buntdb.get.executions", trace.WithAttributes(attribute.String("job_name", jobName)))
@@ -736,14 +1177,26 @@ func (s *Store) SetExecution(ctx context.Context, execution *Execution) (string,
 			Error("store: Error getting executions for job")
 	}
 
-	// Delete all execution results over the limit, starting from olders
-	if len(execs) > MaxExecutions {
+	// Delete all execution results over the limit, starting from olders.
+	// The executions of the group being stored are kept, so the status of
+	// runs with more executions than the limit, like map jobs with many
+	// items, counts all of them.
+	over := len(execs) - MaxExecutions
+	if over > 0 {
+		others := make([]*Execution, 0, len(execs))
+		for _, ex := range execs {
+			if ex.Group != execution.Group {
+				others = append(others, ex)
+			}
+		}
+		execs = others
+
 		//sort the array of all execution groups by StartedAt time
 		sort.Slice(execs, func(i, j int) bool {
 			return execs[i].StartedAt.Before(execs[j].StartedAt)
 		})
 
-		for i := 0; i < len(execs)-MaxExecutions; i++ {
+		for i := 0; i < over && i < len(execs); i++ {
 			s.logger.WithFields(logrus.Fields{
 				"job":       execs[i].JobName,
 				"execution": execs[i].Key(),
@@ -869,26 +1322,11 @@ func (s *Store) unmarshalExecutions(items []kv, timezone *time.Location) ([]*Exe
 
 func (s *Store) computeStatus(jobName string, exGroup int64, tx *buntdb.Tx) (string, error) {
 	// compute job status based on execution group
-	kvs := []kv{}
-	found := false
-	prefix := fmt.Sprintf("%s:%s:", executionsPrefix, jobName)
-
-	if err := s.listTxFunc(prefix, &kvs, &found, &ExecutionOptions{})(tx); err != nil {
-		return "", err
-	}
-
-	execs, err := s.unmarshalExecutions(kvs, nil)
+	executions, err := s.groupExecutionsTx(jobName, exGroup, tx)
 	if err != nil {
 		return "", err
 	}
 
-	var executions []*Execution
-	for _, ex := range execs {
-		if ex.Group == exGroup {
-			executions = append(executions, ex)
-		}
-	}
-
 	success := 0
 	failed := 0
 
@@ -912,6 +1350,40 @@ func (s *Store) computeStatus(jobName string, exGroup int64, tx *buntdb.Tx) (str
 	return status, nil
 }
 
+// computeMapStatus computes the status of a map job from the items of the
+// execution group and the job success rule.
+func (s *Store) computeMapStatus(jobName string, exGroup int64, rule string, tx *buntdb.Tx) (string, error) {
+	executions, err := s.groupExecutionsTx(jobName, exGroup, tx)
+	if err != nil {
+		return "", err
+	}
+	return mapStatus(rule, executions), nil
+}
+
+// groupExecutionsTx returns the executions of the job in the execution group.
+func (s *Store) groupExecutionsTx(jobName string, exGroup int64, tx *buntdb.Tx) ([]*Execution, error) {
+	kvs := []kv{}
+	found := false
+	prefix := fmt.Sprintf("%s:%s:", executionsPrefix, jobName)
+
+	if err := s.listTxFunc(prefix, &kvs, &found, &ExecutionOptions{})(tx); err != nil {
+		return nil, err
+	}
+
+	execs, err := s.unmarshalExecutions(kvs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var executions []*Execution
+	for _, ex := range execs {
+		if ex.Group == exGroup {
+			executions = append(executions, ex)
+		}
+	}
+	return executions, nil
+}
+
 func trimDirectoryKey(key []byte) []byte {
 	if isDirectoryKey(key) {
 		return key[:len(key)-1]
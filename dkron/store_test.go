@@ -191,6 +191,87 @@ func TestStore_ChildIsUpdatedAfterDeletingParentJob(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestStore_FanInJobParents(t *testing.T) {
+	s := setupStore(t)
+	ctx := context.Background()
+
+	storeJob(t, s, "ingest_a")
+	storeJob(t, s, "ingest_b")
+	storeJob(t, s, "ingest_c")
+
+	merge := scaffoldJob()
+	merge.Name = "merge"
+	merge.ParentJobs = []string{"ingest_a", "ingest_b"}
+	require.NoError(t, s.SetJob(ctx, merge, false))
+	assert.Equal(t, []string{"merge"}, loadJob(t, s, "ingest_a").DependentJobs)
+	assert.Equal(t, []string{"merge"}, loadJob(t, s, "ingest_b").DependentJobs)
+
+	// Parents not in the store are rejected
+	merge.ParentJobs = []string{"ingest_a", "missing"}
+	assert.Equal(t, ErrParentJobNotFound, s.SetJob(ctx, merge, false))
+
+	// Changing the parents updates the old and new ones
+	merge.ParentJobs = []string{"ingest_b", "ingest_c"}
+	require.NoError(t, s.SetJob(ctx, merge, false))
+	assert.Empty(t, loadJob(t, s, "ingest_a").DependentJobs)
+	assert.Equal(t, []string{"merge"}, loadJob(t, s, "ingest_b").DependentJobs)
+	assert.Equal(t, []string{"merge"}, loadJob(t, s, "ingest_c").DependentJobs)
+
+	// Cycles are rejected
+	storeChildJob(t, s, "report", "merge")
+	ingest := scaffoldJob()
+	ingest.Name = "ingest_c"
+	ingest.ParentJobs = []string{"ingest_a", "report"}
+	assert.Equal(t, ErrDependencyCycle, s.SetJob(ctx, ingest, false))
+
+	deleteJob(t, s, "report")
+	deleteJob(t, s, "merge")
+	assert.Empty(t, loadJob(t, s, "ingest_b").DependentJobs)
+	assert.Empty(t, loadJob(t, s, "ingest_c").DependentJobs)
+}
+
+func TestStore_DependentConditions(t *testing.T) {
+	s := setupStore(t)
+	ctx := context.Background()
+
+	storeJob(t, s, "parent1")
+	storeChildJob(t, s, "child1", "parent1")
+	assert.Equal(t, map[string]string{"child1": ConditionOnSuccess}, loadJob(t, s, "parent1").DependentConditions)
+
+	cleanup := scaffoldJob()
+	cleanup.Name = "cleanup"
+	cleanup.ParentJob = "parent1"
+	cleanup.ParentConditions = map[string]string{"parent1": ConditionOnFailure}
+	require.NoError(t, s.SetJob(ctx, cleanup, false))
+	assert.Equal(t, map[string]string{
+		"child1":  ConditionOnSuccess,
+		"cleanup": ConditionOnFailure,
+	}, loadJob(t, s, "parent1").DependentConditions)
+
+	// Changing the condition updates the parent
+	cleanup.ParentConditions["parent1"] = ConditionAlways
+	require.NoError(t, s.SetJob(ctx, cleanup, false))
+	parent := loadJob(t, s, "parent1")
+	assert.Equal(t, []string{"child1", "cleanup"}, parent.DependentJobs)
+	assert.Equal(t, ConditionAlways, parent.DependentConditions["cleanup"])
+
+	// Updating the parent keeps them
+	parent = scaffoldJob()
+	parent.Name = "parent1"
+	require.NoError(t, s.SetJob(ctx, parent, true))
+	assert.Equal(t, ConditionAlways, loadJob(t, s, "parent1").DependentConditions["cleanup"])
+
+	// Bad conditions are rejected
+	cleanup.ParentConditions["parent1"] = "on_timeout"
+	assert.Equal(t, "parent_conditions[parent1]: "+ErrWrongParentCondition.Error(), s.SetJob(ctx, cleanup, false).Error())
+	cleanup.ParentConditions = map[string]string{"other": ConditionAlways}
+	assert.Error(t, s.SetJob(ctx, cleanup, false))
+	assert.Equal(t, ErrWrongParentCondition, s.addToParent(ctx, "cleanup", "parent1", "on_timeout"))
+
+	deleteJob(t, s, "cleanup")
+	assert.Equal(t, map[string]string{"child1": ConditionOnSuccess}, loadJob(t, s, "parent1").DependentConditions)
+}
+
 func TestStore_GetJobsWithMetadata(t *testing.T) {
 	s := setupStore(t)
 
@@ -1,6 +1,8 @@
 package dkron
 
 import (
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -8,6 +10,20 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// tagExists matches the nodes having the tag, whatever its value.
+	tagExists = "exists"
+	// tagNotExists matches the nodes not having the tag.
+	tagNotExists = "not exists"
+)
+
+var (
+	tagCardinalityRe = regexp.MustCompile(`^(.*):(\d+)$`)
+	tagSetRe         = regexp.MustCompile(`^(not\s+)?in\s*\((.*)\)$`)
+	tagCompareRe     = regexp.MustCompile(`^(!=|>=|<=|>|<)\s*(.*)$`)
+	tagRegexpRe      = regexp.MustCompile(`^~/(.*)/$`)
+)
+
 // cleanTags takes the tag spec and returns strictly key:value pairs
 // along with the lowest cardinality specified
 func cleanTags(tags map[string]string, logger *logrus.Entry) (map[string]string, int) {
@@ -16,6 +32,19 @@ func cleanTags(tags map[string]string, logger *logrus.Entry) (map[string]string,
 	cleanTags := make(map[string]string, len(tags))
 
 	for k, v := range tags {
+		// Tag expressions can have colons, only a trailing number is a cardinality
+		if m := tagCardinalityRe.FindStringSubmatch(v); m != nil && isTagExpression(m[1]) {
+			cleanTags[k] = m[1]
+			if tagCard, err := strconv.Atoi(m[2]); err == nil && tagCard < cardinality {
+				cardinality = tagCard
+			}
+			continue
+		}
+		if isTagExpression(v) {
+			cleanTags[k] = v
+			continue
+		}
+
 		vparts := strings.Split(v, ":")
 
 		cleanTags[k] = vparts[0]
@@ -43,13 +72,153 @@ func cleanTags(tags map[string]string, logger *logrus.Entry) (map[string]string,
 func nodeMatchesTags(node serf.Member, tags map[string]string) bool {
 	for k, v := range tags {
 		nodeVal, present := node.Tags[k]
-		if !present {
+		sel, err := parseTagSelector(v)
+		if err != nil {
 			return false
 		}
-		if nodeVal != v {
+		if !sel.matches(nodeVal, present) {
 			return false
 		}
 	}
 	// If we matched all key:value pairs, the node matches the tags
 	return true
 }
+
+// isTagExpression returns whether the job tag value is an expression
+// instead of a plain value the node tag must equal.
+func isTagExpression(v string) bool {
+	v = strings.TrimSpace(v)
+	return v == tagExists || v == tagNotExists ||
+		tagSetRe.MatchString(v) ||
+		tagCompareRe.MatchString(v) ||
+		strings.HasPrefix(v, "~/")
+}
+
+// tagSelector is a parsed job tag value, without its cardinality.
+type tagSelector struct {
+	op     string
+	values []string
+	re     *regexp.Regexp
+	num    float64
+}
+
+// parseTagSelector parses a job tag value without its cardinality. Plain
+// values must be equal to the node tag, expressions can be:
+//
+//	!= value          different value or no tag
+//	in (a, b)         one of the values
+//	not in (a, b)     none of the values or no tag
+//	~/^gpu-/          value matching the regular expression
+//	exists            any value
+//	not exists        no tag
+//	>= 8, > 8, <= 8, < 8  numeric comparison
+func parseTagSelector(v string) (*tagSelector, error) {
+	if !isTagExpression(v) {
+		return &tagSelector{op: "=", values: []string{v}}, nil
+	}
+	v = strings.TrimSpace(v)
+
+	switch {
+	case v == tagExists || v == tagNotExists:
+		return &tagSelector{op: v}, nil
+
+	case strings.HasPrefix(v, "~/"):
+		m := tagRegexpRe.FindStringSubmatch(v)
+		if m == nil {
+			return nil, fmt.Errorf("regular expression must be enclosed in slashes: %s", v)
+		}
+		re, err := regexp.Compile(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %w", m[1], err)
+		}
+		return &tagSelector{op: "~", re: re}, nil
+
+	case tagSetRe.MatchString(v):
+		m := tagSetRe.FindStringSubmatch(v)
+		sel := &tagSelector{op: "in"}
+		if m[1] != "" {
+			sel.op = "not in"
+		}
+		for _, value := range strings.Split(m[2], ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return nil, fmt.Errorf("empty value in set: %s", v)
+			}
+			sel.values = append(sel.values, value)
+		}
+		return sel, nil
+	}
+
+	m := tagCompareRe.FindStringSubmatch(v)
+	sel := &tagSelector{op: m[1]}
+	value := strings.TrimSpace(m[2])
+	if value == "" {
+		return nil, fmt.Errorf("missing value to compare with: %s", v)
+	}
+	if sel.op == "!=" {
+		sel.values = []string{value}
+		return sel, nil
+	}
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %s: %s", value, v)
+	}
+	sel.num = num
+	return sel, nil
+}
+
+// matches returns whether a node tag value, or its absence, is selected.
+func (s *tagSelector) matches(nodeVal string, present bool) bool {
+	switch s.op {
+	case "=":
+		return present && nodeVal == s.values[0]
+	case "!=":
+		return !present || nodeVal != s.values[0]
+	case "in", "not in":
+		in := false
+		for _, v := range s.values {
+			if present && nodeVal == v {
+				in = true
+				break
+			}
+		}
+		return in == (s.op == "in")
+	case "~":
+		return present && s.re.MatchString(nodeVal)
+	case tagExists:
+		return present
+	case tagNotExists:
+		return !present
+	}
+
+	num, err := strconv.ParseFloat(nodeVal, 64)
+	if !present || err != nil {
+		return false
+	}
+	switch s.op {
+	case ">=":
+		return num >= s.num
+	case ">":
+		return num > s.num
+	case "<=":
+		return num <= s.num
+	default:
+		return num < s.num
+	}
+}
+
+// validateTags checks the expressions of the job tags are well formed.
+func validateTags(tags map[string]string) error {
+	for k, v := range tags {
+		if m := tagCardinalityRe.FindStringSubmatch(v); m != nil && isTagExpression(m[1]) {
+			v = m[1]
+		}
+		if !isTagExpression(v) {
+			continue
+		}
+		if _, err := parseTagSelector(v); err != nil {
+			return fmt.Errorf("tags[%s]: %s", k, err)
+		}
+	}
+	return nil
+}
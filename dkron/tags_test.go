@@ -3,6 +3,9 @@ package dkron
 import (
 	"reflect"
 	"testing"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_cleanTags(t *testing.T) {
@@ -57,3 +60,73 @@ func Test_cleanTags(t *testing.T) {
 		})
 	}
 }
+
+func Test_cleanTagsExpressions(t *testing.T) {
+	got, card := cleanTags(map[string]string{
+		"gpu":  "~/^nvidia:a100/:2",
+		"cpus": ">= 8:3",
+		"zone": "not in (eu-1a, eu-1b)",
+	}, getTestLogger())
+
+	assert.Equal(t, map[string]string{
+		"gpu":  "~/^nvidia:a100/",
+		"cpus": ">= 8",
+		"zone": "not in (eu-1a, eu-1b)",
+	}, got)
+	assert.Equal(t, 2, card)
+}
+
+func Test_nodeMatchesTagExpressions(t *testing.T) {
+	node := serf.Member{Tags: map[string]string{
+		"role": "gpu-worker",
+		"zone": "eu-1a",
+		"cpus": "16",
+	}}
+
+	tests := []struct {
+		tags  map[string]string
+		match bool
+	}{
+		{map[string]string{"role": "gpu-worker"}, true},
+		{map[string]string{"role": "web"}, false},
+		{map[string]string{"zone": "!= eu-1b"}, true},
+		{map[string]string{"zone": "!=eu-1a"}, false},
+		{map[string]string{"disk": "!= ssd"}, true},
+		{map[string]string{"zone": "in (eu-1a, eu-1b)"}, true},
+		{map[string]string{"zone": "in (us-1a)"}, false},
+		{map[string]string{"zone": "not in (us-1a,us-1b)"}, true},
+		{map[string]string{"zone": "not in (eu-1a)"}, false},
+		{map[string]string{"role": "~/^gpu-/"}, true},
+		{map[string]string{"role": "~/^web-/"}, false},
+		{map[string]string{"cpus": "exists"}, true},
+		{map[string]string{"disk": "exists"}, false},
+		{map[string]string{"disk": "not exists"}, true},
+		{map[string]string{"cpus": ">= 16"}, true},
+		{map[string]string{"cpus": "> 16"}, false},
+		{map[string]string{"cpus": "< 32"}, true},
+		{map[string]string{"cpus": "<= 8"}, false},
+		{map[string]string{"role": ">= 1"}, false},
+		{map[string]string{"memory": ">= 1"}, false},
+		{map[string]string{"role": "~/^gpu-/", "cpus": ">= 8", "zone": "in (eu-1a)"}, true},
+		{map[string]string{"role": "~/^gpu-/", "cpus": ">= 32"}, false},
+	}
+	for _, tt := range tests {
+		bareTags, _ := cleanTags(tt.tags, getTestLogger())
+		assert.Equal(t, tt.match, nodeMatchesTags(node, bareTags), "%v", tt.tags)
+	}
+}
+
+func Test_validateTags(t *testing.T) {
+	valid := []string{"web", "web:2", "value:cardinality", "!= web", "in (a, b):1", "not in (a)", "~/^gpu-[0-9]+/", "exists", "not exists:2", ">= 8", "< 0.5"}
+	for _, v := range valid {
+		assert.NoError(t, validateTags(map[string]string{"tag": v}), v)
+	}
+
+	invalid := []string{"!=", "in (a,,b)", "in ()", "~/[/", "~/unclosed", ">= many", "<"}
+	for _, v := range invalid {
+		assert.Error(t, validateTags(map[string]string{"tag": v}), v)
+	}
+
+	job := &Job{Name: "gpu", Schedule: "@every 1m", Tags: map[string]string{"cpus": ">= lots"}}
+	assert.ErrorContains(t, job.Validate(), "tags[cpus]")
+}
@@ -0,0 +1,360 @@
+package dkron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// WorkflowPending is the status of a job of a workflow run whose parents
+	// met its trigger rule, but that didn't start yet.
+	WorkflowPending = "pending"
+	// WorkflowWaiting is the status of a job of a workflow run whose parents
+	// didn't finish yet.
+	WorkflowWaiting = "waiting"
+	// WorkflowSkipped is the status of a job of a workflow run whose parents
+	// finished without meeting its trigger rule.
+	WorkflowSkipped = "skipped"
+)
+
+// ErrWorkflowRunNotFound is returned when the workflow run doesn't exist.
+var ErrWorkflowRunNotFound = errors.New("workflow run not found")
+
+// WorkflowRun is a run of a job and the dependent jobs it triggered.
+type WorkflowRun struct {
+	// ID of the workflow run, shared by all its executions.
+	ID string `json:"id"`
+
+	// Job that started the workflow run.
+	Root string `json:"root"`
+
+	// Status of the workflow run: running, success or failed.
+	Status string `json:"status"`
+
+	// Start time of the first execution of the workflow run.
+	StartedAt time.Time `json:"started_at,omitempty"`
+
+	// Finish time of the last execution, empty while running.
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+
+	// Jobs of the workflow, parents before their dependent jobs.
+	Jobs []*WorkflowRunJob `json:"jobs"`
+
+	// Chain of jobs, from the root, that finished last.
+	CriticalPath []string `json:"critical_path"`
+}
+
+// WorkflowRunJob is the status of a job in a workflow run.
+type WorkflowRunJob struct {
+	// Name of the job.
+	JobName string `json:"job_name"`
+
+	// Parents of the job in the workflow.
+	ParentJobs []string `json:"parent_jobs,omitempty"`
+
+	// Status of the job: a job status, or pending, waiting or skipped
+	// if it didn't run.
+	Status string `json:"status"`
+
+	// Start time of the first execution of the job.
+	StartedAt time.Time `json:"started_at,omitempty"`
+
+	// Finish time of the last execution of the job.
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+
+	// Number of executions of the job, including retries.
+	Executions int `json:"executions"`
+}
+
+// workflowRunID returns the ID of the workflow run started by the
+// execution group of the root job.
+func workflowRunID(root string, group int64) string {
+	return fmt.Sprintf("%s-%d", root, group)
+}
+
+// workflowRoot returns the job that started the workflow run.
+func workflowRoot(id string) (string, bool) {
+	i := strings.LastIndex(id, "-")
+	if i <= 0 {
+		return "", false
+	}
+	if _, err := strconv.ParseInt(id[i+1:], 10, 64); err != nil {
+		return "", false
+	}
+	return id[:i], true
+}
+
+// WorkflowRuns returns the workflow runs started by the job, newest first.
+func (a *Agent) WorkflowRuns(ctx context.Context, root string) ([]*WorkflowRun, error) {
+	jobs, execs, err := a.workflowExecutions(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	runs := []*WorkflowRun{}
+	for _, ex := range execs[root] {
+		id := ex.WorkflowRunID
+		if r, ok := workflowRoot(id); !ok || r != root || seen[id] {
+			continue
+		}
+		seen[id] = true
+		runs = append(runs, buildWorkflowRun(id, jobs, execs))
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+	return runs, nil
+}
+
+// GetWorkflowRun returns the workflow run with the given ID.
+func (a *Agent) GetWorkflowRun(ctx context.Context, id string) (*WorkflowRun, error) {
+	root, ok := workflowRoot(id)
+	if !ok {
+		return nil, ErrWorkflowRunNotFound
+	}
+	jobs, execs, err := a.workflowExecutions(ctx, root)
+	if err == buntdb.ErrNotFound {
+		return nil, ErrWorkflowRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	run := buildWorkflowRun(id, jobs, execs)
+	if run.StartedAt.IsZero() {
+		return nil, ErrWorkflowRunNotFound
+	}
+	return run, nil
+}
+
+// workflowExecutions returns the root job and its dependent jobs, parents
+// first, along with their executions by job name.
+func (a *Agent) workflowExecutions(ctx context.Context, root string) ([]*Job, map[string][]*Execution, error) {
+	job, err := a.Store.GetJob(ctx, root, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Collect the dependent jobs
+	byName := map[string]*Job{root: job}
+	pending := []*Job{job}
+	for len(pending) > 0 {
+		j := pending[0]
+		pending = pending[1:]
+		for _, djn := range j.DependentJobs {
+			if _, ok := byName[djn]; ok {
+				continue
+			}
+			dj, err := a.Store.GetJob(ctx, djn, nil)
+			if err == buntdb.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			byName[djn] = dj
+			pending = append(pending, dj)
+		}
+	}
+
+	// and sort them parents first
+	var jobs []*Job
+	added := make(map[string]bool)
+	var add func(j *Job)
+	add = func(j *Job) {
+		if added[j.Name] {
+			return
+		}
+		added[j.Name] = true
+		for _, p := range j.parents() {
+			if pj, ok := byName[p]; ok {
+				add(pj)
+			}
+		}
+		jobs = append(jobs, j)
+	}
+	add(job)
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(byName[name])
+	}
+
+	execs := make(map[string][]*Execution, len(jobs))
+	for _, j := range jobs {
+		if execs[j.Name], err = a.Store.GetExecutions(ctx, j.Name, &ExecutionOptions{}); err != nil && err != buntdb.ErrNotFound {
+			return nil, nil, err
+		}
+	}
+	return jobs, execs, nil
+}
+
+// buildWorkflowRun returns the status of the workflow run from the
+// executions of its jobs.
+func buildWorkflowRun(id string, jobs []*Job, execs map[string][]*Execution) *WorkflowRun {
+	run := &WorkflowRun{
+		ID:     id,
+		Root:   jobs[0].Name,
+		Status: StatusSuccess,
+	}
+
+	inWorkflow := make(map[string]*WorkflowRunJob, len(jobs))
+	for _, j := range jobs {
+		wj := &WorkflowRunJob{JobName: j.Name}
+		for _, p := range j.parents() {
+			if _, ok := inWorkflow[p]; ok {
+				wj.ParentJobs = append(wj.ParentJobs, p)
+			}
+		}
+
+		var runExecs []*Execution
+		for _, ex := range execs[j.Name] {
+			if ex.WorkflowRunID == id {
+				runExecs = append(runExecs, ex)
+			}
+		}
+		wj.Executions = len(runExecs)
+		if len(runExecs) > 0 {
+			wj.Status = executionsStatus(runExecs)
+			for _, ex := range runExecs {
+				if wj.StartedAt.IsZero() || ex.StartedAt.Before(wj.StartedAt) {
+					wj.StartedAt = ex.StartedAt
+				}
+				if ex.FinishedAt.After(wj.FinishedAt) {
+					wj.FinishedAt = ex.FinishedAt
+				}
+			}
+		} else {
+			// Jobs are sorted parents first, their status is known
+			wj.Status = pendingStatus(j, wj.ParentJobs, inWorkflow)
+		}
+		inWorkflow[j.Name] = wj
+		run.Jobs = append(run.Jobs, wj)
+
+		switch wj.Status {
+		case StatusRunning, WorkflowPending, WorkflowWaiting:
+			run.Status = StatusRunning
+		case StatusFailed, StatusPartiallyFailed:
+			if run.Status != StatusRunning {
+				run.Status = StatusFailed
+			}
+		}
+		if !wj.StartedAt.IsZero() && (run.StartedAt.IsZero() || wj.StartedAt.Before(run.StartedAt)) {
+			run.StartedAt = wj.StartedAt
+		}
+		if wj.FinishedAt.After(run.FinishedAt) {
+			run.FinishedAt = wj.FinishedAt
+		}
+	}
+	if run.Status == StatusRunning {
+		run.FinishedAt = time.Time{}
+	}
+
+	run.CriticalPath = criticalPath(run.Jobs, inWorkflow)
+	return run
+}
+
+// executionsStatus returns the status of a job from its executions in a
+// workflow run, using the last attempt in every node, or of every item
+// for map jobs.
+func executionsStatus(execs []*Execution) string {
+	last := make(map[string]*Execution)
+	for _, ex := range execs {
+		key := ex.NodeName
+		if isMapItem(ex) {
+			key = ex.Metadata[mapIndexMetadata]
+		}
+		if l, ok := last[key]; !ok || ex.Attempt > l.Attempt ||
+			(ex.Attempt == l.Attempt && ex.StartedAt.After(l.StartedAt)) {
+			last[key] = ex
+		}
+	}
+
+	success, failed := 0, 0
+	for _, ex := range last {
+		switch {
+		case ex.Skipped:
+		case ex.FinishedAt.IsZero():
+			return StatusRunning
+		case ex.Success:
+			success++
+		default:
+			failed++
+		}
+	}
+
+	switch {
+	case success == 0 && failed == 0:
+		return WorkflowSkipped
+	case failed == 0:
+		return StatusSuccess
+	case success == 0:
+		return StatusFailed
+	default:
+		return StatusPartiallyFailed
+	}
+}
+
+// pendingStatus returns the status of a job that didn't run in a workflow
+// run, from the status of its parents.
+func pendingStatus(job *Job, parents []string, inWorkflow map[string]*WorkflowRunJob) string {
+	done, met := 0, 0
+	for _, p := range parents {
+		switch status := inWorkflow[p].Status; status {
+		case StatusRunning, WorkflowPending, WorkflowWaiting:
+		case WorkflowSkipped:
+			done++
+		default:
+			done++
+			if conditionMet(job.parentCondition(p), status) {
+				met++
+			}
+		}
+	}
+
+	switch {
+	case triggerRuleMet(job.TriggerRule, len(parents), done, met):
+		return WorkflowPending
+	case done == len(parents):
+		return WorkflowSkipped
+	default:
+		return WorkflowWaiting
+	}
+}
+
+// criticalPath returns the chain of jobs that ran, from the root, ending
+// in the job that finished last, following the parents that finished last.
+func criticalPath(jobs []*WorkflowRunJob, inWorkflow map[string]*WorkflowRunJob) []string {
+	var last *WorkflowRunJob
+	for _, wj := range jobs {
+		if wj.Executions > 0 && (last == nil || wj.FinishedAt.After(last.FinishedAt)) {
+			last = wj
+		}
+	}
+
+	path := []string{}
+	for last != nil {
+		path = append([]string{last.JobName}, path...)
+		var next *WorkflowRunJob
+		for _, p := range last.ParentJobs {
+			pj := inWorkflow[p]
+			if pj.Executions > 0 && (next == nil || pj.FinishedAt.After(next.FinishedAt)) {
+				next = pj
+			}
+		}
+		last = next
+	}
+	return path
+}
@@ -0,0 +1,117 @@
+package dkron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRoot(t *testing.T) {
+	id := workflowRunID("nightly-ingest", 1700000000)
+	root, ok := workflowRoot(id)
+	assert.True(t, ok)
+	assert.Equal(t, "nightly-ingest", root)
+
+	for _, id := range []string{"", "ingest", "-1", "ingest-x"} {
+		_, ok := workflowRoot(id)
+		assert.False(t, ok, id)
+	}
+}
+
+func TestExecutionsStatus(t *testing.T) {
+	now := time.Now()
+	ex := func(node string, attempt uint, success bool) *Execution {
+		return &Execution{NodeName: node, Attempt: attempt, Success: success, StartedAt: now, FinishedAt: now}
+	}
+
+	// Retries count with their last attempt
+	assert.Equal(t, StatusSuccess, executionsStatus([]*Execution{ex("a", 1, false), ex("a", 2, true)}))
+	assert.Equal(t, StatusFailed, executionsStatus([]*Execution{ex("a", 2, false), ex("a", 1, true)}))
+	assert.Equal(t, StatusPartiallyFailed, executionsStatus([]*Execution{ex("a", 1, true), ex("b", 1, false)}))
+	assert.Equal(t, StatusRunning, executionsStatus([]*Execution{ex("a", 1, true), {NodeName: "b", Attempt: 1, StartedAt: now}}))
+	assert.Equal(t, WorkflowSkipped, executionsStatus([]*Execution{{NodeName: "a", Skipped: true, StartedAt: now, FinishedAt: now}}))
+}
+
+func TestAgentWorkflowRuns(t *testing.T) {
+	s := setupStore(t)
+	ctx := context.Background()
+	a := &Agent{Store: s}
+
+	// ingest -> transform_a, transform_b -> merge, cleanup on failure
+	storeJob(t, s, "ingest")
+	storeChildJob(t, s, "transform_a", "ingest")
+	storeChildJob(t, s, "transform_b", "ingest")
+	merge := scaffoldJob()
+	merge.Name = "merge"
+	merge.ParentJobs = []string{"transform_a", "transform_b"}
+	require.NoError(t, s.SetJob(ctx, merge, false))
+	cleanup := scaffoldJob()
+	cleanup.Name = "cleanup"
+	cleanup.ParentJob = "merge"
+	cleanup.ParentConditions = map[string]string{"merge": ConditionOnFailure}
+	require.NoError(t, s.SetJob(ctx, cleanup, false))
+
+	start := time.Now().Add(-time.Hour)
+	id := workflowRunID("ingest", start.UnixNano())
+	setExecution := func(job string, offset, duration time.Duration, success bool, runID string) {
+		_, err := s.SetExecution(ctx, &Execution{
+			JobName:       job,
+			NodeName:      "node1",
+			Group:         start.Add(offset).UnixNano(),
+			Attempt:       1,
+			StartedAt:     start.Add(offset),
+			FinishedAt:    start.Add(offset + duration),
+			Success:       success,
+			WorkflowRunID: runID,
+		})
+		require.NoError(t, err)
+	}
+	setExecution("ingest", 0, time.Minute, true, id)
+	setExecution("transform_a", time.Minute, time.Minute, true, id)
+	setExecution("transform_b", time.Minute, 5*time.Minute, true, id)
+
+	// Merge is about to start
+	run, err := a.GetWorkflowRun(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, run.Status)
+	assert.True(t, run.FinishedAt.IsZero())
+	statuses := make(map[string]string)
+	for _, j := range run.Jobs {
+		statuses[j.JobName] = j.Status
+	}
+	assert.Equal(t, map[string]string{
+		"ingest":      StatusSuccess,
+		"transform_a": StatusSuccess,
+		"transform_b": StatusSuccess,
+		"merge":       WorkflowPending,
+		"cleanup":     WorkflowWaiting,
+	}, statuses)
+
+	setExecution("merge", 6*time.Minute, time.Minute, true, id)
+
+	// Executions of other runs don't count
+	setExecution("ingest", 30*time.Minute, time.Minute, false, workflowRunID("ingest", start.Add(30*time.Minute).UnixNano()))
+
+	run, err = a.GetWorkflowRun(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuccess, run.Status)
+	assert.Equal(t, start.Add(7*time.Minute).Unix(), run.FinishedAt.Unix())
+	assert.Equal(t, []string{"ingest", "transform_b", "merge"}, run.CriticalPath)
+	assert.Equal(t, "merge", run.Jobs[3].JobName)
+	assert.Equal(t, []string{"transform_a", "transform_b"}, run.Jobs[3].ParentJobs)
+	assert.Equal(t, WorkflowSkipped, run.Jobs[4].Status)
+
+	runs, err := a.WorkflowRuns(ctx, "ingest")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, StatusFailed, runs[0].Status)
+	assert.Equal(t, id, runs[1].ID)
+
+	_, err = a.GetWorkflowRun(ctx, workflowRunID("ingest", 1))
+	assert.Equal(t, ErrWorkflowRunNotFound, err)
+	_, err = a.GetWorkflowRun(ctx, workflowRunID("missing", 1))
+	assert.Equal(t, ErrWorkflowRunNotFound, err)
+}
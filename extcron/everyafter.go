@@ -0,0 +1,57 @@
+package extcron
+
+import (
+	"sync"
+	"time"
+)
+
+// EveryAfterSchedule runs a job a fixed delay after its previous
+// execution finished, instead of at fixed wall clock ticks.
+//
+// The schedule fires once: the scheduler must add it again with the
+// new anchor when the execution finishes.
+type EveryAfterSchedule struct {
+	Delay time.Duration
+	// Anchor is the time the previous execution finished. If empty
+	// the delay counts from the first time Next is called.
+	Anchor time.Time
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// EveryAfter creates an EveryAfterSchedule with the given delay,
+// truncated to the second like @every, with a minimum of one second.
+func EveryAfter(delay time.Duration) *EveryAfterSchedule {
+	if delay < time.Second {
+		delay = time.Second
+	}
+	return &EveryAfterSchedule{
+		Delay: delay - time.Duration(delay.Nanoseconds())%time.Second,
+	}
+}
+
+// Next conforms to the Schedule interface. It returns the anchor plus
+// the delay, or a near-immediate time if that already passed, and the
+// zero time once the schedule fired.
+func (schedule *EveryAfterSchedule) Next(t time.Time) time.Time {
+	schedule.mu.Lock()
+	defer schedule.mu.Unlock()
+
+	if schedule.next.IsZero() {
+		anchor := schedule.Anchor
+		if anchor.IsZero() {
+			anchor = t
+		}
+		schedule.next = anchor.Add(schedule.Delay)
+		if !schedule.next.After(t) {
+			// The cron.Schedule contract requires returning a time later than t.
+			schedule.next = t.Add(time.Nanosecond)
+		}
+	}
+
+	if schedule.next.After(t) {
+		return schedule.next
+	}
+	return time.Time{}
+}
@@ -0,0 +1,65 @@
+package extcron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEveryAfterScheduleNext(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		anchor   time.Time
+		expected time.Time
+	}{
+		{
+			name:     "without previous execution",
+			expected: now.Add(10 * time.Minute),
+		},
+		{
+			name:     "delay after the previous execution",
+			anchor:   now.Add(-4 * time.Minute),
+			expected: now.Add(6 * time.Minute),
+		},
+		{
+			name:     "overdue runs near-immediately",
+			anchor:   now.Add(-time.Hour),
+			expected: now.Add(time.Nanosecond),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse("@every-after 10m")
+			require.NoError(t, err)
+			require.IsType(t, &EveryAfterSchedule{}, s)
+			s.(*EveryAfterSchedule).Anchor = tt.anchor
+
+			next := s.Next(now)
+			assert.Equal(t, tt.expected, next)
+			// Until the schedule is added again with a new anchor it
+			// keeps returning the same time, and nothing once it fired
+			assert.Equal(t, tt.expected, s.Next(now.Add(time.Nanosecond/2)))
+			assert.True(t, s.Next(next).IsZero())
+		})
+	}
+}
+
+func TestEveryAfterParse(t *testing.T) {
+	s, err := Parse("@every-after 90s")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, s.(*EveryAfterSchedule).Delay)
+
+	s, err = Parse("@every-after 1500ms")
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, s.(*EveryAfterSchedule).Delay)
+
+	for _, spec := range []string{"@every-after", "@every-after ten", "@every-after -5m", "@every-after 0s"} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
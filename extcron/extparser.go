@@ -21,7 +21,9 @@ func NewParser() cron.ScheduleParser {
 
 // Parse parses a cron schedule specification. It accepts the cron spec with
 // mandatory seconds parameter, descriptors and the custom descriptors
-// "@at <date>", "@after <date> <duration>", "@manually" and "@minutely".
+// "@at <date>", "@after <date> <duration>", "@every-after <duration>",
+// "@rrule <rule>", "@manually" and "@minutely". The day of month and day of week fields also accept
+// the Quartz L, W and # modifiers.
 func (p ExtParser) Parse(spec string) (cron.Schedule, error) {
 	switch spec {
 	case "@manually":
@@ -69,6 +71,41 @@ func (p ExtParser) Parse(spec string) (cron.Schedule, error) {
 		return After(date, gracePeriod), nil
 	}
 
+	const everyAfter = "@every-after "
+	if strings.HasPrefix(spec, everyAfter) {
+		delay, err := time.ParseDuration(strings.TrimSpace(spec[len(everyAfter):]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse duration %s: %s", spec, err)
+		}
+		if delay <= 0 {
+			return nil, fmt.Errorf("@every-after requires a positive duration, got: %s", spec)
+		}
+		return EveryAfter(delay), nil
+	}
+
+	const rrule = "@rrule "
+	loc := time.Local
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		// Like in regular cron specs, the timezone prefix sets the
+		// location of the floating dates in the rule
+		tz, rest, _ := strings.Cut(spec, " ")
+		if strings.HasPrefix(rest, rrule) {
+			_, name, _ := strings.Cut(tz, "=")
+			var err error
+			if loc, err = time.LoadLocation(name); err != nil {
+				return nil, fmt.Errorf("provided bad location %s: %v", name, err)
+			}
+			spec = rest
+		}
+	}
+	if strings.HasPrefix(spec, rrule) {
+		return ParseRRule(spec[len(rrule):], loc)
+	}
+
+	if hasQuartzModifiers(spec) {
+		return parseQuartz(p.parser, spec)
+	}
+
 	// It's not a dkron specific spec: Let the regular cron schedule parser have it
 	return p.parser.Parse(spec)
 }
@@ -0,0 +1,338 @@
+package extcron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// maxQuartzDays limits how many days ahead a Quartz schedule looks for
+// the next activation, like robfig/cron it gives up after five years.
+const maxQuartzDays = 5 * 366
+
+var dowNames = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+// NthWeekday is a day of week field value with the # modifier,
+// the Nth given weekday of the month.
+type NthWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// QuartzSchedule is a cron schedule whose day of month and day of week
+// fields support the Quartz modifiers:
+//
+//   - L in day of month, the last day of the month, or L-n, n days before it.
+//   - nW in day of month, the weekday nearest to day n of the month.
+//   - LW in day of month, the last weekday of the month.
+//   - dL in day of week, the last weekday d of the month. L alone is Saturday.
+//   - d#n in day of week, the nth weekday d of the month.
+//
+// Seconds, minutes, hours and months are taken from the embedded schedule.
+type QuartzSchedule struct {
+	*cron.SpecSchedule
+
+	// Plain day of month and day of week values, as robfig/cron bits.
+	Dom, Dow uint64
+	DomStar  bool
+	DowStar  bool
+
+	LastDayOffsets     []int
+	NearestWeekdays    []int
+	LastWeekday        bool
+	LastWeekdaysOfWeek []time.Weekday
+	NthWeekdays        []NthWeekday
+}
+
+// hasQuartzModifiers returns whether the cron spec, with mandatory seconds,
+// uses Quartz modifiers in its day of month or day of week fields.
+func hasQuartzModifiers(spec string) bool {
+	fields := strings.Fields(spec)
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "TZ=") || strings.HasPrefix(fields[0], "CRON_TZ=")) {
+		fields = fields[1:]
+	}
+	if len(fields) != 6 {
+		return false
+	}
+	return strings.ContainsAny(fields[3], "LW") || strings.ContainsAny(fields[5], "L#")
+}
+
+// parseQuartz parses a cron spec with mandatory seconds using Quartz
+// modifiers, the rest of the fields are parsed by the given parser.
+func parseQuartz(parser cron.Parser, spec string) (*QuartzSchedule, error) {
+	fields := strings.Fields(spec)
+	var prefix string
+	if strings.HasPrefix(fields[0], "TZ=") || strings.HasPrefix(fields[0], "CRON_TZ=") {
+		prefix, fields = fields[0]+" ", fields[1:]
+	}
+
+	base, err := parser.Parse(prefix + strings.Join([]string{fields[0], fields[1], fields[2], "*", fields[4], "*"}, " "))
+	if err != nil {
+		return nil, err
+	}
+	specSchedule, ok := base.(*cron.SpecSchedule)
+	if !ok {
+		return nil, fmt.Errorf("unexpected schedule type for spec %s", spec)
+	}
+
+	s := &QuartzSchedule{
+		SpecSchedule: specSchedule,
+		DomStar:      fields[3] == "*" || fields[3] == "?",
+		DowStar:      fields[5] == "*" || fields[5] == "?",
+	}
+
+	if !s.DomStar {
+		if err := s.parseDom(parser, fields[3]); err != nil {
+			return nil, err
+		}
+	}
+	if !s.DowStar {
+		if err := s.parseDow(parser, fields[5]); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (s *QuartzSchedule) parseDom(parser cron.Parser, field string) error {
+	var plain []string
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case item == "LW":
+			s.LastWeekday = true
+		case strings.HasPrefix(item, "L"):
+			offset := 0
+			if rest := item[1:]; rest != "" {
+				n, err := strconv.Atoi(strings.TrimPrefix(rest, "-"))
+				if err != nil || !strings.HasPrefix(rest, "-") || n > 30 {
+					return fmt.Errorf("invalid day of month %s", item)
+				}
+				offset = n
+			}
+			s.LastDayOffsets = append(s.LastDayOffsets, offset)
+		case strings.HasSuffix(item, "W"):
+			n, err := strconv.Atoi(item[:len(item)-1])
+			if err != nil || n < 1 || n > 31 {
+				return fmt.Errorf("invalid day of month %s", item)
+			}
+			s.NearestWeekdays = append(s.NearestWeekdays, n)
+		default:
+			plain = append(plain, item)
+		}
+	}
+
+	if len(plain) > 0 {
+		sched, err := parser.Parse("0 0 0 " + strings.Join(plain, ",") + " * *")
+		if err != nil {
+			return err
+		}
+		s.Dom = sched.(*cron.SpecSchedule).Dom
+	}
+	return nil
+}
+
+func (s *QuartzSchedule) parseDow(parser cron.Parser, field string) error {
+	var plain []string
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case item == "L":
+			s.LastWeekdaysOfWeek = append(s.LastWeekdaysOfWeek, time.Saturday)
+		case strings.HasSuffix(item, "L"):
+			wd, err := parseDow(item[:len(item)-1])
+			if err != nil {
+				return err
+			}
+			s.LastWeekdaysOfWeek = append(s.LastWeekdaysOfWeek, wd)
+		case strings.Contains(item, "#"):
+			day, nth, _ := strings.Cut(item, "#")
+			wd, err := parseDow(day)
+			if err != nil {
+				return err
+			}
+			n, err := strconv.Atoi(nth)
+			if err != nil || n < 1 || n > 5 {
+				return fmt.Errorf("invalid day of week %s", item)
+			}
+			s.NthWeekdays = append(s.NthWeekdays, NthWeekday{Weekday: wd, N: n})
+		default:
+			plain = append(plain, item)
+		}
+	}
+
+	if len(plain) > 0 {
+		sched, err := parser.Parse("0 0 0 * * " + strings.Join(plain, ","))
+		if err != nil {
+			return err
+		}
+		s.Dow = sched.(*cron.SpecSchedule).Dow
+	}
+	return nil
+}
+
+func parseDow(s string) (time.Weekday, error) {
+	if wd, ok := dowNames[strings.ToUpper(s)]; ok {
+		return wd, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 6 {
+		return 0, fmt.Errorf("invalid day of week %s", s)
+	}
+	return time.Weekday(n), nil
+}
+
+// Next conforms to the Schedule interface, it returns the next activation
+// time later than the given time, or the zero time if none is found.
+func (s *QuartzSchedule) Next(t time.Time) time.Time {
+	// Like robfig/cron, convert to the schedule location
+	// and back to the original one.
+	origLocation := t.Location()
+	loc := s.Location
+	if loc == time.Local {
+		loc = t.Location()
+	}
+	if s.Location != time.Local {
+		t = t.In(s.Location)
+	}
+
+	// Start at the earliest possible time, the upcoming second
+	t = t.Add(time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
+
+	for i := 0; i < maxQuartzDays; i++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, loc)
+		if 1<<uint(day.Month())&s.Month == 0 || !s.matchDay(day) {
+			continue
+		}
+		if next := s.nextInDay(day, t); !next.IsZero() {
+			return next.In(origLocation)
+		}
+	}
+
+	return time.Time{}
+}
+
+// nextInDay returns the first activation in the given day not before t.
+func (s *QuartzSchedule) nextInDay(day, t time.Time) time.Time {
+	sameDay := day.Year() == t.Year() && day.YearDay() == t.YearDay()
+	for h := 0; h < 24; h++ {
+		if 1<<uint(h)&s.Hour == 0 || (sameDay && h < t.Hour()) {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if 1<<uint(m)&s.Minute == 0 || (sameDay && h == t.Hour() && m < t.Minute()) {
+				continue
+			}
+			for sec := 0; sec < 60; sec++ {
+				if 1<<uint(sec)&s.Second == 0 {
+					continue
+				}
+				next := time.Date(day.Year(), day.Month(), day.Day(), h, m, sec, 0, day.Location())
+				// Skip the times that don't exist due to daylight saving changes
+				if next.Hour() != h || next.Before(t) {
+					continue
+				}
+				return next
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay returns whether the day matches the day of month and day of
+// week fields, following the robfig/cron rule: if any of them is a star
+// both must match, otherwise any of them.
+func (s *QuartzSchedule) matchDay(day time.Time) bool {
+	domMatch := s.DomStar || s.matchDom(day)
+	dowMatch := s.DowStar || s.matchDow(day)
+	if s.DomStar || s.DowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (s *QuartzSchedule) matchDom(day time.Time) bool {
+	if 1<<uint(day.Day())&s.Dom > 0 {
+		return true
+	}
+
+	dim := daysIn(day.Year(), day.Month())
+	for _, offset := range s.LastDayOffsets {
+		if day.Day() == dim-offset {
+			return true
+		}
+	}
+
+	weekend := func(d int) bool {
+		wd := time.Date(day.Year(), day.Month(), d, 0, 0, 0, 0, time.UTC).Weekday()
+		return wd == time.Saturday || wd == time.Sunday
+	}
+
+	if s.LastWeekday {
+		last := dim
+		for weekend(last) {
+			last--
+		}
+		if day.Day() == last {
+			return true
+		}
+	}
+
+	for _, n := range s.NearestWeekdays {
+		if n > dim {
+			continue
+		}
+		// The nearest weekday never leaves the month
+		nearest := n
+		switch time.Date(day.Year(), day.Month(), n, 0, 0, 0, 0, time.UTC).Weekday() {
+		case time.Saturday:
+			if n == 1 {
+				nearest = n + 2
+			} else {
+				nearest = n - 1
+			}
+		case time.Sunday:
+			if n == dim {
+				nearest = n - 2
+			} else {
+				nearest = n + 1
+			}
+		}
+		if day.Day() == nearest {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *QuartzSchedule) matchDow(day time.Time) bool {
+	if 1<<uint(day.Weekday())&s.Dow > 0 {
+		return true
+	}
+
+	dim := daysIn(day.Year(), day.Month())
+	for _, wd := range s.LastWeekdaysOfWeek {
+		if day.Weekday() == wd && day.Day()+7 > dim {
+			return true
+		}
+	}
+
+	for _, nth := range s.NthWeekdays {
+		if day.Weekday() == nth.Weekday && (day.Day()-1)/7+1 == nth.N {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,145 @@
+package extcron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuartzNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		from     time.Time
+		expected []time.Time
+	}{
+		{
+			name: "last day of month",
+			spec: "0 0 12 L * *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "days before the last day of month",
+			spec: "0 0 12 L-2 * *",
+			from: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 2, 27, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 29, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "nearest weekday to the 15th",
+			spec: "0 30 9 15W * *",
+			from: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				// Saturday 15th, Friday 14th
+				time.Date(2024, 6, 14, 9, 30, 0, 0, time.UTC),
+				// Monday 15th
+				time.Date(2024, 7, 15, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "nearest weekday doesn't leave the month",
+			spec: "0 0 0 1W * *",
+			from: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				// Saturday 1st, Monday 3rd
+				time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last weekday of month",
+			spec: "0 0 18 LW * *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				// Sunday 31st, Friday 29th
+				time.Date(2024, 3, 29, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 30, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "third friday",
+			spec: "0 0 10 ? * FRI#3",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 19, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 16, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last friday",
+			spec: "0 0 10 * * 5L",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 26, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 23, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "mixed with plain values",
+			spec: "0 0 0 1,L * *",
+			from: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "within the same day",
+			spec: "0 */15 * L * *",
+			from: time.Date(2024, 1, 31, 10, 7, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 31, 10, 15, 0, 0, time.UTC),
+				time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "timezone",
+			spec: "CRON_TZ=America/New_York 0 0 20 L * *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				// Still December 31st in New York
+				time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			require.IsType(t, &QuartzSchedule{}, s)
+
+			next := tt.from
+			for _, expected := range tt.expected {
+				next = s.Next(next)
+				assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+			}
+		})
+	}
+}
+
+func TestQuartzParseErrors(t *testing.T) {
+	specs := []string{
+		"0 0 0 L3 * *",
+		"0 0 0 32W * *",
+		"0 0 0 ? * FRI#6",
+		"0 0 0 ? * FOO#1",
+		"0 0 0 ? * 9L",
+		"0 0 25 L * *",
+	}
+
+	for _, spec := range specs {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
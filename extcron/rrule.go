@@ -0,0 +1,583 @@
+package extcron
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of a recurrence rule.
+type Frequency int
+
+const (
+	Yearly Frequency = iota
+	Monthly
+	Weekly
+	Daily
+	Hourly
+	Minutely
+	Secondly
+)
+
+var frequencies = map[string]Frequency{
+	"YEARLY":   Yearly,
+	"MONTHLY":  Monthly,
+	"WEEKLY":   Weekly,
+	"DAILY":    Daily,
+	"HOURLY":   Hourly,
+	"MINUTELY": Minutely,
+	"SECONDLY": Secondly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+const (
+	rruleDateLayout     = "20060102"
+	rruleDateTimeLayout = "20060102T150405"
+
+	// maxRRulePeriods limits the number of periods iterated looking for
+	// the next occurrence, so rules that never match don't loop forever.
+	maxRRulePeriods = 100000
+)
+
+// WeekdayNum is a BYDAY value, a weekday with an optional ordinal
+// inside the month or year, as in 2TU or -1FR.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RRuleSchedule represents a RFC 5545 recurrence rule with its
+// start date and excluded dates.
+//
+// BYYEARDAY and BYWEEKNO are not supported.
+type RRuleSchedule struct {
+	Dtstart    time.Time
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByMonth    []int
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	Wkst       time.Weekday
+	Exdates    []time.Time
+
+	// exdays holds the EXDATE values in DATE form, they exclude the whole day.
+	exdays []string
+}
+
+// ParseRRule parses a recurrence rule in iCalendar syntax, a DTSTART
+// property followed by a RRULE and optional EXDATE properties, separated
+// by spaces or new lines:
+//
+//	DTSTART:20240102T090000 RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU EXDATE:20240227T090000
+//
+// DTSTART is mandatory. Floating dates and times, without Z suffix or
+// TZID parameter, are evaluated in loc.
+func ParseRRule(spec string, loc *time.Location) (*RRuleSchedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	s := &RRuleSchedule{
+		Interval: 1,
+		Wkst:     time.Monday,
+	}
+
+	var rule string
+	var exdates [][2]string
+	for _, line := range strings.Fields(spec) {
+		name, value, found := strings.Cut(line, ":")
+		if !found && strings.Contains(line, "=") {
+			// A bare rule without the RRULE property name
+			name, value = "RRULE", line
+		} else if !found {
+			return nil, fmt.Errorf("invalid rrule line: %s", line)
+		}
+
+		name, params, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(name) {
+		case "DTSTART":
+			t, _, err := parseRRuleTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid DTSTART %s: %s", value, err)
+			}
+			s.Dtstart = t
+		case "RRULE":
+			rule = value
+		case "EXDATE":
+			// Parsed once DTSTART, and its location, is known
+			for _, v := range strings.Split(value, ",") {
+				exdates = append(exdates, [2]string{v, params})
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rrule property: %s", name)
+		}
+	}
+
+	if s.Dtstart.IsZero() {
+		return nil, errors.New("rrule requires a DTSTART")
+	}
+	if rule == "" {
+		return nil, errors.New("rrule requires a RRULE")
+	}
+
+	if err := s.parseRule(strings.ToUpper(rule)); err != nil {
+		return nil, err
+	}
+
+	for _, ex := range exdates {
+		t, isDate, err := parseRRuleTime(ex[0], ex[1], s.Dtstart.Location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXDATE %s: %s", ex[0], err)
+		}
+		if isDate {
+			s.exdays = append(s.exdays, t.Format(time.DateOnly))
+		} else {
+			s.Exdates = append(s.Exdates, t)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *RRuleSchedule) parseRule(rule string) error {
+	freq := false
+	for _, part := range strings.Split(rule, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return fmt.Errorf("invalid RRULE part: %s", part)
+		}
+
+		var err error
+		switch key {
+		case "FREQ":
+			if s.Freq, freq = frequencies[value]; !freq {
+				return fmt.Errorf("invalid FREQ: %s", value)
+			}
+		case "INTERVAL":
+			if s.Interval, err = strconv.Atoi(value); err != nil || s.Interval < 1 {
+				return fmt.Errorf("invalid INTERVAL: %s", value)
+			}
+		case "COUNT":
+			if s.Count, err = strconv.Atoi(value); err != nil || s.Count < 1 {
+				return fmt.Errorf("invalid COUNT: %s", value)
+			}
+		case "UNTIL":
+			until, isDate, err := parseRRuleTime(value, "", s.Dtstart.Location())
+			if err != nil {
+				return fmt.Errorf("invalid UNTIL %s: %s", value, err)
+			}
+			if isDate {
+				// A date includes the whole day
+				until = until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			s.Until = until
+		case "BYMONTH":
+			s.ByMonth, err = parseRRuleInts(value, 1, 12, false)
+		case "BYMONTHDAY":
+			s.ByMonthDay, err = parseRRuleInts(value, 1, 31, true)
+		case "BYHOUR":
+			s.ByHour, err = parseRRuleInts(value, 0, 23, false)
+		case "BYMINUTE":
+			s.ByMinute, err = parseRRuleInts(value, 0, 59, false)
+		case "BYSECOND":
+			s.BySecond, err = parseRRuleInts(value, 0, 59, false)
+		case "BYSETPOS":
+			s.BySetPos, err = parseRRuleInts(value, 1, 366, true)
+		case "BYDAY":
+			s.ByDay, err = parseRRuleWeekdays(value)
+		case "WKST":
+			wkst, ok := weekdays[value]
+			if !ok {
+				return fmt.Errorf("invalid WKST: %s", value)
+			}
+			s.Wkst = wkst
+		default:
+			return fmt.Errorf("unsupported RRULE part: %s", key)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", key, err)
+		}
+	}
+
+	if !freq {
+		return errors.New("RRULE requires a FREQ")
+	}
+	if s.Count > 0 && !s.Until.IsZero() {
+		return errors.New("RRULE can't have both COUNT and UNTIL")
+	}
+	if s.Freq != Monthly && s.Freq != Yearly {
+		for _, wd := range s.ByDay {
+			if wd.N != 0 {
+				return errors.New("BYDAY ordinals are only valid in MONTHLY and YEARLY rules")
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseRRuleTime parses a DATE or DATE-TIME value, returning whether it's a DATE.
+func parseRRuleTime(value string, params string, loc *time.Location) (time.Time, bool, error) {
+	for _, param := range strings.Split(params, ";") {
+		if tzid, ok := strings.CutPrefix(param, "TZID="); ok {
+			l, err := time.LoadLocation(tzid)
+			if err != nil {
+				return time.Time{}, false, err
+			}
+			loc = l
+		}
+	}
+
+	if len(value) == len(rruleDateLayout) {
+		t, err := time.ParseInLocation(rruleDateLayout, value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(rruleDateTimeLayout+"Z", value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation(rruleDateTimeLayout, value, loc)
+	return t, false, err
+}
+
+func parseRRuleInts(value string, min, max int, negative bool) ([]int, error) {
+	var values []int
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("value %d out of range", n)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func parseRRuleWeekdays(value string) ([]WeekdayNum, error) {
+	var values []WeekdayNum
+	for _, v := range strings.Split(value, ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("invalid weekday %s", v)
+		}
+		wd, ok := weekdays[v[len(v)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %s", v)
+		}
+		var n int
+		if ord := v[:len(v)-2]; ord != "" {
+			var err error
+			if n, err = strconv.Atoi(ord); err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("invalid weekday %s", v)
+			}
+		}
+		values = append(values, WeekdayNum{Weekday: wd, N: n})
+	}
+	return values, nil
+}
+
+// Next conforms to the Schedule interface, returning the first occurrence
+// after the given time or the zero time if the rule is over.
+func (s *RRuleSchedule) Next(t time.Time) time.Time {
+	// Occurrences must be counted from the start when COUNT is set,
+	// otherwise jump to the period containing t.
+	start := 0
+	if s.Count == 0 {
+		start = s.periodIndex(t)
+	}
+
+	count := 0
+	for i := start; i < start+maxRRulePeriods; i++ {
+		for _, occ := range s.occurrences(i) {
+			if occ.Before(s.Dtstart) {
+				continue
+			}
+			if !s.Until.IsZero() && occ.After(s.Until) {
+				return time.Time{}
+			}
+			count++
+			if s.Count > 0 && count > s.Count {
+				return time.Time{}
+			}
+			if occ.After(t) && !s.excluded(occ) {
+				return occ
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// periodIndex returns the index of the period that contains t.
+func (s *RRuleSchedule) periodIndex(t time.Time) int {
+	d := s.Dtstart
+	t = t.In(d.Location())
+
+	var n int
+	switch s.Freq {
+	case Yearly:
+		n = t.Year() - d.Year()
+	case Monthly:
+		n = (t.Year()-d.Year())*12 + int(t.Month()) - int(d.Month())
+	case Weekly:
+		n = civilDays(s.weekStart(), civilDate(t)) / 7
+	case Daily:
+		n = civilDays(civilDate(d), civilDate(t))
+	default:
+		n = int(t.Sub(s.subDailyBase()) / s.unit())
+	}
+
+	if n < 0 {
+		return 0
+	}
+	return n / s.Interval
+}
+
+// occurrences returns the sorted occurrences of the rule in the i-th period.
+func (s *RRuleSchedule) occurrences(i int) []time.Time {
+	d := s.Dtstart
+	loc := d.Location()
+	step := i * s.Interval
+
+	var occs []time.Time
+	if s.Freq >= Hourly {
+		ps := s.subDailyBase().Add(time.Duration(step) * s.unit())
+		local := ps.In(loc)
+		if !s.matchDay(civilDate(local)) {
+			return nil
+		}
+		if len(s.ByHour) > 0 && !slices.Contains(s.ByHour, local.Hour()) {
+			return nil
+		}
+
+		minutes := []int{local.Minute()}
+		if s.Freq == Hourly {
+			minutes = s.byOrDefault(s.ByMinute, d.Minute())
+		} else if len(s.ByMinute) > 0 && !slices.Contains(s.ByMinute, local.Minute()) {
+			return nil
+		}
+		seconds := []int{local.Second()}
+		if s.Freq != Secondly {
+			seconds = s.byOrDefault(s.BySecond, d.Second())
+		} else if len(s.BySecond) > 0 && !slices.Contains(s.BySecond, local.Second()) {
+			return nil
+		}
+
+		for _, m := range minutes {
+			for _, sec := range seconds {
+				offset := time.Duration(m-local.Minute())*time.Minute + time.Duration(sec-local.Second())*time.Second
+				occs = append(occs, ps.Add(offset))
+			}
+		}
+	} else {
+		var from, to time.Time
+		switch s.Freq {
+		case Yearly:
+			from = time.Date(d.Year()+step, 1, 1, 0, 0, 0, 0, time.UTC)
+			to = from.AddDate(1, 0, 0)
+		case Monthly:
+			from = time.Date(d.Year(), d.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+			to = from.AddDate(0, 1, 0)
+		case Weekly:
+			from = s.weekStart().AddDate(0, 0, 7*step)
+			to = from.AddDate(0, 0, 7)
+		case Daily:
+			from = civilDate(d).AddDate(0, 0, step)
+			to = from.AddDate(0, 0, 1)
+		}
+
+		hours := s.byOrDefault(s.ByHour, d.Hour())
+		minutes := s.byOrDefault(s.ByMinute, d.Minute())
+		seconds := s.byOrDefault(s.BySecond, d.Second())
+		for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+			if !s.matchDay(day) {
+				continue
+			}
+			for _, h := range hours {
+				for _, m := range minutes {
+					for _, sec := range seconds {
+						occs = append(occs, time.Date(day.Year(), day.Month(), day.Day(), h, m, sec, 0, loc))
+					}
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(occs, func(a, b time.Time) int { return a.Compare(b) })
+
+	if len(s.BySetPos) > 0 {
+		var selected []time.Time
+		for _, pos := range s.BySetPos {
+			idx := pos - 1
+			if pos < 0 {
+				idx = len(occs) + pos
+			}
+			if idx >= 0 && idx < len(occs) {
+				selected = append(selected, occs[idx])
+			}
+		}
+		slices.SortFunc(selected, func(a, b time.Time) int { return a.Compare(b) })
+		occs = slices.CompactFunc(selected, time.Time.Equal)
+	}
+
+	return occs
+}
+
+// matchDay returns whether the given civil date matches the day rules.
+func (s *RRuleSchedule) matchDay(day time.Time) bool {
+	d := s.Dtstart
+
+	if len(s.ByMonth) > 0 && !slices.Contains(s.ByMonth, int(day.Month())) {
+		return false
+	}
+
+	if len(s.ByMonthDay) > 0 {
+		dim := daysIn(day.Year(), day.Month())
+		match := false
+		for _, md := range s.ByMonthDay {
+			if md == day.Day() || (md < 0 && dim+md+1 == day.Day()) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+
+	if len(s.ByDay) > 0 && !s.matchWeekday(day) {
+		return false
+	}
+
+	// Without BYxxx rules the missing values are taken from DTSTART
+	switch s.Freq {
+	case Yearly:
+		if len(s.ByMonth) == 0 && len(s.ByMonthDay) == 0 && len(s.ByDay) == 0 {
+			return day.Month() == d.Month() && day.Day() == d.Day()
+		}
+		if len(s.ByMonthDay) == 0 && len(s.ByDay) == 0 {
+			return day.Day() == d.Day()
+		}
+	case Monthly:
+		if len(s.ByMonthDay) == 0 && len(s.ByDay) == 0 {
+			return day.Day() == d.Day()
+		}
+	case Weekly:
+		if len(s.ByDay) == 0 {
+			return day.Weekday() == d.Weekday()
+		}
+	}
+
+	return true
+}
+
+// matchWeekday returns whether the given civil date matches BYDAY, ordinals
+// count inside the month in monthly rules, or yearly rules with BYMONTH,
+// and inside the year otherwise.
+func (s *RRuleSchedule) matchWeekday(day time.Time) bool {
+	inMonth := s.Freq == Monthly || (s.Freq == Yearly && len(s.ByMonth) > 0)
+
+	for _, wd := range s.ByDay {
+		if wd.Weekday != day.Weekday() {
+			continue
+		}
+		if wd.N == 0 {
+			return true
+		}
+
+		pos, total := day.YearDay(), time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		if inMonth {
+			pos, total = day.Day(), daysIn(day.Year(), day.Month())
+		}
+		if wd.N > 0 && (pos-1)/7+1 == wd.N {
+			return true
+		}
+		if wd.N < 0 && (total-pos)/7+1 == -wd.N {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *RRuleSchedule) excluded(t time.Time) bool {
+	for _, ex := range s.Exdates {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+	return slices.Contains(s.exdays, t.In(s.Dtstart.Location()).Format(time.DateOnly))
+}
+
+func (s *RRuleSchedule) byOrDefault(values []int, def int) []int {
+	if len(values) > 0 {
+		return values
+	}
+	return []int{def}
+}
+
+// unit returns the duration of a sub-daily period.
+func (s *RRuleSchedule) unit() time.Duration {
+	switch s.Freq {
+	case Hourly:
+		return time.Hour
+	case Minutely:
+		return time.Minute
+	}
+	return time.Second
+}
+
+// subDailyBase returns DTSTART truncated to the start of its sub-daily period.
+func (s *RRuleSchedule) subDailyBase() time.Time {
+	d := s.Dtstart
+	switch s.Freq {
+	case Hourly:
+		return time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), 0, 0, 0, d.Location())
+	case Minutely:
+		return time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), 0, 0, d.Location())
+	}
+	return d.Truncate(time.Second)
+}
+
+// weekStart returns the civil date of the first day of the DTSTART week.
+func (s *RRuleSchedule) weekStart() time.Time {
+	day := civilDate(s.Dtstart)
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(s.Wkst) + 7) % 7))
+}
+
+// civilDate returns the date of t as midnight UTC, to do day arithmetic
+// without daylight saving changes.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func civilDays(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,136 @@
+package extcron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRuleNext(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		spec     string
+		from     time.Time
+		expected []time.Time
+	}{
+		{
+			name: "every second tuesday",
+			spec: "@rrule DTSTART:20240102T090000Z RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "second tuesday of the month",
+			spec: "@rrule DTSTART:20240101T090000Z RRULE:FREQ=MONTHLY;BYDAY=2TU",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 13, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 12, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last business day of the month",
+			spec: "@rrule DTSTART:20240101T170000Z RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 31, 17, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 17, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 29, 17, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 30, 17, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "every 90 minutes starting 08:15",
+			spec: "@rrule DTSTART:20240101T081500Z RRULE:FREQ=MINUTELY;INTERVAL=90",
+			from: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 1, 9, 45, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 11, 15, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 12, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "count",
+			spec: "@rrule DTSTART:20240101T100000Z RRULE:FREQ=DAILY;COUNT=2",
+			from: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+				{},
+			},
+		},
+		{
+			name: "until and exdate",
+			spec: "@rrule DTSTART:20240101T100000Z\nRRULE:FREQ=DAILY;UNTIL=20240104\nEXDATE:20240102T100000Z",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC),
+				{},
+			},
+		},
+		{
+			name: "floating times use the spec timezone",
+			spec: "CRON_TZ=Europe/Madrid @rrule DTSTART:20240325T090000 RRULE:FREQ=DAILY",
+			from: time.Date(2024, 3, 30, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 3, 31, 9, 0, 0, 0, madrid),
+				time.Date(2024, 4, 1, 9, 0, 0, 0, madrid),
+			},
+		},
+		{
+			name: "tzid",
+			spec: "@rrule DTSTART;TZID=Europe/Madrid:20240101T090000 RRULE:FREQ=YEARLY",
+			from: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2025, 1, 1, 9, 0, 0, 0, madrid),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+
+			next := tt.from
+			for _, expected := range tt.expected {
+				next = s.Next(next)
+				assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+				if next.IsZero() {
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRRuleParseErrors(t *testing.T) {
+	specs := []string{
+		"@rrule RRULE:FREQ=DAILY",
+		"@rrule DTSTART:20240101T090000Z",
+		"@rrule DTSTART:20240101T090000Z RRULE:INTERVAL=2",
+		"@rrule DTSTART:20240101T090000Z RRULE:FREQ=FORTNIGHTLY",
+		"@rrule DTSTART:20240101T090000Z RRULE:FREQ=DAILY;COUNT=2;UNTIL=20240110",
+		"@rrule DTSTART:20240101T090000Z RRULE:FREQ=WEEKLY;BYDAY=2TU",
+		"@rrule DTSTART:20240101T090000Z RRULE:FREQ=DAILY;BYHOUR=24",
+		"@rrule DTSTART:20240101T090000Z RRULE:FREQ=YEARLY;BYWEEKNO=20",
+		"CRON_TZ=Nowhere/Land @rrule DTSTART:20240101T090000 RRULE:FREQ=DAILY",
+	}
+
+	for _, spec := range specs {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
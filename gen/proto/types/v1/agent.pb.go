@@ -27,6 +27,7 @@ type AgentRunRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	Execution     *Execution             `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
+	Parent        *ParentExecution       `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -75,6 +76,13 @@ func (x *AgentRunRequest) GetExecution() *Execution {
 	return nil
 }
 
+func (x *AgentRunRequest) GetParent() *ParentExecution {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
 type AgentRunStream struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Execution     *Execution             `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
@@ -175,10 +183,11 @@ var File_types_v1_agent_proto protoreflect.FileDescriptor
 
 const file_types_v1_agent_proto_rawDesc = "" +
 	"\n" +
-	"\x14types/v1/agent.proto\x12\btypes.v1\x1a\x14types/v1/dkron.proto\"e\n" +
+	"\x14types/v1/agent.proto\x12\btypes.v1\x1a\x14types/v1/dkron.proto\x1a\x17types/v1/executor.proto\"\x98\x01\n" +
 	"\x0fAgentRunRequest\x12\x1f\n" +
 	"\x03job\x18\x01 \x01(\v2\r.types.v1.JobR\x03job\x121\n" +
-	"\texecution\x18\x02 \x01(\v2\x13.types.v1.ExecutionR\texecution\"C\n" +
+	"\texecution\x18\x02 \x01(\v2\x13.types.v1.ExecutionR\texecution\x121\n" +
+	"\x06parent\x18\x03 \x01(\v2\x19.types.v1.ParentExecutionR\x06parent\"C\n" +
 	"\x0eAgentRunStream\x121\n" +
 	"\texecution\x18\x01 \x01(\v2\x13.types.v1.ExecutionR\texecution\"@\n" +
 	"\x10AgentRunResponse\x12\x12\n" +
@@ -208,18 +217,20 @@ var file_types_v1_agent_proto_goTypes = []any{
 	(*AgentRunResponse)(nil), // 2: types.v1.AgentRunResponse
 	(*Job)(nil),              // 3: types.v1.Job
 	(*Execution)(nil),        // 4: types.v1.Execution
+	(*ParentExecution)(nil),  // 5: types.v1.ParentExecution
 }
 var file_types_v1_agent_proto_depIdxs = []int32{
 	3, // 0: types.v1.AgentRunRequest.job:type_name -> types.v1.Job
 	4, // 1: types.v1.AgentRunRequest.execution:type_name -> types.v1.Execution
-	4, // 2: types.v1.AgentRunStream.execution:type_name -> types.v1.Execution
-	0, // 3: types.v1.AgentService.AgentRun:input_type -> types.v1.AgentRunRequest
-	1, // 4: types.v1.AgentService.AgentRun:output_type -> types.v1.AgentRunStream
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 2: types.v1.AgentRunRequest.parent:type_name -> types.v1.ParentExecution
+	4, // 3: types.v1.AgentRunStream.execution:type_name -> types.v1.Execution
+	0, // 4: types.v1.AgentService.AgentRun:input_type -> types.v1.AgentRunRequest
+	1, // 5: types.v1.AgentService.AgentRun:output_type -> types.v1.AgentRunStream
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_types_v1_agent_proto_init() }
@@ -228,6 +239,7 @@ func file_types_v1_agent_proto_init() {
 		return
 	}
 	file_types_v1_dkron_proto_init()
+	file_types_v1_executor_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -26,34 +26,57 @@ const (
 )
 
 type Job struct {
-	state          protoimpl.MessageState   `protogen:"open.v1"`
-	Name           string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Timezone       string                   `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
-	Schedule       string                   `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
-	Owner          string                   `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
-	OwnerEmail     string                   `protobuf:"bytes,8,opt,name=owner_email,json=ownerEmail,proto3" json:"owner_email,omitempty"`
-	SuccessCount   int32                    `protobuf:"varint,9,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
-	ErrorCount     int32                    `protobuf:"varint,10,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
-	Disabled       bool                     `protobuf:"varint,11,opt,name=disabled,proto3" json:"disabled,omitempty"`
-	Tags           map[string]string        `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Retries        uint32                   `protobuf:"varint,13,opt,name=retries,proto3" json:"retries,omitempty"`
-	DependentJobs  []string                 `protobuf:"bytes,14,rep,name=dependent_jobs,json=dependentJobs,proto3" json:"dependent_jobs,omitempty"`
-	ParentJob      string                   `protobuf:"bytes,15,opt,name=parent_job,json=parentJob,proto3" json:"parent_job,omitempty"`
-	Concurrency    string                   `protobuf:"bytes,16,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
-	Executor       string                   `protobuf:"bytes,17,opt,name=executor,proto3" json:"executor,omitempty"`
-	ExecutorConfig map[string]string        `protobuf:"bytes,18,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Status         string                   `protobuf:"bytes,19,opt,name=status,proto3" json:"status,omitempty"`
-	Metadata       map[string]string        `protobuf:"bytes,20,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	LastSuccess    *Job_NullableTime        `protobuf:"bytes,25,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
-	LastError      *Job_NullableTime        `protobuf:"bytes,26,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
-	Next           *timestamppb.Timestamp   `protobuf:"bytes,23,opt,name=next,proto3" json:"next,omitempty"`
-	Displayname    string                   `protobuf:"bytes,24,opt,name=displayname,proto3" json:"displayname,omitempty"`
-	Processors     map[string]*PluginConfig `protobuf:"bytes,27,rep,name=processors,proto3" json:"processors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Ephemeral      bool                     `protobuf:"varint,28,opt,name=ephemeral,proto3" json:"ephemeral,omitempty"`
-	ExpiresAt      *Job_NullableTime        `protobuf:"bytes,29,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	StartsAt       *Job_NullableTime        `protobuf:"bytes,30,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state               protoimpl.MessageState   `protogen:"open.v1"`
+	Name                string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Timezone            string                   `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Schedule            string                   `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Owner               string                   `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
+	OwnerEmail          string                   `protobuf:"bytes,8,opt,name=owner_email,json=ownerEmail,proto3" json:"owner_email,omitempty"`
+	SuccessCount        int32                    `protobuf:"varint,9,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	ErrorCount          int32                    `protobuf:"varint,10,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	Disabled            bool                     `protobuf:"varint,11,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Tags                map[string]string        `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Retries             uint32                   `protobuf:"varint,13,opt,name=retries,proto3" json:"retries,omitempty"`
+	DependentJobs       []string                 `protobuf:"bytes,14,rep,name=dependent_jobs,json=dependentJobs,proto3" json:"dependent_jobs,omitempty"`
+	ParentJob           string                   `protobuf:"bytes,15,opt,name=parent_job,json=parentJob,proto3" json:"parent_job,omitempty"`
+	Concurrency         string                   `protobuf:"bytes,16,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Executor            string                   `protobuf:"bytes,17,opt,name=executor,proto3" json:"executor,omitempty"`
+	ExecutorConfig      map[string]string        `protobuf:"bytes,18,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status              string                   `protobuf:"bytes,19,opt,name=status,proto3" json:"status,omitempty"`
+	Metadata            map[string]string        `protobuf:"bytes,20,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	LastSuccess         *Job_NullableTime        `protobuf:"bytes,25,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	LastError           *Job_NullableTime        `protobuf:"bytes,26,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Next                *timestamppb.Timestamp   `protobuf:"bytes,23,opt,name=next,proto3" json:"next,omitempty"`
+	Displayname         string                   `protobuf:"bytes,24,opt,name=displayname,proto3" json:"displayname,omitempty"`
+	Processors          map[string]*PluginConfig `protobuf:"bytes,27,rep,name=processors,proto3" json:"processors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Ephemeral           bool                     `protobuf:"varint,28,opt,name=ephemeral,proto3" json:"ephemeral,omitempty"`
+	ExpiresAt           *Job_NullableTime        `protobuf:"bytes,29,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	StartsAt            *Job_NullableTime        `protobuf:"bytes,30,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	MisfirePolicy       string                   `protobuf:"bytes,31,opt,name=misfire_policy,json=misfirePolicy,proto3" json:"misfire_policy,omitempty"`
+	MisfireGrace        string                   `protobuf:"bytes,32,opt,name=misfire_grace,json=misfireGrace,proto3" json:"misfire_grace,omitempty"`
+	Calendars           []string                 `protobuf:"bytes,33,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	ScheduleFormat      string                   `protobuf:"bytes,34,opt,name=schedule_format,json=scheduleFormat,proto3" json:"schedule_format,omitempty"`
+	DstPolicy           string                   `protobuf:"bytes,35,opt,name=dst_policy,json=dstPolicy,proto3" json:"dst_policy,omitempty"`
+	Jitter              string                   `protobuf:"bytes,36,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	JitterSeed          int64                    `protobuf:"varint,37,opt,name=jitter_seed,json=jitterSeed,proto3" json:"jitter_seed,omitempty"`
+	Schedules           []*JobSchedule           `protobuf:"bytes,38,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	RunWindows          []*RunWindow             `protobuf:"bytes,39,rep,name=run_windows,json=runWindows,proto3" json:"run_windows,omitempty"`
+	RunWindowPolicy     string                   `protobuf:"bytes,40,opt,name=run_window_policy,json=runWindowPolicy,proto3" json:"run_window_policy,omitempty"`
+	MaxConcurrency      uint32                   `protobuf:"varint,41,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	Pool                string                   `protobuf:"bytes,42,opt,name=pool,proto3" json:"pool,omitempty"`
+	PoolSlots           uint32                   `protobuf:"varint,43,opt,name=pool_slots,json=poolSlots,proto3" json:"pool_slots,omitempty"`
+	Priority            int32                    `protobuf:"varint,44,opt,name=priority,proto3" json:"priority,omitempty"`
+	SelectionStrategy   string                   `protobuf:"bytes,45,opt,name=selection_strategy,json=selectionStrategy,proto3" json:"selection_strategy,omitempty"`
+	SpreadTag           string                   `protobuf:"bytes,46,opt,name=spread_tag,json=spreadTag,proto3" json:"spread_tag,omitempty"`
+	ParentJobs          []string                 `protobuf:"bytes,47,rep,name=parent_jobs,json=parentJobs,proto3" json:"parent_jobs,omitempty"`
+	TriggerRule         string                   `protobuf:"bytes,48,opt,name=trigger_rule,json=triggerRule,proto3" json:"trigger_rule,omitempty"`
+	ParentConditions    map[string]string        `protobuf:"bytes,49,rep,name=parent_conditions,json=parentConditions,proto3" json:"parent_conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DependentConditions map[string]string        `protobuf:"bytes,50,rep,name=dependent_conditions,json=dependentConditions,proto3" json:"dependent_conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Map                 bool                     `protobuf:"varint,51,opt,name=map,proto3" json:"map,omitempty"`
+	MaxParallel         uint32                   `protobuf:"varint,52,opt,name=max_parallel,json=maxParallel,proto3" json:"max_parallel,omitempty"`
+	MapSuccessRule      string                   `protobuf:"bytes,53,opt,name=map_success_rule,json=mapSuccessRule,proto3" json:"map_success_rule,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Job) Reset() {
@@ -261,6 +284,287 @@ func (x *Job) GetStartsAt() *Job_NullableTime {
 	return nil
 }
 
+func (x *Job) GetMisfirePolicy() string {
+	if x != nil {
+		return x.MisfirePolicy
+	}
+	return ""
+}
+
+func (x *Job) GetMisfireGrace() string {
+	if x != nil {
+		return x.MisfireGrace
+	}
+	return ""
+}
+
+func (x *Job) GetCalendars() []string {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+func (x *Job) GetScheduleFormat() string {
+	if x != nil {
+		return x.ScheduleFormat
+	}
+	return ""
+}
+
+func (x *Job) GetDstPolicy() string {
+	if x != nil {
+		return x.DstPolicy
+	}
+	return ""
+}
+
+func (x *Job) GetJitter() string {
+	if x != nil {
+		return x.Jitter
+	}
+	return ""
+}
+
+func (x *Job) GetJitterSeed() int64 {
+	if x != nil {
+		return x.JitterSeed
+	}
+	return 0
+}
+
+func (x *Job) GetSchedules() []*JobSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+func (x *Job) GetRunWindows() []*RunWindow {
+	if x != nil {
+		return x.RunWindows
+	}
+	return nil
+}
+
+func (x *Job) GetRunWindowPolicy() string {
+	if x != nil {
+		return x.RunWindowPolicy
+	}
+	return ""
+}
+
+func (x *Job) GetMaxConcurrency() uint32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *Job) GetPool() string {
+	if x != nil {
+		return x.Pool
+	}
+	return ""
+}
+
+func (x *Job) GetPoolSlots() uint32 {
+	if x != nil {
+		return x.PoolSlots
+	}
+	return 0
+}
+
+func (x *Job) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Job) GetSelectionStrategy() string {
+	if x != nil {
+		return x.SelectionStrategy
+	}
+	return ""
+}
+
+func (x *Job) GetSpreadTag() string {
+	if x != nil {
+		return x.SpreadTag
+	}
+	return ""
+}
+
+func (x *Job) GetParentJobs() []string {
+	if x != nil {
+		return x.ParentJobs
+	}
+	return nil
+}
+
+func (x *Job) GetTriggerRule() string {
+	if x != nil {
+		return x.TriggerRule
+	}
+	return ""
+}
+
+func (x *Job) GetParentConditions() map[string]string {
+	if x != nil {
+		return x.ParentConditions
+	}
+	return nil
+}
+
+func (x *Job) GetDependentConditions() map[string]string {
+	if x != nil {
+		return x.DependentConditions
+	}
+	return nil
+}
+
+func (x *Job) GetMap() bool {
+	if x != nil {
+		return x.Map
+	}
+	return false
+}
+
+func (x *Job) GetMaxParallel() uint32 {
+	if x != nil {
+		return x.MaxParallel
+	}
+	return 0
+}
+
+func (x *Job) GetMapSuccessRule() string {
+	if x != nil {
+		return x.MapSuccessRule
+	}
+	return ""
+}
+
+type JobSchedule struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Schedule       string                 `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Timezone       string                 `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	ExecutorConfig map[string]string      `protobuf:"bytes,3,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *JobSchedule) Reset() {
+	*x = JobSchedule{}
+	mi := &file_types_v1_dkron_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobSchedule) ProtoMessage() {}
+
+func (x *JobSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobSchedule.ProtoReflect.Descriptor instead.
+func (*JobSchedule) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *JobSchedule) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *JobSchedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *JobSchedule) GetExecutorConfig() map[string]string {
+	if x != nil {
+		return x.ExecutorConfig
+	}
+	return nil
+}
+
+type RunWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []string               `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	Start         string                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunWindow) Reset() {
+	*x = RunWindow{}
+	mi := &file_types_v1_dkron_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunWindow) ProtoMessage() {}
+
+func (x *RunWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunWindow.ProtoReflect.Descriptor instead.
+func (*RunWindow) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RunWindow) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *RunWindow) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *RunWindow) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
 type PluginConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Config        map[string]string      `protobuf:"bytes,1,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -270,7 +574,7 @@ type PluginConfig struct {
 
 func (x *PluginConfig) Reset() {
 	*x = PluginConfig{}
-	mi := &file_types_v1_dkron_proto_msgTypes[1]
+	mi := &file_types_v1_dkron_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -282,7 +586,7 @@ func (x *PluginConfig) String() string {
 func (*PluginConfig) ProtoMessage() {}
 
 func (x *PluginConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[1]
+	mi := &file_types_v1_dkron_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +599,7 @@ func (x *PluginConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginConfig.ProtoReflect.Descriptor instead.
 func (*PluginConfig) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{1}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PluginConfig) GetConfig() map[string]string {
@@ -314,7 +618,7 @@ type SetJobRequest struct {
 
 func (x *SetJobRequest) Reset() {
 	*x = SetJobRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[2]
+	mi := &file_types_v1_dkron_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -326,7 +630,7 @@ func (x *SetJobRequest) String() string {
 func (*SetJobRequest) ProtoMessage() {}
 
 func (x *SetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[2]
+	mi := &file_types_v1_dkron_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -339,7 +643,7 @@ func (x *SetJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetJobRequest.ProtoReflect.Descriptor instead.
 func (*SetJobRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{2}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SetJobRequest) GetJob() *Job {
@@ -358,7 +662,7 @@ type SetJobResponse struct {
 
 func (x *SetJobResponse) Reset() {
 	*x = SetJobResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[3]
+	mi := &file_types_v1_dkron_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -370,7 +674,7 @@ func (x *SetJobResponse) String() string {
 func (*SetJobResponse) ProtoMessage() {}
 
 func (x *SetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[3]
+	mi := &file_types_v1_dkron_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -383,7 +687,7 @@ func (x *SetJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetJobResponse.ProtoReflect.Descriptor instead.
 func (*SetJobResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{3}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SetJobResponse) GetJob() *Job {
@@ -402,7 +706,7 @@ type DeleteJobRequest struct {
 
 func (x *DeleteJobRequest) Reset() {
 	*x = DeleteJobRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[4]
+	mi := &file_types_v1_dkron_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -414,7 +718,7 @@ func (x *DeleteJobRequest) String() string {
 func (*DeleteJobRequest) ProtoMessage() {}
 
 func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[4]
+	mi := &file_types_v1_dkron_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +731,7 @@ func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
 func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{4}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteJobRequest) GetJobName() string {
@@ -446,7 +750,7 @@ type DeleteJobResponse struct {
 
 func (x *DeleteJobResponse) Reset() {
 	*x = DeleteJobResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[5]
+	mi := &file_types_v1_dkron_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -458,7 +762,7 @@ func (x *DeleteJobResponse) String() string {
 func (*DeleteJobResponse) ProtoMessage() {}
 
 func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[5]
+	mi := &file_types_v1_dkron_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +775,7 @@ func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
 func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{5}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteJobResponse) GetJob() *Job {
@@ -490,7 +794,7 @@ type GetJobRequest struct {
 
 func (x *GetJobRequest) Reset() {
 	*x = GetJobRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[6]
+	mi := &file_types_v1_dkron_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +806,7 @@ func (x *GetJobRequest) String() string {
 func (*GetJobRequest) ProtoMessage() {}
 
 func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[6]
+	mi := &file_types_v1_dkron_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +819,7 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
 func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{6}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetJobRequest) GetJobName() string {
@@ -534,7 +838,7 @@ type GetJobResponse struct {
 
 func (x *GetJobResponse) Reset() {
 	*x = GetJobResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[7]
+	mi := &file_types_v1_dkron_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -546,7 +850,7 @@ func (x *GetJobResponse) String() string {
 func (*GetJobResponse) ProtoMessage() {}
 
 func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[7]
+	mi := &file_types_v1_dkron_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -559,7 +863,7 @@ func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
 func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{7}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetJobResponse) GetJob() *Job {
@@ -579,13 +883,19 @@ type Execution struct {
 	Attempt       uint32                 `protobuf:"varint,6,opt,name=attempt,proto3" json:"attempt,omitempty"`
 	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
 	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Skipped       bool                   `protobuf:"varint,9,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ScheduledAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	JitterDelay   string                 `protobuf:"bytes,12,opt,name=jitter_delay,json=jitterDelay,proto3" json:"jitter_delay,omitempty"`
+	WorkflowRunId string                 `protobuf:"bytes,13,opt,name=workflow_run_id,json=workflowRunId,proto3" json:"workflow_run_id,omitempty"`
+	Result        []byte                 `protobuf:"bytes,14,opt,name=result,proto3" json:"result,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Execution) Reset() {
 	*x = Execution{}
-	mi := &file_types_v1_dkron_proto_msgTypes[8]
+	mi := &file_types_v1_dkron_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -597,7 +907,7 @@ func (x *Execution) String() string {
 func (*Execution) ProtoMessage() {}
 
 func (x *Execution) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[8]
+	mi := &file_types_v1_dkron_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +920,7 @@ func (x *Execution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Execution.ProtoReflect.Descriptor instead.
 func (*Execution) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{8}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Execution) GetJobName() string {
@@ -669,16 +979,58 @@ func (x *Execution) GetFinishedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type ExecutionDoneRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Execution     *Execution             `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+func (x *Execution) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *Execution) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Execution) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *Execution) GetJitterDelay() string {
+	if x != nil {
+		return x.JitterDelay
+	}
+	return ""
+}
+
+func (x *Execution) GetWorkflowRunId() string {
+	if x != nil {
+		return x.WorkflowRunId
+	}
+	return ""
+}
+
+func (x *Execution) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ExecutionDoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Execution     *Execution             `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExecutionDoneRequest) Reset() {
 	*x = ExecutionDoneRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[9]
+	mi := &file_types_v1_dkron_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -690,7 +1042,7 @@ func (x *ExecutionDoneRequest) String() string {
 func (*ExecutionDoneRequest) ProtoMessage() {}
 
 func (x *ExecutionDoneRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[9]
+	mi := &file_types_v1_dkron_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -703,7 +1055,7 @@ func (x *ExecutionDoneRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecutionDoneRequest.ProtoReflect.Descriptor instead.
 func (*ExecutionDoneRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{9}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ExecutionDoneRequest) GetExecution() *Execution {
@@ -723,7 +1075,7 @@ type ExecutionDoneResponse struct {
 
 func (x *ExecutionDoneResponse) Reset() {
 	*x = ExecutionDoneResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[10]
+	mi := &file_types_v1_dkron_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -735,7 +1087,7 @@ func (x *ExecutionDoneResponse) String() string {
 func (*ExecutionDoneResponse) ProtoMessage() {}
 
 func (x *ExecutionDoneResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[10]
+	mi := &file_types_v1_dkron_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +1100,7 @@ func (x *ExecutionDoneResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecutionDoneResponse.ProtoReflect.Descriptor instead.
 func (*ExecutionDoneResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{10}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ExecutionDoneResponse) GetFrom() string {
@@ -768,13 +1120,15 @@ func (x *ExecutionDoneResponse) GetPayload() []byte {
 type RunJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	JobName       string                 `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Nodes         []string               `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Tags          map[string]string      `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RunJobRequest) Reset() {
 	*x = RunJobRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[11]
+	mi := &file_types_v1_dkron_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -786,7 +1140,7 @@ func (x *RunJobRequest) String() string {
 func (*RunJobRequest) ProtoMessage() {}
 
 func (x *RunJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[11]
+	mi := &file_types_v1_dkron_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -799,7 +1153,7 @@ func (x *RunJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunJobRequest.ProtoReflect.Descriptor instead.
 func (*RunJobRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{11}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RunJobRequest) GetJobName() string {
@@ -809,6 +1163,20 @@ func (x *RunJobRequest) GetJobName() string {
 	return ""
 }
 
+func (x *RunJobRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *RunJobRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type RunJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
@@ -818,7 +1186,7 @@ type RunJobResponse struct {
 
 func (x *RunJobResponse) Reset() {
 	*x = RunJobResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[12]
+	mi := &file_types_v1_dkron_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -830,7 +1198,7 @@ func (x *RunJobResponse) String() string {
 func (*RunJobResponse) ProtoMessage() {}
 
 func (x *RunJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[12]
+	mi := &file_types_v1_dkron_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +1211,7 @@ func (x *RunJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunJobResponse.ProtoReflect.Descriptor instead.
 func (*RunJobResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{12}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RunJobResponse) GetJob() *Job {
@@ -862,7 +1230,7 @@ type DeleteExecutionsRequest struct {
 
 func (x *DeleteExecutionsRequest) Reset() {
 	*x = DeleteExecutionsRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[13]
+	mi := &file_types_v1_dkron_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -874,7 +1242,7 @@ func (x *DeleteExecutionsRequest) String() string {
 func (*DeleteExecutionsRequest) ProtoMessage() {}
 
 func (x *DeleteExecutionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[13]
+	mi := &file_types_v1_dkron_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -887,7 +1255,7 @@ func (x *DeleteExecutionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteExecutionsRequest.ProtoReflect.Descriptor instead.
 func (*DeleteExecutionsRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{13}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeleteExecutionsRequest) GetJobName() string {
@@ -906,7 +1274,7 @@ type DeleteExecutionsResponse struct {
 
 func (x *DeleteExecutionsResponse) Reset() {
 	*x = DeleteExecutionsResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[14]
+	mi := &file_types_v1_dkron_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -918,7 +1286,7 @@ func (x *DeleteExecutionsResponse) String() string {
 func (*DeleteExecutionsResponse) ProtoMessage() {}
 
 func (x *DeleteExecutionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[14]
+	mi := &file_types_v1_dkron_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -931,7 +1299,7 @@ func (x *DeleteExecutionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteExecutionsResponse.ProtoReflect.Descriptor instead.
 func (*DeleteExecutionsResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{14}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeleteExecutionsResponse) GetJob() *Job {
@@ -950,7 +1318,7 @@ type ToggleJobRequest struct {
 
 func (x *ToggleJobRequest) Reset() {
 	*x = ToggleJobRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[15]
+	mi := &file_types_v1_dkron_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -962,7 +1330,7 @@ func (x *ToggleJobRequest) String() string {
 func (*ToggleJobRequest) ProtoMessage() {}
 
 func (x *ToggleJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[15]
+	mi := &file_types_v1_dkron_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -975,7 +1343,7 @@ func (x *ToggleJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleJobRequest.ProtoReflect.Descriptor instead.
 func (*ToggleJobRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{15}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ToggleJobRequest) GetJobName() string {
@@ -994,7 +1362,7 @@ type ToggleJobResponse struct {
 
 func (x *ToggleJobResponse) Reset() {
 	*x = ToggleJobResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[16]
+	mi := &file_types_v1_dkron_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1006,7 +1374,7 @@ func (x *ToggleJobResponse) String() string {
 func (*ToggleJobResponse) ProtoMessage() {}
 
 func (x *ToggleJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[16]
+	mi := &file_types_v1_dkron_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1019,7 +1387,7 @@ func (x *ToggleJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleJobResponse.ProtoReflect.Descriptor instead.
 func (*ToggleJobResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{16}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ToggleJobResponse) GetJob() *Job {
@@ -1043,7 +1411,7 @@ type RaftServer struct {
 
 func (x *RaftServer) Reset() {
 	*x = RaftServer{}
-	mi := &file_types_v1_dkron_proto_msgTypes[17]
+	mi := &file_types_v1_dkron_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1055,7 +1423,7 @@ func (x *RaftServer) String() string {
 func (*RaftServer) ProtoMessage() {}
 
 func (x *RaftServer) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[17]
+	mi := &file_types_v1_dkron_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1068,7 +1436,7 @@ func (x *RaftServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RaftServer.ProtoReflect.Descriptor instead.
 func (*RaftServer) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{17}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RaftServer) GetId() string {
@@ -1123,7 +1491,7 @@ type RaftGetConfigurationResponse struct {
 
 func (x *RaftGetConfigurationResponse) Reset() {
 	*x = RaftGetConfigurationResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[18]
+	mi := &file_types_v1_dkron_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1135,7 +1503,7 @@ func (x *RaftGetConfigurationResponse) String() string {
 func (*RaftGetConfigurationResponse) ProtoMessage() {}
 
 func (x *RaftGetConfigurationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[18]
+	mi := &file_types_v1_dkron_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1148,7 +1516,7 @@ func (x *RaftGetConfigurationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RaftGetConfigurationResponse.ProtoReflect.Descriptor instead.
 func (*RaftGetConfigurationResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{18}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RaftGetConfigurationResponse) GetServers() []*RaftServer {
@@ -1174,7 +1542,7 @@ type RaftRemovePeerByIDRequest struct {
 
 func (x *RaftRemovePeerByIDRequest) Reset() {
 	*x = RaftRemovePeerByIDRequest{}
-	mi := &file_types_v1_dkron_proto_msgTypes[19]
+	mi := &file_types_v1_dkron_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1186,7 +1554,7 @@ func (x *RaftRemovePeerByIDRequest) String() string {
 func (*RaftRemovePeerByIDRequest) ProtoMessage() {}
 
 func (x *RaftRemovePeerByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[19]
+	mi := &file_types_v1_dkron_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1199,7 +1567,7 @@ func (x *RaftRemovePeerByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RaftRemovePeerByIDRequest.ProtoReflect.Descriptor instead.
 func (*RaftRemovePeerByIDRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{19}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RaftRemovePeerByIDRequest) GetId() string {
@@ -1218,7 +1586,7 @@ type GetActiveExecutionsResponse struct {
 
 func (x *GetActiveExecutionsResponse) Reset() {
 	*x = GetActiveExecutionsResponse{}
-	mi := &file_types_v1_dkron_proto_msgTypes[20]
+	mi := &file_types_v1_dkron_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1230,7 +1598,7 @@ func (x *GetActiveExecutionsResponse) String() string {
 func (*GetActiveExecutionsResponse) ProtoMessage() {}
 
 func (x *GetActiveExecutionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_dkron_proto_msgTypes[20]
+	mi := &file_types_v1_dkron_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1243,7 +1611,7 @@ func (x *GetActiveExecutionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveExecutionsResponse.ProtoReflect.Descriptor instead.
 func (*GetActiveExecutionsResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{20}
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetActiveExecutionsResponse) GetExecutions() []*Execution {
@@ -1253,28 +1621,103 @@ func (x *GetActiveExecutionsResponse) GetExecutions() []*Execution {
 	return nil
 }
 
-type Job_NullableTime struct {
+type Calendar struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description      string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Dates            []string               `protobuf:"bytes,3,rep,name=dates,proto3" json:"dates,omitempty"`
+	ExcludedWeekdays []string               `protobuf:"bytes,4,rep,name=excluded_weekdays,json=excludedWeekdays,proto3" json:"excluded_weekdays,omitempty"`
+	Exdates          []string               `protobuf:"bytes,5,rep,name=exdates,proto3" json:"exdates,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Calendar) Reset() {
+	*x = Calendar{}
+	mi := &file_types_v1_dkron_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Calendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calendar) ProtoMessage() {}
+
+func (x *Calendar) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calendar.ProtoReflect.Descriptor instead.
+func (*Calendar) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Calendar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Calendar) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Calendar) GetDates() []string {
+	if x != nil {
+		return x.Dates
+	}
+	return nil
+}
+
+func (x *Calendar) GetExcludedWeekdays() []string {
+	if x != nil {
+		return x.ExcludedWeekdays
+	}
+	return nil
+}
+
+func (x *Calendar) GetExdates() []string {
+	if x != nil {
+		return x.Exdates
+	}
+	return nil
+}
+
+type SetCalendarRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	HasValue      bool                   `protobuf:"varint,1,opt,name=has_value,json=hasValue,proto3" json:"has_value,omitempty"`
-	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Calendar      *Calendar              `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Job_NullableTime) Reset() {
-	*x = Job_NullableTime{}
+func (x *SetCalendarRequest) Reset() {
+	*x = SetCalendarRequest{}
 	mi := &file_types_v1_dkron_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Job_NullableTime) String() string {
+func (x *SetCalendarRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Job_NullableTime) ProtoMessage() {}
+func (*SetCalendarRequest) ProtoMessage() {}
 
-func (x *Job_NullableTime) ProtoReflect() protoreflect.Message {
+func (x *SetCalendarRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_types_v1_dkron_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1286,80 +1729,798 @@ func (x *Job_NullableTime) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Job_NullableTime.ProtoReflect.Descriptor instead.
-func (*Job_NullableTime) Descriptor() ([]byte, []int) {
-	return file_types_v1_dkron_proto_rawDescGZIP(), []int{0, 3}
+// Deprecated: Use SetCalendarRequest.ProtoReflect.Descriptor instead.
+func (*SetCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *Job_NullableTime) GetHasValue() bool {
+func (x *SetCalendarRequest) GetCalendar() *Calendar {
 	if x != nil {
-		return x.HasValue
+		return x.Calendar
 	}
-	return false
+	return nil
 }
 
-func (x *Job_NullableTime) GetTime() *timestamppb.Timestamp {
+type SetCalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendar      *Calendar              `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCalendarResponse) Reset() {
+	*x = SetCalendarResponse{}
+	mi := &file_types_v1_dkron_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCalendarResponse) ProtoMessage() {}
+
+func (x *SetCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[25]
 	if x != nil {
-		return x.Time
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCalendarResponse.ProtoReflect.Descriptor instead.
+func (*SetCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetCalendarResponse) GetCalendar() *Calendar {
+	if x != nil {
+		return x.Calendar
 	}
 	return nil
 }
 
-var File_types_v1_dkron_proto protoreflect.FileDescriptor
+type DeleteCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_types_v1_dkron_proto_rawDesc = "" +
-	"\n" +
-	"\x14types/v1/dkron.proto\x12\btypes.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdc\n" +
-	"\n" +
-	"\x03Job\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
-	"\btimezone\x18\x02 \x01(\tR\btimezone\x12\x1a\n" +
-	"\bschedule\x18\x03 \x01(\tR\bschedule\x12\x14\n" +
-	"\x05owner\x18\a \x01(\tR\x05owner\x12\x1f\n" +
-	"\vowner_email\x18\b \x01(\tR\n" +
-	"ownerEmail\x12#\n" +
-	"\rsuccess_count\x18\t \x01(\x05R\fsuccessCount\x12\x1f\n" +
-	"\verror_count\x18\n" +
-	" \x01(\x05R\n" +
-	"errorCount\x12\x1a\n" +
-	"\bdisabled\x18\v \x01(\bR\bdisabled\x12+\n" +
-	"\x04tags\x18\f \x03(\v2\x17.types.v1.Job.TagsEntryR\x04tags\x12\x18\n" +
-	"\aretries\x18\r \x01(\rR\aretries\x12%\n" +
-	"\x0edependent_jobs\x18\x0e \x03(\tR\rdependentJobs\x12\x1d\n" +
-	"\n" +
-	"parent_job\x18\x0f \x01(\tR\tparentJob\x12 \n" +
-	"\vconcurrency\x18\x10 \x01(\tR\vconcurrency\x12\x1a\n" +
-	"\bexecutor\x18\x11 \x01(\tR\bexecutor\x12J\n" +
-	"\x0fexecutor_config\x18\x12 \x03(\v2!.types.v1.Job.ExecutorConfigEntryR\x0eexecutorConfig\x12\x16\n" +
-	"\x06status\x18\x13 \x01(\tR\x06status\x127\n" +
-	"\bmetadata\x18\x14 \x03(\v2\x1b.types.v1.Job.MetadataEntryR\bmetadata\x12=\n" +
-	"\flast_success\x18\x19 \x01(\v2\x1a.types.v1.Job.NullableTimeR\vlastSuccess\x129\n" +
-	"\n" +
-	"last_error\x18\x1a \x01(\v2\x1a.types.v1.Job.NullableTimeR\tlastError\x12.\n" +
-	"\x04next\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\x04next\x12 \n" +
-	"\vdisplayname\x18\x18 \x01(\tR\vdisplayname\x12=\n" +
-	"\n" +
-	"processors\x18\x1b \x03(\v2\x1d.types.v1.Job.ProcessorsEntryR\n" +
-	"processors\x12\x1c\n" +
-	"\tephemeral\x18\x1c \x01(\bR\tephemeral\x129\n" +
-	"\n" +
-	"expires_at\x18\x1d \x01(\v2\x1a.types.v1.Job.NullableTimeR\texpiresAt\x127\n" +
-	"\tstarts_at\x18\x1e \x01(\v2\x1a.types.v1.Job.NullableTimeR\bstartsAt\x1a7\n" +
-	"\tTagsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
-	"\x13ExecutorConfigEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a[\n" +
-	"\fNullableTime\x12\x1b\n" +
-	"\thas_value\x18\x01 \x01(\bR\bhasValue\x12.\n" +
-	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x1aU\n" +
-	"\x0fProcessorsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
-	"\x05value\x18\x02 \x01(\v2\x16.types.v1.PluginConfigR\x05value:\x028\x01\"\x85\x01\n" +
+func (x *DeleteCalendarRequest) Reset() {
+	*x = DeleteCalendarRequest{}
+	mi := &file_types_v1_dkron_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalendarRequest) ProtoMessage() {}
+
+func (x *DeleteCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalendarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteCalendarRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteCalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendar      *Calendar              `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalendarResponse) Reset() {
+	*x = DeleteCalendarResponse{}
+	mi := &file_types_v1_dkron_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalendarResponse) ProtoMessage() {}
+
+func (x *DeleteCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalendarResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteCalendarResponse) GetCalendar() *Calendar {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+type MaintenanceWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Tags          map[string]string      `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata      map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceWindow) Reset() {
+	*x = MaintenanceWindow{}
+	mi := &file_types_v1_dkron_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindow) ProtoMessage() {}
+
+func (x *MaintenanceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindow.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MaintenanceWindow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type SetMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceWindowRequest) Reset() {
+	*x = SetMaintenanceWindowRequest{}
+	mi := &file_types_v1_dkron_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *SetMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SetMaintenanceWindowRequest) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type SetMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceWindowResponse) Reset() {
+	*x = SetMaintenanceWindowResponse{}
+	mi := &file_types_v1_dkron_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *SetMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SetMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type DeleteMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMaintenanceWindowRequest) Reset() {
+	*x = DeleteMaintenanceWindowRequest{}
+	mi := &file_types_v1_dkron_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *DeleteMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteMaintenanceWindowRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMaintenanceWindowResponse) Reset() {
+	*x = DeleteMaintenanceWindowResponse{}
+	mi := &file_types_v1_dkron_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *DeleteMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type Pool struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Slots         uint32                 `protobuf:"varint,3,opt,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pool) Reset() {
+	*x = Pool{}
+	mi := &file_types_v1_dkron_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pool) ProtoMessage() {}
+
+func (x *Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pool.ProtoReflect.Descriptor instead.
+func (*Pool) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Pool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pool) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Pool) GetSlots() uint32 {
+	if x != nil {
+		return x.Slots
+	}
+	return 0
+}
+
+type SetPoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pool          *Pool                  `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPoolRequest) Reset() {
+	*x = SetPoolRequest{}
+	mi := &file_types_v1_dkron_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPoolRequest) ProtoMessage() {}
+
+func (x *SetPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPoolRequest.ProtoReflect.Descriptor instead.
+func (*SetPoolRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetPoolRequest) GetPool() *Pool {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+type SetPoolResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pool          *Pool                  `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPoolResponse) Reset() {
+	*x = SetPoolResponse{}
+	mi := &file_types_v1_dkron_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPoolResponse) ProtoMessage() {}
+
+func (x *SetPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPoolResponse.ProtoReflect.Descriptor instead.
+func (*SetPoolResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetPoolResponse) GetPool() *Pool {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+type DeletePoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePoolRequest) Reset() {
+	*x = DeletePoolRequest{}
+	mi := &file_types_v1_dkron_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePoolRequest) ProtoMessage() {}
+
+func (x *DeletePoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePoolRequest.ProtoReflect.Descriptor instead.
+func (*DeletePoolRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeletePoolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeletePoolResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pool          *Pool                  `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePoolResponse) Reset() {
+	*x = DeletePoolResponse{}
+	mi := &file_types_v1_dkron_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePoolResponse) ProtoMessage() {}
+
+func (x *DeletePoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePoolResponse.ProtoReflect.Descriptor instead.
+func (*DeletePoolResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeletePoolResponse) GetPool() *Pool {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+type Job_NullableTime struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HasValue      bool                   `protobuf:"varint,1,opt,name=has_value,json=hasValue,proto3" json:"has_value,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job_NullableTime) Reset() {
+	*x = Job_NullableTime{}
+	mi := &file_types_v1_dkron_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job_NullableTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job_NullableTime) ProtoMessage() {}
+
+func (x *Job_NullableTime) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_dkron_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job_NullableTime.ProtoReflect.Descriptor instead.
+func (*Job_NullableTime) Descriptor() ([]byte, []int) {
+	return file_types_v1_dkron_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *Job_NullableTime) GetHasValue() bool {
+	if x != nil {
+		return x.HasValue
+	}
+	return false
+}
+
+func (x *Job_NullableTime) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+var File_types_v1_dkron_proto protoreflect.FileDescriptor
+
+const file_types_v1_dkron_proto_rawDesc = "" +
+	"\n" +
+	"\x14types/v1/dkron.proto\x12\btypes.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x13\n" +
+	"\x03Job\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\x12\x1a\n" +
+	"\bschedule\x18\x03 \x01(\tR\bschedule\x12\x14\n" +
+	"\x05owner\x18\a \x01(\tR\x05owner\x12\x1f\n" +
+	"\vowner_email\x18\b \x01(\tR\n" +
+	"ownerEmail\x12#\n" +
+	"\rsuccess_count\x18\t \x01(\x05R\fsuccessCount\x12\x1f\n" +
+	"\verror_count\x18\n" +
+	" \x01(\x05R\n" +
+	"errorCount\x12\x1a\n" +
+	"\bdisabled\x18\v \x01(\bR\bdisabled\x12+\n" +
+	"\x04tags\x18\f \x03(\v2\x17.types.v1.Job.TagsEntryR\x04tags\x12\x18\n" +
+	"\aretries\x18\r \x01(\rR\aretries\x12%\n" +
+	"\x0edependent_jobs\x18\x0e \x03(\tR\rdependentJobs\x12\x1d\n" +
+	"\n" +
+	"parent_job\x18\x0f \x01(\tR\tparentJob\x12 \n" +
+	"\vconcurrency\x18\x10 \x01(\tR\vconcurrency\x12\x1a\n" +
+	"\bexecutor\x18\x11 \x01(\tR\bexecutor\x12J\n" +
+	"\x0fexecutor_config\x18\x12 \x03(\v2!.types.v1.Job.ExecutorConfigEntryR\x0eexecutorConfig\x12\x16\n" +
+	"\x06status\x18\x13 \x01(\tR\x06status\x127\n" +
+	"\bmetadata\x18\x14 \x03(\v2\x1b.types.v1.Job.MetadataEntryR\bmetadata\x12=\n" +
+	"\flast_success\x18\x19 \x01(\v2\x1a.types.v1.Job.NullableTimeR\vlastSuccess\x129\n" +
+	"\n" +
+	"last_error\x18\x1a \x01(\v2\x1a.types.v1.Job.NullableTimeR\tlastError\x12.\n" +
+	"\x04next\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\x04next\x12 \n" +
+	"\vdisplayname\x18\x18 \x01(\tR\vdisplayname\x12=\n" +
+	"\n" +
+	"processors\x18\x1b \x03(\v2\x1d.types.v1.Job.ProcessorsEntryR\n" +
+	"processors\x12\x1c\n" +
+	"\tephemeral\x18\x1c \x01(\bR\tephemeral\x129\n" +
+	"\n" +
+	"expires_at\x18\x1d \x01(\v2\x1a.types.v1.Job.NullableTimeR\texpiresAt\x127\n" +
+	"\tstarts_at\x18\x1e \x01(\v2\x1a.types.v1.Job.NullableTimeR\bstartsAt\x12%\n" +
+	"\x0emisfire_policy\x18\x1f \x01(\tR\rmisfirePolicy\x12#\n" +
+	"\rmisfire_grace\x18  \x01(\tR\fmisfireGrace\x12\x1c\n" +
+	"\tcalendars\x18! \x03(\tR\tcalendars\x12'\n" +
+	"\x0fschedule_format\x18\" \x01(\tR\x0escheduleFormat\x12\x1d\n" +
+	"\n" +
+	"dst_policy\x18# \x01(\tR\tdstPolicy\x12\x16\n" +
+	"\x06jitter\x18$ \x01(\tR\x06jitter\x12\x1f\n" +
+	"\vjitter_seed\x18% \x01(\x03R\n" +
+	"jitterSeed\x123\n" +
+	"\tschedules\x18& \x03(\v2\x15.types.v1.JobScheduleR\tschedules\x124\n" +
+	"\vrun_windows\x18' \x03(\v2\x13.types.v1.RunWindowR\n" +
+	"runWindows\x12*\n" +
+	"\x11run_window_policy\x18( \x01(\tR\x0frunWindowPolicy\x12'\n" +
+	"\x0fmax_concurrency\x18) \x01(\rR\x0emaxConcurrency\x12\x12\n" +
+	"\x04pool\x18* \x01(\tR\x04pool\x12\x1d\n" +
+	"\n" +
+	"pool_slots\x18+ \x01(\rR\tpoolSlots\x12\x1a\n" +
+	"\bpriority\x18, \x01(\x05R\bpriority\x12-\n" +
+	"\x12selection_strategy\x18- \x01(\tR\x11selectionStrategy\x12\x1d\n" +
+	"\n" +
+	"spread_tag\x18. \x01(\tR\tspreadTag\x12\x1f\n" +
+	"\vparent_jobs\x18/ \x03(\tR\n" +
+	"parentJobs\x12!\n" +
+	"\ftrigger_rule\x180 \x01(\tR\vtriggerRule\x12P\n" +
+	"\x11parent_conditions\x181 \x03(\v2#.types.v1.Job.ParentConditionsEntryR\x10parentConditions\x12Y\n" +
+	"\x14dependent_conditions\x182 \x03(\v2&.types.v1.Job.DependentConditionsEntryR\x13dependentConditions\x12\x10\n" +
+	"\x03map\x183 \x01(\bR\x03map\x12!\n" +
+	"\fmax_parallel\x184 \x01(\rR\vmaxParallel\x12(\n" +
+	"\x10map_success_rule\x185 \x01(\tR\x0emapSuccessRule\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13ExecutorConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a[\n" +
+	"\fNullableTime\x12\x1b\n" +
+	"\thas_value\x18\x01 \x01(\bR\bhasValue\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x1aU\n" +
+	"\x0fProcessorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.types.v1.PluginConfigR\x05value:\x028\x01\x1aC\n" +
+	"\x15ParentConditionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aF\n" +
+	"\x18DependentConditionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xdc\x01\n" +
+	"\vJobSchedule\x12\x1a\n" +
+	"\bschedule\x18\x01 \x01(\tR\bschedule\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\x12R\n" +
+	"\x0fexecutor_config\x18\x03 \x03(\v2).types.v1.JobSchedule.ExecutorConfigEntryR\x0eexecutorConfig\x1aA\n" +
+	"\x13ExecutorConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
+	"\tRunWindow\x12\x12\n" +
+	"\x04days\x18\x01 \x03(\tR\x04days\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\tR\x03end\"\x85\x01\n" +
 	"\fPluginConfig\x12:\n" +
 	"\x06config\x18\x01 \x03(\v2\".types.v1.PluginConfig.ConfigEntryR\x06config\x1a9\n" +
 	"\vConfigEntry\x12\x10\n" +
@@ -1376,7 +2537,7 @@ const file_types_v1_dkron_proto_rawDesc = "" +
 	"\rGetJobRequest\x12\x19\n" +
 	"\bjob_name\x18\x01 \x01(\tR\ajobName\"1\n" +
 	"\x0eGetJobResponse\x12\x1f\n" +
-	"\x03job\x18\x01 \x01(\v2\r.types.v1.JobR\x03job\"\x9d\x02\n" +
+	"\x03job\x18\x01 \x01(\v2\r.types.v1.JobR\x03job\"\xd5\x04\n" +
 	"\tExecution\x12\x19\n" +
 	"\bjob_name\x18\x01 \x01(\tR\ajobName\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x16\n" +
@@ -1387,14 +2548,29 @@ const file_types_v1_dkron_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
 	"\vfinished_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"finishedAt\"I\n" +
+	"finishedAt\x12\x18\n" +
+	"\askipped\x18\t \x01(\bR\askipped\x12=\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2!.types.v1.Execution.MetadataEntryR\bmetadata\x12=\n" +
+	"\fscheduled_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x12!\n" +
+	"\fjitter_delay\x18\f \x01(\tR\vjitterDelay\x12&\n" +
+	"\x0fworkflow_run_id\x18\r \x01(\tR\rworkflowRunId\x12\x16\n" +
+	"\x06result\x18\x0e \x01(\fR\x06result\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"I\n" +
 	"\x14ExecutionDoneRequest\x121\n" +
 	"\texecution\x18\x01 \x01(\v2\x13.types.v1.ExecutionR\texecution\"E\n" +
 	"\x15ExecutionDoneResponse\x12\x12\n" +
 	"\x04from\x18\x01 \x01(\tR\x04from\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\fR\apayload\"*\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\"\xb0\x01\n" +
 	"\rRunJobRequest\x12\x19\n" +
-	"\bjob_name\x18\x01 \x01(\tR\ajobName\"1\n" +
+	"\bjob_name\x18\x01 \x01(\tR\ajobName\x12\x14\n" +
+	"\x05nodes\x18\x02 \x03(\tR\x05nodes\x125\n" +
+	"\x04tags\x18\x03 \x03(\v2!.types.v1.RunJobRequest.TagsEntryR\x04tags\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"1\n" +
 	"\x0eRunJobResponse\x12\x1f\n" +
 	"\x03job\x18\x01 \x01(\v2\r.types.v1.JobR\x03job\"4\n" +
 	"\x17DeleteExecutionsRequest\x12\x19\n" +
@@ -1421,7 +2597,55 @@ const file_types_v1_dkron_proto_rawDesc = "" +
 	"\x1bGetActiveExecutionsResponse\x123\n" +
 	"\n" +
 	"executions\x18\x01 \x03(\v2\x13.types.v1.ExecutionR\n" +
-	"executions2\xee\x06\n" +
+	"executions\"\x9d\x01\n" +
+	"\bCalendar\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05dates\x18\x03 \x03(\tR\x05dates\x12+\n" +
+	"\x11excluded_weekdays\x18\x04 \x03(\tR\x10excludedWeekdays\x12\x18\n" +
+	"\aexdates\x18\x05 \x03(\tR\aexdates\"D\n" +
+	"\x12SetCalendarRequest\x12.\n" +
+	"\bcalendar\x18\x01 \x01(\v2\x12.types.v1.CalendarR\bcalendar\"E\n" +
+	"\x13SetCalendarResponse\x12.\n" +
+	"\bcalendar\x18\x01 \x01(\v2\x12.types.v1.CalendarR\bcalendar\"+\n" +
+	"\x15DeleteCalendarRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"H\n" +
+	"\x16DeleteCalendarResponse\x12.\n" +
+	"\bcalendar\x18\x01 \x01(\v2\x12.types.v1.CalendarR\bcalendar\"\xa5\x03\n" +
+	"\x11MaintenanceWindow\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x127\n" +
+	"\tstarts_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\x04tags\x18\x05 \x03(\v2%.types.v1.MaintenanceWindow.TagsEntryR\x04tags\x12E\n" +
+	"\bmetadata\x18\x06 \x03(\v2).types.v1.MaintenanceWindow.MetadataEntryR\bmetadata\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"R\n" +
+	"\x1bSetMaintenanceWindowRequest\x123\n" +
+	"\x06window\x18\x01 \x01(\v2\x1b.types.v1.MaintenanceWindowR\x06window\"S\n" +
+	"\x1cSetMaintenanceWindowResponse\x123\n" +
+	"\x06window\x18\x01 \x01(\v2\x1b.types.v1.MaintenanceWindowR\x06window\"4\n" +
+	"\x1eDeleteMaintenanceWindowRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"V\n" +
+	"\x1fDeleteMaintenanceWindowResponse\x123\n" +
+	"\x06window\x18\x01 \x01(\v2\x1b.types.v1.MaintenanceWindowR\x06window\"R\n" +
+	"\x04Pool\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05slots\x18\x03 \x01(\rR\x05slots\"4\n" +
+	"\x0eSetPoolRequest\x12\"\n" +
+	"\x04pool\x18\x01 \x01(\v2\x0e.types.v1.PoolR\x04pool\"5\n" +
+	"\x0fSetPoolResponse\x12\"\n" +
+	"\x04pool\x18\x01 \x01(\v2\x0e.types.v1.PoolR\x04pool\"'\n" +
+	"\x11DeletePoolRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"8\n" +
+	"\x12DeletePoolResponse\x12\"\n" +
+	"\x04pool\x18\x01 \x01(\v2\x0e.types.v1.PoolR\x04pool2\xef\n" +
+	"\n" +
 	"\x05Dkron\x12;\n" +
 	"\x06GetJob\x12\x17.types.v1.GetJobRequest\x1a\x18.types.v1.GetJobResponse\x12P\n" +
 	"\rExecutionDone\x12\x1e.types.v1.ExecutionDoneRequest\x1a\x1f.types.v1.ExecutionDoneResponse\x127\n" +
@@ -1434,7 +2658,14 @@ const file_types_v1_dkron_proto_rawDesc = "" +
 	"\x14RaftGetConfiguration\x12\x16.google.protobuf.Empty\x1a&.types.v1.RaftGetConfigurationResponse\x12Q\n" +
 	"\x12RaftRemovePeerByID\x12#.types.v1.RaftRemovePeerByIDRequest\x1a\x16.google.protobuf.Empty\x12T\n" +
 	"\x13GetActiveExecutions\x12\x16.google.protobuf.Empty\x1a%.types.v1.GetActiveExecutionsResponse\x12;\n" +
-	"\fSetExecution\x12\x13.types.v1.Execution\x1a\x16.google.protobuf.EmptyB\x94\x01\n" +
+	"\fSetExecution\x12\x13.types.v1.Execution\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\vSetCalendar\x12\x1c.types.v1.SetCalendarRequest\x1a\x1d.types.v1.SetCalendarResponse\x12S\n" +
+	"\x0eDeleteCalendar\x12\x1f.types.v1.DeleteCalendarRequest\x1a .types.v1.DeleteCalendarResponse\x12e\n" +
+	"\x14SetMaintenanceWindow\x12%.types.v1.SetMaintenanceWindowRequest\x1a&.types.v1.SetMaintenanceWindowResponse\x12n\n" +
+	"\x17DeleteMaintenanceWindow\x12(.types.v1.DeleteMaintenanceWindowRequest\x1a).types.v1.DeleteMaintenanceWindowResponse\x12>\n" +
+	"\aSetPool\x12\x18.types.v1.SetPoolRequest\x1a\x19.types.v1.SetPoolResponse\x12G\n" +
+	"\n" +
+	"DeletePool\x12\x1b.types.v1.DeletePoolRequest\x1a\x1c.types.v1.DeletePoolResponseB\x94\x01\n" +
 	"\fcom.types.v1B\n" +
 	"DkronProtoP\x01Z7github.com/distribworks/dkron/v4/types/types/v1;typesv1\xa2\x02\x03TXX\xaa\x02\bTypes.V1\xca\x02\bTypes\\V1\xe2\x02\x14Types\\V1\\GPBMetadata\xea\x02\tTypes::V1b\x06proto3"
 
@@ -1450,92 +2681,149 @@ func file_types_v1_dkron_proto_rawDescGZIP() []byte {
 	return file_types_v1_dkron_proto_rawDescData
 }
 
-var file_types_v1_dkron_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_types_v1_dkron_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_types_v1_dkron_proto_goTypes = []any{
-	(*Job)(nil),                          // 0: types.v1.Job
-	(*PluginConfig)(nil),                 // 1: types.v1.PluginConfig
-	(*SetJobRequest)(nil),                // 2: types.v1.SetJobRequest
-	(*SetJobResponse)(nil),               // 3: types.v1.SetJobResponse
-	(*DeleteJobRequest)(nil),             // 4: types.v1.DeleteJobRequest
-	(*DeleteJobResponse)(nil),            // 5: types.v1.DeleteJobResponse
-	(*GetJobRequest)(nil),                // 6: types.v1.GetJobRequest
-	(*GetJobResponse)(nil),               // 7: types.v1.GetJobResponse
-	(*Execution)(nil),                    // 8: types.v1.Execution
-	(*ExecutionDoneRequest)(nil),         // 9: types.v1.ExecutionDoneRequest
-	(*ExecutionDoneResponse)(nil),        // 10: types.v1.ExecutionDoneResponse
-	(*RunJobRequest)(nil),                // 11: types.v1.RunJobRequest
-	(*RunJobResponse)(nil),               // 12: types.v1.RunJobResponse
-	(*DeleteExecutionsRequest)(nil),      // 13: types.v1.DeleteExecutionsRequest
-	(*DeleteExecutionsResponse)(nil),     // 14: types.v1.DeleteExecutionsResponse
-	(*ToggleJobRequest)(nil),             // 15: types.v1.ToggleJobRequest
-	(*ToggleJobResponse)(nil),            // 16: types.v1.ToggleJobResponse
-	(*RaftServer)(nil),                   // 17: types.v1.RaftServer
-	(*RaftGetConfigurationResponse)(nil), // 18: types.v1.RaftGetConfigurationResponse
-	(*RaftRemovePeerByIDRequest)(nil),    // 19: types.v1.RaftRemovePeerByIDRequest
-	(*GetActiveExecutionsResponse)(nil),  // 20: types.v1.GetActiveExecutionsResponse
-	nil,                                  // 21: types.v1.Job.TagsEntry
-	nil,                                  // 22: types.v1.Job.ExecutorConfigEntry
-	nil,                                  // 23: types.v1.Job.MetadataEntry
-	(*Job_NullableTime)(nil),             // 24: types.v1.Job.NullableTime
-	nil,                                  // 25: types.v1.Job.ProcessorsEntry
-	nil,                                  // 26: types.v1.PluginConfig.ConfigEntry
-	(*timestamppb.Timestamp)(nil),        // 27: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                // 28: google.protobuf.Empty
+	(*Job)(nil),                             // 0: types.v1.Job
+	(*JobSchedule)(nil),                     // 1: types.v1.JobSchedule
+	(*RunWindow)(nil),                       // 2: types.v1.RunWindow
+	(*PluginConfig)(nil),                    // 3: types.v1.PluginConfig
+	(*SetJobRequest)(nil),                   // 4: types.v1.SetJobRequest
+	(*SetJobResponse)(nil),                  // 5: types.v1.SetJobResponse
+	(*DeleteJobRequest)(nil),                // 6: types.v1.DeleteJobRequest
+	(*DeleteJobResponse)(nil),               // 7: types.v1.DeleteJobResponse
+	(*GetJobRequest)(nil),                   // 8: types.v1.GetJobRequest
+	(*GetJobResponse)(nil),                  // 9: types.v1.GetJobResponse
+	(*Execution)(nil),                       // 10: types.v1.Execution
+	(*ExecutionDoneRequest)(nil),            // 11: types.v1.ExecutionDoneRequest
+	(*ExecutionDoneResponse)(nil),           // 12: types.v1.ExecutionDoneResponse
+	(*RunJobRequest)(nil),                   // 13: types.v1.RunJobRequest
+	(*RunJobResponse)(nil),                  // 14: types.v1.RunJobResponse
+	(*DeleteExecutionsRequest)(nil),         // 15: types.v1.DeleteExecutionsRequest
+	(*DeleteExecutionsResponse)(nil),        // 16: types.v1.DeleteExecutionsResponse
+	(*ToggleJobRequest)(nil),                // 17: types.v1.ToggleJobRequest
+	(*ToggleJobResponse)(nil),               // 18: types.v1.ToggleJobResponse
+	(*RaftServer)(nil),                      // 19: types.v1.RaftServer
+	(*RaftGetConfigurationResponse)(nil),    // 20: types.v1.RaftGetConfigurationResponse
+	(*RaftRemovePeerByIDRequest)(nil),       // 21: types.v1.RaftRemovePeerByIDRequest
+	(*GetActiveExecutionsResponse)(nil),     // 22: types.v1.GetActiveExecutionsResponse
+	(*Calendar)(nil),                        // 23: types.v1.Calendar
+	(*SetCalendarRequest)(nil),              // 24: types.v1.SetCalendarRequest
+	(*SetCalendarResponse)(nil),             // 25: types.v1.SetCalendarResponse
+	(*DeleteCalendarRequest)(nil),           // 26: types.v1.DeleteCalendarRequest
+	(*DeleteCalendarResponse)(nil),          // 27: types.v1.DeleteCalendarResponse
+	(*MaintenanceWindow)(nil),               // 28: types.v1.MaintenanceWindow
+	(*SetMaintenanceWindowRequest)(nil),     // 29: types.v1.SetMaintenanceWindowRequest
+	(*SetMaintenanceWindowResponse)(nil),    // 30: types.v1.SetMaintenanceWindowResponse
+	(*DeleteMaintenanceWindowRequest)(nil),  // 31: types.v1.DeleteMaintenanceWindowRequest
+	(*DeleteMaintenanceWindowResponse)(nil), // 32: types.v1.DeleteMaintenanceWindowResponse
+	(*Pool)(nil),                            // 33: types.v1.Pool
+	(*SetPoolRequest)(nil),                  // 34: types.v1.SetPoolRequest
+	(*SetPoolResponse)(nil),                 // 35: types.v1.SetPoolResponse
+	(*DeletePoolRequest)(nil),               // 36: types.v1.DeletePoolRequest
+	(*DeletePoolResponse)(nil),              // 37: types.v1.DeletePoolResponse
+	nil,                                     // 38: types.v1.Job.TagsEntry
+	nil,                                     // 39: types.v1.Job.ExecutorConfigEntry
+	nil,                                     // 40: types.v1.Job.MetadataEntry
+	(*Job_NullableTime)(nil),                // 41: types.v1.Job.NullableTime
+	nil,                                     // 42: types.v1.Job.ProcessorsEntry
+	nil,                                     // 43: types.v1.Job.ParentConditionsEntry
+	nil,                                     // 44: types.v1.Job.DependentConditionsEntry
+	nil,                                     // 45: types.v1.JobSchedule.ExecutorConfigEntry
+	nil,                                     // 46: types.v1.PluginConfig.ConfigEntry
+	nil,                                     // 47: types.v1.Execution.MetadataEntry
+	nil,                                     // 48: types.v1.RunJobRequest.TagsEntry
+	nil,                                     // 49: types.v1.MaintenanceWindow.TagsEntry
+	nil,                                     // 50: types.v1.MaintenanceWindow.MetadataEntry
+	(*timestamppb.Timestamp)(nil),           // 51: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                   // 52: google.protobuf.Empty
 }
 var file_types_v1_dkron_proto_depIdxs = []int32{
-	21, // 0: types.v1.Job.tags:type_name -> types.v1.Job.TagsEntry
-	22, // 1: types.v1.Job.executor_config:type_name -> types.v1.Job.ExecutorConfigEntry
-	23, // 2: types.v1.Job.metadata:type_name -> types.v1.Job.MetadataEntry
-	24, // 3: types.v1.Job.last_success:type_name -> types.v1.Job.NullableTime
-	24, // 4: types.v1.Job.last_error:type_name -> types.v1.Job.NullableTime
-	27, // 5: types.v1.Job.next:type_name -> google.protobuf.Timestamp
-	25, // 6: types.v1.Job.processors:type_name -> types.v1.Job.ProcessorsEntry
-	24, // 7: types.v1.Job.expires_at:type_name -> types.v1.Job.NullableTime
-	24, // 8: types.v1.Job.starts_at:type_name -> types.v1.Job.NullableTime
-	26, // 9: types.v1.PluginConfig.config:type_name -> types.v1.PluginConfig.ConfigEntry
-	0,  // 10: types.v1.SetJobRequest.job:type_name -> types.v1.Job
-	0,  // 11: types.v1.SetJobResponse.job:type_name -> types.v1.Job
-	0,  // 12: types.v1.DeleteJobResponse.job:type_name -> types.v1.Job
-	0,  // 13: types.v1.GetJobResponse.job:type_name -> types.v1.Job
-	27, // 14: types.v1.Execution.started_at:type_name -> google.protobuf.Timestamp
-	27, // 15: types.v1.Execution.finished_at:type_name -> google.protobuf.Timestamp
-	8,  // 16: types.v1.ExecutionDoneRequest.execution:type_name -> types.v1.Execution
-	0,  // 17: types.v1.RunJobResponse.job:type_name -> types.v1.Job
-	0,  // 18: types.v1.DeleteExecutionsResponse.job:type_name -> types.v1.Job
-	0,  // 19: types.v1.ToggleJobResponse.job:type_name -> types.v1.Job
-	17, // 20: types.v1.RaftGetConfigurationResponse.servers:type_name -> types.v1.RaftServer
-	8,  // 21: types.v1.GetActiveExecutionsResponse.executions:type_name -> types.v1.Execution
-	27, // 22: types.v1.Job.NullableTime.time:type_name -> google.protobuf.Timestamp
-	1,  // 23: types.v1.Job.ProcessorsEntry.value:type_name -> types.v1.PluginConfig
-	6,  // 24: types.v1.Dkron.GetJob:input_type -> types.v1.GetJobRequest
-	9,  // 25: types.v1.Dkron.ExecutionDone:input_type -> types.v1.ExecutionDoneRequest
-	28, // 26: types.v1.Dkron.Leave:input_type -> google.protobuf.Empty
-	2,  // 27: types.v1.Dkron.SetJob:input_type -> types.v1.SetJobRequest
-	4,  // 28: types.v1.Dkron.DeleteJob:input_type -> types.v1.DeleteJobRequest
-	11, // 29: types.v1.Dkron.RunJob:input_type -> types.v1.RunJobRequest
-	13, // 30: types.v1.Dkron.DeleteExecutions:input_type -> types.v1.DeleteExecutionsRequest
-	15, // 31: types.v1.Dkron.ToggleJob:input_type -> types.v1.ToggleJobRequest
-	28, // 32: types.v1.Dkron.RaftGetConfiguration:input_type -> google.protobuf.Empty
-	19, // 33: types.v1.Dkron.RaftRemovePeerByID:input_type -> types.v1.RaftRemovePeerByIDRequest
-	28, // 34: types.v1.Dkron.GetActiveExecutions:input_type -> google.protobuf.Empty
-	8,  // 35: types.v1.Dkron.SetExecution:input_type -> types.v1.Execution
-	7,  // 36: types.v1.Dkron.GetJob:output_type -> types.v1.GetJobResponse
-	10, // 37: types.v1.Dkron.ExecutionDone:output_type -> types.v1.ExecutionDoneResponse
-	28, // 38: types.v1.Dkron.Leave:output_type -> google.protobuf.Empty
-	3,  // 39: types.v1.Dkron.SetJob:output_type -> types.v1.SetJobResponse
-	5,  // 40: types.v1.Dkron.DeleteJob:output_type -> types.v1.DeleteJobResponse
-	12, // 41: types.v1.Dkron.RunJob:output_type -> types.v1.RunJobResponse
-	14, // 42: types.v1.Dkron.DeleteExecutions:output_type -> types.v1.DeleteExecutionsResponse
-	16, // 43: types.v1.Dkron.ToggleJob:output_type -> types.v1.ToggleJobResponse
-	18, // 44: types.v1.Dkron.RaftGetConfiguration:output_type -> types.v1.RaftGetConfigurationResponse
-	28, // 45: types.v1.Dkron.RaftRemovePeerByID:output_type -> google.protobuf.Empty
-	20, // 46: types.v1.Dkron.GetActiveExecutions:output_type -> types.v1.GetActiveExecutionsResponse
-	28, // 47: types.v1.Dkron.SetExecution:output_type -> google.protobuf.Empty
-	36, // [36:48] is the sub-list for method output_type
-	24, // [24:36] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	38, // 0: types.v1.Job.tags:type_name -> types.v1.Job.TagsEntry
+	39, // 1: types.v1.Job.executor_config:type_name -> types.v1.Job.ExecutorConfigEntry
+	40, // 2: types.v1.Job.metadata:type_name -> types.v1.Job.MetadataEntry
+	41, // 3: types.v1.Job.last_success:type_name -> types.v1.Job.NullableTime
+	41, // 4: types.v1.Job.last_error:type_name -> types.v1.Job.NullableTime
+	51, // 5: types.v1.Job.next:type_name -> google.protobuf.Timestamp
+	42, // 6: types.v1.Job.processors:type_name -> types.v1.Job.ProcessorsEntry
+	41, // 7: types.v1.Job.expires_at:type_name -> types.v1.Job.NullableTime
+	41, // 8: types.v1.Job.starts_at:type_name -> types.v1.Job.NullableTime
+	1,  // 9: types.v1.Job.schedules:type_name -> types.v1.JobSchedule
+	2,  // 10: types.v1.Job.run_windows:type_name -> types.v1.RunWindow
+	43, // 11: types.v1.Job.parent_conditions:type_name -> types.v1.Job.ParentConditionsEntry
+	44, // 12: types.v1.Job.dependent_conditions:type_name -> types.v1.Job.DependentConditionsEntry
+	45, // 13: types.v1.JobSchedule.executor_config:type_name -> types.v1.JobSchedule.ExecutorConfigEntry
+	46, // 14: types.v1.PluginConfig.config:type_name -> types.v1.PluginConfig.ConfigEntry
+	0,  // 15: types.v1.SetJobRequest.job:type_name -> types.v1.Job
+	0,  // 16: types.v1.SetJobResponse.job:type_name -> types.v1.Job
+	0,  // 17: types.v1.DeleteJobResponse.job:type_name -> types.v1.Job
+	0,  // 18: types.v1.GetJobResponse.job:type_name -> types.v1.Job
+	51, // 19: types.v1.Execution.started_at:type_name -> google.protobuf.Timestamp
+	51, // 20: types.v1.Execution.finished_at:type_name -> google.protobuf.Timestamp
+	47, // 21: types.v1.Execution.metadata:type_name -> types.v1.Execution.MetadataEntry
+	51, // 22: types.v1.Execution.scheduled_at:type_name -> google.protobuf.Timestamp
+	10, // 23: types.v1.ExecutionDoneRequest.execution:type_name -> types.v1.Execution
+	48, // 24: types.v1.RunJobRequest.tags:type_name -> types.v1.RunJobRequest.TagsEntry
+	0,  // 25: types.v1.RunJobResponse.job:type_name -> types.v1.Job
+	0,  // 26: types.v1.DeleteExecutionsResponse.job:type_name -> types.v1.Job
+	0,  // 27: types.v1.ToggleJobResponse.job:type_name -> types.v1.Job
+	19, // 28: types.v1.RaftGetConfigurationResponse.servers:type_name -> types.v1.RaftServer
+	10, // 29: types.v1.GetActiveExecutionsResponse.executions:type_name -> types.v1.Execution
+	23, // 30: types.v1.SetCalendarRequest.calendar:type_name -> types.v1.Calendar
+	23, // 31: types.v1.SetCalendarResponse.calendar:type_name -> types.v1.Calendar
+	23, // 32: types.v1.DeleteCalendarResponse.calendar:type_name -> types.v1.Calendar
+	51, // 33: types.v1.MaintenanceWindow.starts_at:type_name -> google.protobuf.Timestamp
+	51, // 34: types.v1.MaintenanceWindow.ends_at:type_name -> google.protobuf.Timestamp
+	49, // 35: types.v1.MaintenanceWindow.tags:type_name -> types.v1.MaintenanceWindow.TagsEntry
+	50, // 36: types.v1.MaintenanceWindow.metadata:type_name -> types.v1.MaintenanceWindow.MetadataEntry
+	28, // 37: types.v1.SetMaintenanceWindowRequest.window:type_name -> types.v1.MaintenanceWindow
+	28, // 38: types.v1.SetMaintenanceWindowResponse.window:type_name -> types.v1.MaintenanceWindow
+	28, // 39: types.v1.DeleteMaintenanceWindowResponse.window:type_name -> types.v1.MaintenanceWindow
+	33, // 40: types.v1.SetPoolRequest.pool:type_name -> types.v1.Pool
+	33, // 41: types.v1.SetPoolResponse.pool:type_name -> types.v1.Pool
+	33, // 42: types.v1.DeletePoolResponse.pool:type_name -> types.v1.Pool
+	51, // 43: types.v1.Job.NullableTime.time:type_name -> google.protobuf.Timestamp
+	3,  // 44: types.v1.Job.ProcessorsEntry.value:type_name -> types.v1.PluginConfig
+	8,  // 45: types.v1.Dkron.GetJob:input_type -> types.v1.GetJobRequest
+	11, // 46: types.v1.Dkron.ExecutionDone:input_type -> types.v1.ExecutionDoneRequest
+	52, // 47: types.v1.Dkron.Leave:input_type -> google.protobuf.Empty
+	4,  // 48: types.v1.Dkron.SetJob:input_type -> types.v1.SetJobRequest
+	6,  // 49: types.v1.Dkron.DeleteJob:input_type -> types.v1.DeleteJobRequest
+	13, // 50: types.v1.Dkron.RunJob:input_type -> types.v1.RunJobRequest
+	15, // 51: types.v1.Dkron.DeleteExecutions:input_type -> types.v1.DeleteExecutionsRequest
+	17, // 52: types.v1.Dkron.ToggleJob:input_type -> types.v1.ToggleJobRequest
+	52, // 53: types.v1.Dkron.RaftGetConfiguration:input_type -> google.protobuf.Empty
+	21, // 54: types.v1.Dkron.RaftRemovePeerByID:input_type -> types.v1.RaftRemovePeerByIDRequest
+	52, // 55: types.v1.Dkron.GetActiveExecutions:input_type -> google.protobuf.Empty
+	10, // 56: types.v1.Dkron.SetExecution:input_type -> types.v1.Execution
+	24, // 57: types.v1.Dkron.SetCalendar:input_type -> types.v1.SetCalendarRequest
+	26, // 58: types.v1.Dkron.DeleteCalendar:input_type -> types.v1.DeleteCalendarRequest
+	29, // 59: types.v1.Dkron.SetMaintenanceWindow:input_type -> types.v1.SetMaintenanceWindowRequest
+	31, // 60: types.v1.Dkron.DeleteMaintenanceWindow:input_type -> types.v1.DeleteMaintenanceWindowRequest
+	34, // 61: types.v1.Dkron.SetPool:input_type -> types.v1.SetPoolRequest
+	36, // 62: types.v1.Dkron.DeletePool:input_type -> types.v1.DeletePoolRequest
+	9,  // 63: types.v1.Dkron.GetJob:output_type -> types.v1.GetJobResponse
+	12, // 64: types.v1.Dkron.ExecutionDone:output_type -> types.v1.ExecutionDoneResponse
+	52, // 65: types.v1.Dkron.Leave:output_type -> google.protobuf.Empty
+	5,  // 66: types.v1.Dkron.SetJob:output_type -> types.v1.SetJobResponse
+	7,  // 67: types.v1.Dkron.DeleteJob:output_type -> types.v1.DeleteJobResponse
+	14, // 68: types.v1.Dkron.RunJob:output_type -> types.v1.RunJobResponse
+	16, // 69: types.v1.Dkron.DeleteExecutions:output_type -> types.v1.DeleteExecutionsResponse
+	18, // 70: types.v1.Dkron.ToggleJob:output_type -> types.v1.ToggleJobResponse
+	20, // 71: types.v1.Dkron.RaftGetConfiguration:output_type -> types.v1.RaftGetConfigurationResponse
+	52, // 72: types.v1.Dkron.RaftRemovePeerByID:output_type -> google.protobuf.Empty
+	22, // 73: types.v1.Dkron.GetActiveExecutions:output_type -> types.v1.GetActiveExecutionsResponse
+	52, // 74: types.v1.Dkron.SetExecution:output_type -> google.protobuf.Empty
+	25, // 75: types.v1.Dkron.SetCalendar:output_type -> types.v1.SetCalendarResponse
+	27, // 76: types.v1.Dkron.DeleteCalendar:output_type -> types.v1.DeleteCalendarResponse
+	30, // 77: types.v1.Dkron.SetMaintenanceWindow:output_type -> types.v1.SetMaintenanceWindowResponse
+	32, // 78: types.v1.Dkron.DeleteMaintenanceWindow:output_type -> types.v1.DeleteMaintenanceWindowResponse
+	35, // 79: types.v1.Dkron.SetPool:output_type -> types.v1.SetPoolResponse
+	37, // 80: types.v1.Dkron.DeletePool:output_type -> types.v1.DeletePoolResponse
+	63, // [63:81] is the sub-list for method output_type
+	45, // [45:63] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_types_v1_dkron_proto_init() }
@@ -1549,7 +2837,7 @@ func file_types_v1_dkron_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_v1_dkron_proto_rawDesc), len(file_types_v1_dkron_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   27,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
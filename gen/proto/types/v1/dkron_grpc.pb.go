@@ -22,18 +22,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Dkron_GetJob_FullMethodName               = "/types.v1.Dkron/GetJob"
-	Dkron_ExecutionDone_FullMethodName        = "/types.v1.Dkron/ExecutionDone"
-	Dkron_Leave_FullMethodName                = "/types.v1.Dkron/Leave"
-	Dkron_SetJob_FullMethodName               = "/types.v1.Dkron/SetJob"
-	Dkron_DeleteJob_FullMethodName            = "/types.v1.Dkron/DeleteJob"
-	Dkron_RunJob_FullMethodName               = "/types.v1.Dkron/RunJob"
-	Dkron_DeleteExecutions_FullMethodName     = "/types.v1.Dkron/DeleteExecutions"
-	Dkron_ToggleJob_FullMethodName            = "/types.v1.Dkron/ToggleJob"
-	Dkron_RaftGetConfiguration_FullMethodName = "/types.v1.Dkron/RaftGetConfiguration"
-	Dkron_RaftRemovePeerByID_FullMethodName   = "/types.v1.Dkron/RaftRemovePeerByID"
-	Dkron_GetActiveExecutions_FullMethodName  = "/types.v1.Dkron/GetActiveExecutions"
-	Dkron_SetExecution_FullMethodName         = "/types.v1.Dkron/SetExecution"
+	Dkron_GetJob_FullMethodName                  = "/types.v1.Dkron/GetJob"
+	Dkron_ExecutionDone_FullMethodName           = "/types.v1.Dkron/ExecutionDone"
+	Dkron_Leave_FullMethodName                   = "/types.v1.Dkron/Leave"
+	Dkron_SetJob_FullMethodName                  = "/types.v1.Dkron/SetJob"
+	Dkron_DeleteJob_FullMethodName               = "/types.v1.Dkron/DeleteJob"
+	Dkron_RunJob_FullMethodName                  = "/types.v1.Dkron/RunJob"
+	Dkron_DeleteExecutions_FullMethodName        = "/types.v1.Dkron/DeleteExecutions"
+	Dkron_ToggleJob_FullMethodName               = "/types.v1.Dkron/ToggleJob"
+	Dkron_RaftGetConfiguration_FullMethodName    = "/types.v1.Dkron/RaftGetConfiguration"
+	Dkron_RaftRemovePeerByID_FullMethodName      = "/types.v1.Dkron/RaftRemovePeerByID"
+	Dkron_GetActiveExecutions_FullMethodName     = "/types.v1.Dkron/GetActiveExecutions"
+	Dkron_SetExecution_FullMethodName            = "/types.v1.Dkron/SetExecution"
+	Dkron_SetCalendar_FullMethodName             = "/types.v1.Dkron/SetCalendar"
+	Dkron_DeleteCalendar_FullMethodName          = "/types.v1.Dkron/DeleteCalendar"
+	Dkron_SetMaintenanceWindow_FullMethodName    = "/types.v1.Dkron/SetMaintenanceWindow"
+	Dkron_DeleteMaintenanceWindow_FullMethodName = "/types.v1.Dkron/DeleteMaintenanceWindow"
+	Dkron_SetPool_FullMethodName                 = "/types.v1.Dkron/SetPool"
+	Dkron_DeletePool_FullMethodName              = "/types.v1.Dkron/DeletePool"
 )
 
 // DkronClient is the client API for Dkron service.
@@ -61,6 +67,12 @@ type DkronClient interface {
 	// buf:lint:ignore RPC_REQUEST_STANDARD_NAME
 	// buf:lint:ignore RPC_RESPONSE_STANDARD_NAME
 	SetExecution(ctx context.Context, in *Execution, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetCalendar(ctx context.Context, in *SetCalendarRequest, opts ...grpc.CallOption) (*SetCalendarResponse, error)
+	DeleteCalendar(ctx context.Context, in *DeleteCalendarRequest, opts ...grpc.CallOption) (*DeleteCalendarResponse, error)
+	SetMaintenanceWindow(ctx context.Context, in *SetMaintenanceWindowRequest, opts ...grpc.CallOption) (*SetMaintenanceWindowResponse, error)
+	DeleteMaintenanceWindow(ctx context.Context, in *DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*DeleteMaintenanceWindowResponse, error)
+	SetPool(ctx context.Context, in *SetPoolRequest, opts ...grpc.CallOption) (*SetPoolResponse, error)
+	DeletePool(ctx context.Context, in *DeletePoolRequest, opts ...grpc.CallOption) (*DeletePoolResponse, error)
 }
 
 type dkronClient struct {
@@ -191,6 +203,66 @@ func (c *dkronClient) SetExecution(ctx context.Context, in *Execution, opts ...g
 	return out, nil
 }
 
+func (c *dkronClient) SetCalendar(ctx context.Context, in *SetCalendarRequest, opts ...grpc.CallOption) (*SetCalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCalendarResponse)
+	err := c.cc.Invoke(ctx, Dkron_SetCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeleteCalendar(ctx context.Context, in *DeleteCalendarRequest, opts ...grpc.CallOption) (*DeleteCalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCalendarResponse)
+	err := c.cc.Invoke(ctx, Dkron_DeleteCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetMaintenanceWindow(ctx context.Context, in *SetMaintenanceWindowRequest, opts ...grpc.CallOption) (*SetMaintenanceWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, Dkron_SetMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeleteMaintenanceWindow(ctx context.Context, in *DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*DeleteMaintenanceWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, Dkron_DeleteMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetPool(ctx context.Context, in *SetPoolRequest, opts ...grpc.CallOption) (*SetPoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPoolResponse)
+	err := c.cc.Invoke(ctx, Dkron_SetPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeletePool(ctx context.Context, in *DeletePoolRequest, opts ...grpc.CallOption) (*DeletePoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePoolResponse)
+	err := c.cc.Invoke(ctx, Dkron_DeletePool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DkronServer is the server API for Dkron service.
 // All implementations must embed UnimplementedDkronServer
 // for forward compatibility.
@@ -216,6 +288,12 @@ type DkronServer interface {
 	// buf:lint:ignore RPC_REQUEST_STANDARD_NAME
 	// buf:lint:ignore RPC_RESPONSE_STANDARD_NAME
 	SetExecution(context.Context, *Execution) (*emptypb.Empty, error)
+	SetCalendar(context.Context, *SetCalendarRequest) (*SetCalendarResponse, error)
+	DeleteCalendar(context.Context, *DeleteCalendarRequest) (*DeleteCalendarResponse, error)
+	SetMaintenanceWindow(context.Context, *SetMaintenanceWindowRequest) (*SetMaintenanceWindowResponse, error)
+	DeleteMaintenanceWindow(context.Context, *DeleteMaintenanceWindowRequest) (*DeleteMaintenanceWindowResponse, error)
+	SetPool(context.Context, *SetPoolRequest) (*SetPoolResponse, error)
+	DeletePool(context.Context, *DeletePoolRequest) (*DeletePoolResponse, error)
 	mustEmbedUnimplementedDkronServer()
 }
 
@@ -262,6 +340,24 @@ func (UnimplementedDkronServer) GetActiveExecutions(context.Context, *emptypb.Em
 func (UnimplementedDkronServer) SetExecution(context.Context, *Execution) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetExecution not implemented")
 }
+func (UnimplementedDkronServer) SetCalendar(context.Context, *SetCalendarRequest) (*SetCalendarResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCalendar not implemented")
+}
+func (UnimplementedDkronServer) DeleteCalendar(context.Context, *DeleteCalendarRequest) (*DeleteCalendarResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCalendar not implemented")
+}
+func (UnimplementedDkronServer) SetMaintenanceWindow(context.Context, *SetMaintenanceWindowRequest) (*SetMaintenanceWindowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMaintenanceWindow not implemented")
+}
+func (UnimplementedDkronServer) DeleteMaintenanceWindow(context.Context, *DeleteMaintenanceWindowRequest) (*DeleteMaintenanceWindowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMaintenanceWindow not implemented")
+}
+func (UnimplementedDkronServer) SetPool(context.Context, *SetPoolRequest) (*SetPoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPool not implemented")
+}
+func (UnimplementedDkronServer) DeletePool(context.Context, *DeletePoolRequest) (*DeletePoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePool not implemented")
+}
 func (UnimplementedDkronServer) mustEmbedUnimplementedDkronServer() {}
 func (UnimplementedDkronServer) testEmbeddedByValue()               {}
 
@@ -499,6 +595,114 @@ func _Dkron_SetExecution_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dkron_SetCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).SetCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dkron_SetCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).SetCalendar(ctx, req.(*SetCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dkron_DeleteCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).DeleteCalendar(ctx, req.(*DeleteCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_SetMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).SetMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dkron_SetMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).SetMaintenanceWindow(ctx, req.(*SetMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_DeleteMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).DeleteMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dkron_DeleteMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).DeleteMaintenanceWindow(ctx, req.(*DeleteMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_SetPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).SetPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dkron_SetPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).SetPool(ctx, req.(*SetPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_DeletePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).DeletePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dkron_DeletePool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).DeletePool(ctx, req.(*DeletePoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Dkron_ServiceDesc is the grpc.ServiceDesc for Dkron service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -554,6 +758,30 @@ var Dkron_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetExecution",
 			Handler:    _Dkron_SetExecution_Handler,
 		},
+		{
+			MethodName: "SetCalendar",
+			Handler:    _Dkron_SetCalendar_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _Dkron_DeleteCalendar_Handler,
+		},
+		{
+			MethodName: "SetMaintenanceWindow",
+			Handler:    _Dkron_SetMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "DeleteMaintenanceWindow",
+			Handler:    _Dkron_DeleteMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "SetPool",
+			Handler:    _Dkron_SetPool_Handler,
+		},
+		{
+			MethodName: "DeletePool",
+			Handler:    _Dkron_DeletePool_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "types/v1/dkron.proto",
@@ -28,6 +28,9 @@ type ExecuteRequest struct {
 	JobName       string                 `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
 	Config        map[string]string      `protobuf:"bytes,2,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	StatusServer  uint32                 `protobuf:"varint,3,opt,name=status_server,json=statusServer,proto3" json:"status_server,omitempty"`
+	Parent        *ParentExecution       `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
+	CaptureResult bool                   `protobuf:"varint,5,opt,name=capture_result,json=captureResult,proto3" json:"capture_result,omitempty"`
+	MapItem       *MapItem               `protobuf:"bytes,6,opt,name=map_item,json=mapItem,proto3" json:"map_item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,17 +86,178 @@ func (x *ExecuteRequest) GetStatusServer() uint32 {
 	return 0
 }
 
+func (x *ExecuteRequest) GetParent() *ParentExecution {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetCaptureResult() bool {
+	if x != nil {
+		return x.CaptureResult
+	}
+	return false
+}
+
+func (x *ExecuteRequest) GetMapItem() *MapItem {
+	if x != nil {
+		return x.MapItem
+	}
+	return nil
+}
+
+// ParentExecution is the execution of the parent job that triggered
+// the run of a dependent job.
+type ParentExecution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobName       string                 `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,2,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Group         int64                  `protobuf:"varint,3,opt,name=group,proto3" json:"group,omitempty"`
+	NodeName      string                 `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Success       bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	Result        []byte                 `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParentExecution) Reset() {
+	*x = ParentExecution{}
+	mi := &file_types_v1_executor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParentExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParentExecution) ProtoMessage() {}
+
+func (x *ParentExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_executor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParentExecution.ProtoReflect.Descriptor instead.
+func (*ParentExecution) Descriptor() ([]byte, []int) {
+	return file_types_v1_executor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParentExecution) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *ParentExecution) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *ParentExecution) GetGroup() int64 {
+	if x != nil {
+		return x.Group
+	}
+	return 0
+}
+
+func (x *ParentExecution) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *ParentExecution) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ParentExecution) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// MapItem is the item of a map job run by the execution.
+type MapItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          []byte                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Index         int64                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapItem) Reset() {
+	*x = MapItem{}
+	mi := &file_types_v1_executor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapItem) ProtoMessage() {}
+
+func (x *MapItem) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_executor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapItem.ProtoReflect.Descriptor instead.
+func (*MapItem) Descriptor() ([]byte, []int) {
+	return file_types_v1_executor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MapItem) GetItem() []byte {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *MapItem) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
 type ExecuteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Output        []byte                 `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Result        []byte                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExecuteResponse) Reset() {
 	*x = ExecuteResponse{}
-	mi := &file_types_v1_executor_proto_msgTypes[1]
+	mi := &file_types_v1_executor_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -105,7 +269,7 @@ func (x *ExecuteResponse) String() string {
 func (*ExecuteResponse) ProtoMessage() {}
 
 func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_executor_proto_msgTypes[1]
+	mi := &file_types_v1_executor_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +282,7 @@ func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_executor_proto_rawDescGZIP(), []int{1}
+	return file_types_v1_executor_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ExecuteResponse) GetOutput() []byte {
@@ -135,6 +299,13 @@ func (x *ExecuteResponse) GetError() string {
 	return ""
 }
 
+func (x *ExecuteResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
 type StatusUpdateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Output        []byte                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
@@ -145,7 +316,7 @@ type StatusUpdateRequest struct {
 
 func (x *StatusUpdateRequest) Reset() {
 	*x = StatusUpdateRequest{}
-	mi := &file_types_v1_executor_proto_msgTypes[2]
+	mi := &file_types_v1_executor_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -157,7 +328,7 @@ func (x *StatusUpdateRequest) String() string {
 func (*StatusUpdateRequest) ProtoMessage() {}
 
 func (x *StatusUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_executor_proto_msgTypes[2]
+	mi := &file_types_v1_executor_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -170,7 +341,7 @@ func (x *StatusUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusUpdateRequest.ProtoReflect.Descriptor instead.
 func (*StatusUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_executor_proto_rawDescGZIP(), []int{2}
+	return file_types_v1_executor_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *StatusUpdateRequest) GetOutput() []byte {
@@ -196,7 +367,7 @@ type StatusUpdateResponse struct {
 
 func (x *StatusUpdateResponse) Reset() {
 	*x = StatusUpdateResponse{}
-	mi := &file_types_v1_executor_proto_msgTypes[3]
+	mi := &file_types_v1_executor_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -208,7 +379,7 @@ func (x *StatusUpdateResponse) String() string {
 func (*StatusUpdateResponse) ProtoMessage() {}
 
 func (x *StatusUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_executor_proto_msgTypes[3]
+	mi := &file_types_v1_executor_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +392,7 @@ func (x *StatusUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusUpdateResponse.ProtoReflect.Descriptor instead.
 func (*StatusUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_executor_proto_rawDescGZIP(), []int{3}
+	return file_types_v1_executor_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *StatusUpdateResponse) GetR() int64 {
@@ -235,17 +406,31 @@ var File_types_v1_executor_proto protoreflect.FileDescriptor
 
 const file_types_v1_executor_proto_rawDesc = "" +
 	"\n" +
-	"\x17types/v1/executor.proto\x12\btypes.v1\"\xc9\x01\n" +
+	"\x17types/v1/executor.proto\x12\btypes.v1\"\xd1\x02\n" +
 	"\x0eExecuteRequest\x12\x19\n" +
 	"\bjob_name\x18\x01 \x01(\tR\ajobName\x12<\n" +
 	"\x06config\x18\x02 \x03(\v2$.types.v1.ExecuteRequest.ConfigEntryR\x06config\x12#\n" +
-	"\rstatus_server\x18\x03 \x01(\rR\fstatusServer\x1a9\n" +
+	"\rstatus_server\x18\x03 \x01(\rR\fstatusServer\x121\n" +
+	"\x06parent\x18\x04 \x01(\v2\x19.types.v1.ParentExecutionR\x06parent\x12%\n" +
+	"\x0ecapture_result\x18\x05 \x01(\bR\rcaptureResult\x12,\n" +
+	"\bmap_item\x18\x06 \x01(\v2\x11.types.v1.MapItemR\amapItem\x1a9\n" +
 	"\vConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb4\x01\n" +
+	"\x0fParentExecution\x12\x19\n" +
+	"\bjob_name\x18\x01 \x01(\tR\ajobName\x12!\n" +
+	"\fexecution_id\x18\x02 \x01(\tR\vexecutionId\x12\x14\n" +
+	"\x05group\x18\x03 \x01(\x03R\x05group\x12\x1b\n" +
+	"\tnode_name\x18\x04 \x01(\tR\bnodeName\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06result\x18\x06 \x01(\fR\x06result\"3\n" +
+	"\aMapItem\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\fR\x04item\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x03R\x05index\"W\n" +
 	"\x0fExecuteResponse\x12\x16\n" +
 	"\x06output\x18\x01 \x01(\fR\x06output\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"C\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\fR\x06result\"C\n" +
 	"\x13StatusUpdateRequest\x12\x16\n" +
 	"\x06output\x18\x02 \x01(\fR\x06output\x12\x14\n" +
 	"\x05error\x18\x03 \x01(\bR\x05error\"$\n" +
@@ -269,25 +454,29 @@ func file_types_v1_executor_proto_rawDescGZIP() []byte {
 	return file_types_v1_executor_proto_rawDescData
 }
 
-var file_types_v1_executor_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_types_v1_executor_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_types_v1_executor_proto_goTypes = []any{
 	(*ExecuteRequest)(nil),       // 0: types.v1.ExecuteRequest
-	(*ExecuteResponse)(nil),      // 1: types.v1.ExecuteResponse
-	(*StatusUpdateRequest)(nil),  // 2: types.v1.StatusUpdateRequest
-	(*StatusUpdateResponse)(nil), // 3: types.v1.StatusUpdateResponse
-	nil,                          // 4: types.v1.ExecuteRequest.ConfigEntry
+	(*ParentExecution)(nil),      // 1: types.v1.ParentExecution
+	(*MapItem)(nil),              // 2: types.v1.MapItem
+	(*ExecuteResponse)(nil),      // 3: types.v1.ExecuteResponse
+	(*StatusUpdateRequest)(nil),  // 4: types.v1.StatusUpdateRequest
+	(*StatusUpdateResponse)(nil), // 5: types.v1.StatusUpdateResponse
+	nil,                          // 6: types.v1.ExecuteRequest.ConfigEntry
 }
 var file_types_v1_executor_proto_depIdxs = []int32{
-	4, // 0: types.v1.ExecuteRequest.config:type_name -> types.v1.ExecuteRequest.ConfigEntry
-	0, // 1: types.v1.ExecutorService.Execute:input_type -> types.v1.ExecuteRequest
-	2, // 2: types.v1.StatusHelperService.Update:input_type -> types.v1.StatusUpdateRequest
-	1, // 3: types.v1.ExecutorService.Execute:output_type -> types.v1.ExecuteResponse
-	3, // 4: types.v1.StatusHelperService.Update:output_type -> types.v1.StatusUpdateResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6, // 0: types.v1.ExecuteRequest.config:type_name -> types.v1.ExecuteRequest.ConfigEntry
+	1, // 1: types.v1.ExecuteRequest.parent:type_name -> types.v1.ParentExecution
+	2, // 2: types.v1.ExecuteRequest.map_item:type_name -> types.v1.MapItem
+	0, // 3: types.v1.ExecutorService.Execute:input_type -> types.v1.ExecuteRequest
+	4, // 4: types.v1.StatusHelperService.Update:input_type -> types.v1.StatusUpdateRequest
+	3, // 5: types.v1.ExecutorService.Execute:output_type -> types.v1.ExecuteResponse
+	5, // 6: types.v1.StatusHelperService.Update:output_type -> types.v1.StatusUpdateResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_types_v1_executor_proto_init() }
@@ -301,7 +490,7 @@ func file_types_v1_executor_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_v1_executor_proto_rawDesc), len(file_types_v1_executor_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -18,6 +18,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/armon/circbuf"
@@ -70,7 +71,7 @@ func New() *HTTP {
 //	    "method": "GET",             // Request method in uppercase
 //	    "url": "http://example.com", // Request url
 //	    "headers": "[]"              // Json string, such as "[\"Content-Type: application/json\"]"
-//	    "body": "",                  // POST body
+//	    "body": "",                  // POST body, a template when run by a parent job
 //	    "timeout": "30",             // Request timeout, unit seconds
 //	    "expectCode": "200",         // Expect response code, such as 200,206
 //	    "expectBody": "",            // Expect response body, support regexp, such as /success/
@@ -103,7 +104,12 @@ func (s *HTTP) ExecuteImpl(args *types.ExecuteRequest) ([]byte, error) {
 		return output.Bytes(), errors.New("method is empty")
 	}
 
-	req, err := http.NewRequest(args.Config["method"], args.Config["url"], bytes.NewBuffer([]byte(args.Config["body"])))
+	body, err := renderBody(args)
+	if err != nil {
+		return output.Bytes(), err
+	}
+
+	req, err := http.NewRequest(args.Config["method"], args.Config["url"], bytes.NewBuffer(body))
 	if err != nil {
 		return output.Bytes(), err
 	}
@@ -197,6 +203,70 @@ func (s *HTTP) ExecuteImpl(args *types.ExecuteRequest) ([]byte, error) {
 	return output.Bytes(), nil
 }
 
+// parentData is the parent execution available to the body template.
+type parentData struct {
+	JobName     string
+	ExecutionID string
+	Group       int64
+	NodeName    string
+	Success     bool
+	Result      string
+}
+
+// bodyData is the data available to the body template.
+type bodyData struct {
+	JobName string
+	Parent  parentData
+
+	// Item of map jobs, decoded from JSON and in JSON, and its position.
+	Item     interface{}
+	ItemJSON string
+	Index    int64
+}
+
+// renderBody returns the request body. With bodyTemplate set the body is
+// a template with the job name, the parent execution of jobs run by a
+// parent job, such as {{ .Parent.Result }}, and the item of map jobs.
+func renderBody(args *types.ExecuteRequest) ([]byte, error) {
+	body := args.Config["body"]
+	tmplBody, err := strconv.ParseBool(args.Config["bodyTemplate"])
+	if args.Config["bodyTemplate"] != "" && err != nil {
+		return nil, fmt.Errorf("invalid bodyTemplate value: %w", err)
+	}
+	if !tmplBody {
+		return []byte(body), nil
+	}
+
+	data := bodyData{JobName: args.JobName}
+	if p := args.Parent; p != nil {
+		data.Parent = parentData{
+			JobName:     p.JobName,
+			ExecutionID: p.ExecutionId,
+			Group:       p.Group,
+			NodeName:    p.NodeName,
+			Success:     p.Success,
+			Result:      string(p.Result),
+		}
+	}
+	if m := args.MapItem; m != nil {
+		if err := json.Unmarshal(m.Item, &data.Item); err != nil {
+			return nil, fmt.Errorf("invalid map item: %w", err)
+		}
+		data.ItemJSON = string(m.Item)
+		data.Index = m.Index
+	}
+
+	tmpl, err := template.New("body").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing body template failed: %w", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("rendering body template failed: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
 // generateClientKey creates a unique key for the client pool based on configuration
 // This fixes the key collision issue by using proper hashing instead of string concatenation
 func (s *HTTP) generateClientKey(config map[string]string) string {
@@ -249,3 +249,73 @@ func TestClientPoolKeyCollisions(t *testing.T) {
 	// With the fix, different configs should create different clients
 	assert.Equal(t, 2, poolSize, "Proper key generation should prevent unintended client sharing")
 }
+
+func TestExecuteParentBody(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	config := map[string]string{
+		"method":     "POST",
+		"url":        fmt.Sprintf("%s/echo", ts.URL),
+		"body":       `{"job": "{{ .JobName }}", "parent": "{{ .Parent.JobName }}", "success": {{ .Parent.Success }}, "files": {{ .Parent.Result }}}`,
+		"expectCode": "200",
+	}
+
+	parent := &types.ParentExecution{
+		JobName: "find_files",
+		Success: true,
+		Result:  []byte(`["a.csv","b.csv"]`),
+	}
+
+	// Bodies are sent as is unless they are templates
+	got, _ := New().Execute(&types.ExecuteRequest{JobName: "process_files", Config: config, Parent: parent}, nil)
+	assert.Empty(t, got.Error)
+	assert.Equal(t, config["body"], string(got.Output))
+
+	config["bodyTemplate"] = "true"
+	got, _ = New().Execute(&types.ExecuteRequest{JobName: "process_files", Config: config, Parent: parent}, nil)
+	assert.Empty(t, got.Error)
+	assert.Equal(t, `{"job": "process_files", "parent": "find_files", "success": true, "files": ["a.csv","b.csv"]}`, string(got.Output))
+
+	// Jobs without parent get an empty one
+	config["body"] = `{"job": "{{ .JobName }}", "parent": "{{ .Parent.JobName }}"}`
+	got, _ = New().Execute(&types.ExecuteRequest{JobName: "process_files", Config: config}, nil)
+	assert.Empty(t, got.Error)
+	assert.Equal(t, `{"job": "process_files", "parent": ""}`, string(got.Output))
+
+	config["body"] = "{{ .Parent.Missing }}"
+	got, _ = New().Execute(&types.ExecuteRequest{JobName: "process_files", Config: config, Parent: parent}, nil)
+	assert.NotEmpty(t, got.Error)
+
+	config["bodyTemplate"] = "yes"
+	got, _ = New().Execute(&types.ExecuteRequest{JobName: "process_files", Config: config, Parent: parent}, nil)
+	assert.NotEmpty(t, got.Error)
+}
+
+func TestExecuteMapItemBody(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	config := map[string]string{
+		"method":       "POST",
+		"url":          fmt.Sprintf("%s/echo", ts.URL),
+		"body":         `{"parent": "{{ .Parent.JobName }}", "tenant": "{{ .Item.tenant }}", "item": {{ .ItemJSON }}, "index": {{ .Index }}}`,
+		"bodyTemplate": "true",
+		"expectCode":   "200",
+	}
+	req := &types.ExecuteRequest{
+		JobName: "bill_tenant",
+		Config:  config,
+		Parent:  &types.ParentExecution{JobName: "list_tenants"},
+		MapItem: &types.MapItem{Item: []byte(`{"tenant": "{{ acme }}"}`), Index: 2},
+	}
+
+	// Items are data of the template, they are not rendered
+	got, _ := New().Execute(req, nil)
+	assert.Empty(t, got.Error)
+	assert.Equal(t, `{"parent": "list_tenants", "tenant": "{{ acme }}", "item": {"tenant": "{{ acme }}"}, "index": 2}`, string(got.Output))
+
+	req.MapItem.Item = []byte(`{"tenant"`)
+	got, _ = New().Execute(req, nil)
+	assert.NotEmpty(t, got.Error)
+}
@@ -6,6 +6,10 @@ import (
 
 // See serve.go for serving plugins
 
+// MaxResultSize limits the size of the result an executor can emit for
+// the dependent jobs of the job.
+const MaxResultSize = 64 * 1024
+
 // PluginMap should be used by clients for the map of plugins.
 var PluginMap = map[string]plugin.Plugin{
 	"processor": &ProcessorPlugin{},
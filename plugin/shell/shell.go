@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
@@ -44,8 +45,27 @@ type Shell struct{}
 
 // Execute method of the plugin
 func (s *Shell) Execute(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelper) (*dktypes.ExecuteResponse, error) {
-	out, err := s.ExecuteImpl(args, cb)
-	resp := &dktypes.ExecuteResponse{Output: out}
+	resp := &dktypes.ExecuteResponse{}
+
+	// The command can write the result for the dependent jobs to a file,
+	// created only for jobs with dependents or opting in
+	var resultFile string
+	if capture, _ := strconv.ParseBool(args.Config["capture_result"]); args.CaptureResult || capture {
+		f, err := os.CreateTemp("", "dkron-result-*")
+		if err != nil {
+			resp.Error = err.Error()
+			return resp, nil
+		}
+		f.Close()
+		resultFile = f.Name()
+		defer os.Remove(resultFile)
+	}
+
+	out, err := s.execute(args, cb, resultFile)
+	resp.Output = out
+	if err == nil && resultFile != "" {
+		resp.Result, err = readResult(resultFile)
+	}
 	if err != nil {
 		resp.Error = err.Error()
 	}
@@ -54,6 +74,12 @@ func (s *Shell) Execute(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelper)
 
 // ExecuteImpl do execute command
 func (s *Shell) ExecuteImpl(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelper) ([]byte, error) {
+	return s.execute(args, cb, "")
+}
+
+// execute runs the command, exporting the path of the result file, if any,
+// and the parent execution that triggered the job to its environment.
+func (s *Shell) execute(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelper, resultFile string) ([]byte, error) {
 	output, _ := circbuf.NewBuffer(maxBufSize)
 
 	shell, err := strconv.ParseBool(args.Config["shell"])
@@ -66,6 +92,10 @@ func (s *Shell) ExecuteImpl(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelp
 
 	executionInfo := strings.Split(fmt.Sprintf("ENV_JOB_NAME=%s", args.JobName), ",")
 	env = append(env, executionInfo...)
+	if resultFile != "" {
+		env = append(env, "DKRON_RESULT_FILE="+resultFile)
+	}
+	env = append(env, parentEnv(args.Parent)...)
 
 	cmd, err := buildCmd(command, shell, env, cwd)
 	if err != nil {
@@ -213,6 +243,41 @@ func (s *Shell) ExecuteImpl(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelp
 	return output.Bytes(), err
 }
 
+// parentEnv returns the environment variables describing the parent
+// execution that triggered the job.
+func parentEnv(p *dktypes.ParentExecution) []string {
+	if p == nil {
+		return nil
+	}
+	return []string{
+		"DKRON_PARENT_JOB=" + p.JobName,
+		"DKRON_PARENT_EXECUTION_ID=" + p.ExecutionId,
+		"DKRON_PARENT_GROUP=" + strconv.FormatInt(p.Group, 10),
+		"DKRON_PARENT_NODE=" + p.NodeName,
+		"DKRON_PARENT_SUCCESS=" + strconv.FormatBool(p.Success),
+		"DKRON_PARENT_RESULT=" + string(p.Result),
+	}
+}
+
+// readResult returns the result written by the command, failing if it
+// exceeds the size allowed for results.
+func readResult(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result, err := io.ReadAll(io.LimitReader(f, dkplugin.MaxResultSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(result) > dkplugin.MaxResultSize {
+		return nil, fmt.Errorf("shell: Result exceeds the limit of %d bytes", dkplugin.MaxResultSize)
+	}
+	return result, nil
+}
+
 // Determine the shell invocation based on OS
 func buildCmd(command string, useShell bool, env []string, cwd string) (cmd *exec.Cmd, err error) {
 	var shell, flag string
@@ -428,3 +428,54 @@ func Test_parseMemoryLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestExecute_ParentAndResult(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping result file test on Windows")
+	}
+
+	s := &Shell{}
+	args := &dktypes.ExecuteRequest{
+		JobName: "process_files",
+		Config: map[string]string{
+			"command": `echo "$DKRON_PARENT_JOB $DKRON_PARENT_GROUP $DKRON_PARENT_NODE $DKRON_PARENT_SUCCESS $DKRON_PARENT_RESULT" && printf '{"processed": 2}' > "$DKRON_RESULT_FILE"`,
+			"shell":   "true",
+		},
+		Parent: &dktypes.ParentExecution{
+			JobName:  "find_files",
+			Group:    1700000000,
+			NodeName: "node1",
+			Success:  true,
+			Result:   []byte(`["a.csv","b.csv"]`),
+		},
+		CaptureResult: true,
+	}
+
+	resp, err := s.Execute(args, &MockStatusHelper{})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, "find_files 1700000000 node1 true [\"a.csv\",\"b.csv\"]\n", string(resp.Output))
+	assert.Equal(t, `{"processed": 2}`, string(resp.Result))
+
+	// Results are bounded
+	args.Config["command"] = `head -c 70000 /dev/zero > "$DKRON_RESULT_FILE"`
+	resp, err = s.Execute(args, &MockStatusHelper{})
+	assert.NoError(t, err)
+	assert.Contains(t, resp.Error, "exceeds the limit")
+	assert.Empty(t, resp.Result)
+
+	// Jobs without dependents get no result file unless they opt in
+	args.CaptureResult = false
+	args.Config["command"] = `echo "file: $DKRON_RESULT_FILE"`
+	resp, err = s.Execute(args, &MockStatusHelper{})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, "file: \n", string(resp.Output))
+
+	args.Config["capture_result"] = "true"
+	args.Config["command"] = `printf done > "$DKRON_RESULT_FILE"`
+	resp, err = s.Execute(args, &MockStatusHelper{})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, "done", string(resp.Result))
+}